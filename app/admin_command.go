@@ -0,0 +1,210 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/model"
+)
+
+// usersOf returns the given user, or every user when none is given.
+func usersOf(ctx context.Context, admin *classroom.AdminService, user string) ([]string, error) {
+	if user != "" {
+		return []string{user}, nil
+	}
+	return admin.ListUsers(ctx)
+}
+
+func runStats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	user := flags.String("user", "", "only show this user (default: all users)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var admin *classroom.AdminService
+	return runWith(func(ctx context.Context) error {
+		userIds, err := usersOf(ctx, admin, *user)
+		if err != nil {
+			return fmt.Errorf("stats: %w", err)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "user\tvocab\tforms\tsets\tpool\tdue\tpaused\t")
+
+		total := model.UserStats{UserId: fmt.Sprintf("total (%d users)", len(userIds))}
+		for _, userId := range userIds {
+			stats, err := admin.Stats(ctx, userId)
+			if err != nil {
+				return fmt.Errorf("stats: %w", err)
+			}
+			printStatsRow(tw, stats)
+			total.Add(stats)
+		}
+		if len(userIds) > 1 {
+			printStatsRow(tw, total)
+		}
+		tw.Flush()
+
+		fmt.Println()
+		for _, level := range slices.Sorted(maps.Keys(total.ByLevel)) {
+			fmt.Printf("level %d: %d vocab\n", level, total.ByLevel[level])
+		}
+		for _, pos := range slices.Sorted(maps.Keys(total.ByPartOfSpeech)) {
+			name := string(pos)
+			if name == "" {
+				name = "(none)"
+			}
+			fmt.Printf("%s: %d vocab\n", name, total.ByPartOfSpeech[pos])
+		}
+		return nil
+	}, fx.Populate(&admin))
+}
+
+func printStatsRow(tw *tabwriter.Writer, stats model.UserStats) {
+	fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+		stats.UserId, stats.Vocab, stats.Forms, stats.Sets, stats.PoolSize, stats.Due, stats.Paused)
+}
+
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	user := flags.String("user", "", "only migrate this user (default: all users)")
+	dryRun := flags.Bool("dry-run", false, "only report what would change")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var admin *classroom.AdminService
+	return runWith(func(ctx context.Context) error {
+		userIds, err := usersOf(ctx, admin, *user)
+		if err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+
+		for _, userId := range userIds {
+			report, err := admin.Repair(ctx, userId, *dryRun)
+			if err != nil {
+				return fmt.Errorf("migrate: %w", err)
+			}
+			fmt.Printf("%s: %d vocab, %d missing ids, %d stale dates, %d reindexed, "+
+				"%d dangling set entries, %d dangling pool entries\n",
+				userId, report.Vocab, report.MissingIds, report.StaleDates, report.Reindexed,
+				report.DanglingSetIds, report.DanglingPool)
+		}
+		if *dryRun {
+			fmt.Println("dry run, nothing was written")
+			return nil
+		}
+		// With every user repaired, the services can stop falling back to
+		// scanning for data written before the migration.
+		if *user == "" {
+			if err := admin.MarkMigrated(ctx); err != nil {
+				return fmt.Errorf("migrate: %w", err)
+			}
+			fmt.Printf("data version %d recorded\n", classroom.DataVersion)
+		}
+		return nil
+	}, fx.Populate(&admin))
+}
+
+func runUser(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("user: expected list, delete or role")
+	}
+
+	switch args[0] {
+	case "list":
+		return runUserList(args[1:])
+	case "delete":
+		return runUserDelete(args[1:])
+	case "role":
+		return runUserRole(args[1:])
+	default:
+		return fmt.Errorf("user: unknown command %q, expected list, delete or role", args[0])
+	}
+}
+
+func runUserList(args []string) error {
+	flags := flag.NewFlagSet("user list", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var admin *classroom.AdminService
+	return runWith(func(ctx context.Context) error {
+		userIds, err := admin.ListUsers(ctx)
+		if err != nil {
+			return fmt.Errorf("user list: %w", err)
+		}
+		for _, userId := range userIds {
+			fmt.Println(userId)
+		}
+		return nil
+	}, fx.Populate(&admin))
+}
+
+func runUserDelete(args []string) error {
+	flags := flag.NewFlagSet("user delete", flag.ContinueOnError)
+	user := flags.String("user", "", "id of the user to delete")
+	yes := flags.Bool("yes", false, "do not ask for confirmation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("user delete", map[string]string{"user": *user}); err != nil {
+		return err
+	}
+
+	if !*yes {
+		fmt.Printf("Delete all data of user %s? Type the user id to confirm: ", *user)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != *user {
+			return fmt.Errorf("user delete: not confirmed")
+		}
+	}
+
+	var account *classroom.AccountService
+	return runWith(func(ctx context.Context) error {
+		if err := account.Delete(ctx, *user); err != nil {
+			return fmt.Errorf("user delete: %w", err)
+		}
+		fmt.Printf("deleted user %s\n", *user)
+		return nil
+	}, fx.Populate(&account))
+}
+
+func runUserRole(args []string) error {
+	flags := flag.NewFlagSet("user role", flag.ContinueOnError)
+	user := flags.String("user", "", "id of the user")
+	role := flags.String("role", "", "role to set: learner, teacher or admin; shows the role if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("user role", map[string]string{"user": *user}); err != nil {
+		return err
+	}
+
+	var roles *classroom.RoleService
+	return runWith(func(ctx context.Context) error {
+		if *role != "" {
+			if err := roles.SetRole(ctx, *user, model.Role(*role)); err != nil {
+				return fmt.Errorf("user role: %w", err)
+			}
+		}
+		current, err := roles.GetRole(ctx, *user)
+		if err != nil {
+			return fmt.Errorf("user role: %w", err)
+		}
+		fmt.Printf("%s: %s\n", *user, current)
+		return nil
+	}, fx.Populate(&roles))
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+type APIKey struct {
+	Id         string    `json:"id"`
+	UserId     string    `json:"user_id"`
+	Name       string    `json:"name"`
+	Scopes     []string  `json:"scopes"`
+	RateLimit  int       `json:"rate_limit"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+func FromAPIKey(k model.APIKey) APIKey {
+	return APIKey{
+		Id:     k.Id,
+		UserId: k.UserId,
+		Name:   k.Name,
+		Scopes: mapSlice(k.Scopes, func(s model.APIKeyScope) string {
+			return string(s)
+		}),
+		RateLimit:  k.RateLimit,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+	}
+}
+
+func FromAPIKeys(ks []model.APIKey) []APIKey {
+	return mapSlice(ks, FromAPIKey)
+}
+
+// CreatedAPIKey is the only response carrying the key itself.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+func FromCreatedAPIKey(k model.CreatedAPIKey) CreatedAPIKey {
+	return CreatedAPIKey{APIKey: FromAPIKey(k.APIKey), Key: k.Key}
+}
+
+type UserStats struct {
+	UserId         string         `json:"user_id"`
+	Vocab          int            `json:"vocab"`
+	Forms          int            `json:"forms"`
+	Sets           int            `json:"sets"`
+	PoolSize       int            `json:"pool_size"`
+	Due            int            `json:"due"`
+	Paused         int            `json:"paused"`
+	ByLevel        map[int]int    `json:"by_level"`
+	ByPartOfSpeech map[string]int `json:"by_part_of_speech"`
+}
+
+func FromUserStats(s model.UserStats) UserStats {
+	var byPartOfSpeech map[string]int
+	if s.ByPartOfSpeech != nil {
+		byPartOfSpeech = make(map[string]int, len(s.ByPartOfSpeech))
+		for pos, n := range s.ByPartOfSpeech {
+			byPartOfSpeech[string(pos)] = n
+		}
+	}
+
+	return UserStats{
+		UserId:         s.UserId,
+		Vocab:          s.Vocab,
+		Forms:          s.Forms,
+		Sets:           s.Sets,
+		PoolSize:       s.PoolSize,
+		Due:            s.Due,
+		Paused:         s.Paused,
+		ByLevel:        s.ByLevel,
+		ByPartOfSpeech: byPartOfSpeech,
+	}
+}
+
+type RepairReport struct {
+	UserId         string `json:"user_id"`
+	DryRun         bool   `json:"dry_run"`
+	Vocab          int    `json:"vocab"`
+	MissingIds     int    `json:"missing_ids"`
+	StaleDates     int    `json:"stale_dates"`
+	Reindexed      int    `json:"reindexed"`
+	DanglingSetIds int    `json:"dangling_set_ids"`
+	DanglingPool   int    `json:"dangling_pool"`
+}
+
+func FromRepairReport(r model.RepairReport) RepairReport {
+	return RepairReport{
+		UserId:         r.UserId,
+		DryRun:         r.DryRun,
+		Vocab:          r.Vocab,
+		MissingIds:     r.MissingIds,
+		StaleDates:     r.StaleDates,
+		Reindexed:      r.Reindexed,
+		DanglingSetIds: r.DanglingSetIds,
+		DanglingPool:   r.DanglingPool,
+	}
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+type Class struct {
+	Id         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	TeacherId  string    `json:"teacher_id"`
+	InviteCode string    `json:"invite_code,omitempty"`
+	StudentIds []string  `json:"student_ids,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func FromClass(c model.Class) Class {
+	return Class{
+		Id:         c.Id,
+		Name:       c.Name,
+		TeacherId:  c.TeacherId,
+		InviteCode: c.InviteCode,
+		StudentIds: c.StudentIds,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func FromClasses(cs []model.Class) []Class {
+	return mapSlice(cs, FromClass)
+}
+
+type Assignment struct {
+	Id         uuid.UUID                 `json:"id"`
+	ClassId    uuid.UUID                 `json:"class_id"`
+	SetId      uuid.UUID                 `json:"set_id"`
+	Name       string                    `json:"name"`
+	DueAt      time.Time                 `json:"due_at"`
+	VocabCount int                       `json:"vocab_count"`
+	Vocabs     []Vocab                   `json:"vocabs,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	Copies     map[string]AssignmentCopy `json:"copies,omitempty"`
+}
+
+type AssignmentCopy struct {
+	SetId    uuid.UUID   `json:"set_id"`
+	VocabIds []uuid.UUID `json:"vocab_ids"`
+}
+
+func FromAssignment(a model.Assignment) Assignment {
+	var copies map[string]AssignmentCopy
+	if a.Copies != nil {
+		copies = make(map[string]AssignmentCopy, len(a.Copies))
+		for studentId, c := range a.Copies {
+			copies[studentId] = AssignmentCopy{SetId: c.SetId, VocabIds: c.VocabIds}
+		}
+	}
+
+	return Assignment{
+		Id:         a.Id,
+		ClassId:    a.ClassId,
+		SetId:      a.SetId,
+		Name:       a.Name,
+		DueAt:      a.DueAt,
+		VocabCount: a.VocabCount,
+		Vocabs:     FromVocabs(a.Vocabs),
+		CreatedAt:  a.CreatedAt,
+		Copies:     copies,
+	}
+}
+
+func FromAssignments(as []model.Assignment) []Assignment {
+	return mapSlice(as, FromAssignment)
+}
+
+type AssignmentProgress struct {
+	AssignmentId uuid.UUID `json:"assignment_id"`
+	Forms        int       `json:"forms"`
+	Learned      int       `json:"learned"`
+	AverageLevel float64   `json:"average_level"`
+	Percent      int       `json:"percent"`
+	Overdue      bool      `json:"overdue"`
+}
+
+type StudentProgress struct {
+	StudentId   string               `json:"student_id"`
+	Assignments []AssignmentProgress `json:"assignments"`
+}
+
+type ClassDashboard struct {
+	Class       Class             `json:"class"`
+	Assignments []Assignment      `json:"assignments"`
+	Students    []StudentProgress `json:"students"`
+}
+
+func FromClassDashboard(d model.ClassDashboard) ClassDashboard {
+	return ClassDashboard{
+		Class:       FromClass(d.Class),
+		Assignments: FromAssignments(d.Assignments),
+		Students: mapSlice(d.Students, func(s model.StudentProgress) StudentProgress {
+			return StudentProgress{
+				StudentId: s.StudentId,
+				Assignments: mapSlice(s.Assignments, func(p model.AssignmentProgress) AssignmentProgress {
+					return AssignmentProgress{
+						AssignmentId: p.AssignmentId,
+						Forms:        p.Forms,
+						Learned:      p.Learned,
+						AverageLevel: p.AverageLevel,
+						Percent:      p.Percent,
+						Overdue:      p.Overdue,
+					}
+				}),
+			}
+		}),
+	}
+}
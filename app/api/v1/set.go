@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+type VocabSet struct {
+	Id       uuid.UUID   `json:"id"`
+	Name     string      `json:"name"`
+	VocabIds []uuid.UUID `json:"vocab_ids"`
+}
+
+func FromVocabSet(s model.VocabSet) VocabSet {
+	return VocabSet{Id: s.Id, Name: s.Name, VocabIds: s.VocabIds}
+}
+
+func FromVocabSets(ss []model.VocabSet) []VocabSet {
+	return mapSlice(ss, FromVocabSet)
+}
+
+type PublishedSet struct {
+	Id          string    `json:"id"`
+	OwnerId     string    `json:"owner_id,omitempty"`
+	SourceSetId uuid.UUID `json:"source_set_id,omitempty"`
+	Name        string    `json:"name"`
+	VocabCount  int       `json:"vocab_count"`
+	Vocabs      []Vocab   `json:"vocabs,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func FromPublishedSet(s model.PublishedSet) PublishedSet {
+	return PublishedSet{
+		Id:          s.Id,
+		OwnerId:     s.OwnerId,
+		SourceSetId: s.SourceSetId,
+		Name:        s.Name,
+		VocabCount:  s.VocabCount,
+		Vocabs:      FromVocabs(s.Vocabs),
+		PublishedAt: s.PublishedAt,
+	}
+}
+
+func FromPublishedSets(ss []model.PublishedSet) []PublishedSet {
+	return mapSlice(ss, FromPublishedSet)
+}
+
+type CloneResult struct {
+	Set     VocabSet `json:"set"`
+	Created int      `json:"created"`
+	Merged  int      `json:"merged"`
+}
+
+func FromCloneResult(r model.CloneResult) CloneResult {
+	return CloneResult{Set: FromVocabSet(r.Set), Created: r.Created, Merged: r.Merged}
+}
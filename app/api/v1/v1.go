@@ -0,0 +1,19 @@
+// Package v1 holds the request and response bodies of the /v1 API. They
+// mirror the model types but are kept apart from them, so a change to how
+// the model is stored cannot change the JSON that deployed clients parse.
+// Changing any of these types in a breaking way needs a new API version.
+// Account archives are not covered, they carry a version of their own.
+package v1
+
+// mapSlice converts every element of in, keeping nil slices nil so they
+// encode the same way.
+func mapSlice[T, U any](in []T, f func(T) U) []U {
+	if in == nil {
+		return nil
+	}
+	out := make([]U, len(in))
+	for i, v := range in {
+		out[i] = f(v)
+	}
+	return out
+}
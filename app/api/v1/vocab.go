@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/transfer"
+)
+
+type Vocab struct {
+	Id           uuid.UUID   `json:"id"`
+	Definition   string      `json:"definition"`
+	PartOfSpeech string      `json:"part_of_speech"`
+	Forms        []VocabForm `json:"forms"`
+	PausedUntil  *time.Time  `json:"pause_until,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+	DueAt        time.Time   `json:"due_at"`
+}
+
+func FromVocab(v model.Vocab) Vocab {
+	return Vocab{
+		Id:           v.Id,
+		Definition:   v.Definition,
+		PartOfSpeech: string(v.PartOfSpeech),
+		Forms:        mapSlice(v.Forms, FromVocabForm),
+		PausedUntil:  v.PausedUntil,
+		CreatedAt:    v.CreatedAt,
+		DueAt:        v.DueAt,
+	}
+}
+
+func FromVocabs(vs []model.Vocab) []Vocab {
+	return mapSlice(vs, FromVocab)
+}
+
+func (v Vocab) ToModel() model.Vocab {
+	return model.Vocab{
+		Id:           v.Id,
+		Definition:   v.Definition,
+		PartOfSpeech: model.PartOfSpeech(v.PartOfSpeech),
+		Forms:        mapSlice(v.Forms, VocabForm.ToModel),
+		PausedUntil:  v.PausedUntil,
+		CreatedAt:    v.CreatedAt,
+		DueAt:        v.DueAt,
+	}
+}
+
+func ToVocabs(vs []Vocab) []model.Vocab {
+	return mapSlice(vs, Vocab.ToModel)
+}
+
+type VocabForm struct {
+	Id           uuid.UUID `json:"id"`
+	Value        string    `json:"value"`
+	Form         string    `json:"form"`
+	Level        int       `json:"level"`
+	LastSuccess  time.Time `json:"last_success"`
+	SuccessInRow int       `json:"success_in_row"`
+}
+
+func FromVocabForm(f model.VocabForm) VocabForm {
+	return VocabForm{
+		Id:           f.Id,
+		Value:        f.Value,
+		Form:         f.Form,
+		Level:        f.Level,
+		LastSuccess:  f.LastSuccess,
+		SuccessInRow: f.SuccessInRow,
+	}
+}
+
+func (f VocabForm) ToModel() model.VocabForm {
+	return model.VocabForm{
+		Id:           f.Id,
+		Value:        f.Value,
+		Form:         f.Form,
+		Level:        f.Level,
+		LastSuccess:  f.LastSuccess,
+		SuccessInRow: f.SuccessInRow,
+	}
+}
+
+type Batch struct {
+	Vocabs []Vocab `json:"vocabs"`
+}
+
+func FromBatch(b *model.Batch) Batch {
+	return Batch{Vocabs: FromVocabs(b.Vocabs)}
+}
+
+type BulkResult struct {
+	Index  int       `json:"index"`
+	Id     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+func FromBulkResults(rs []model.BulkResult) []BulkResult {
+	return mapSlice(rs, func(r model.BulkResult) BulkResult {
+		return BulkResult{Index: r.Index, Id: r.Id, Status: string(r.Status), Reason: r.Reason}
+	})
+}
+
+type ImportReport struct {
+	DryRun   bool           `json:"dry_run"`
+	Created  int            `json:"created"`
+	Merged   int            `json:"merged"`
+	Rejected int            `json:"rejected"`
+	Results  []ImportResult `json:"results"`
+}
+
+type ImportResult struct {
+	Index  int       `json:"index"`
+	Line   int       `json:"line,omitempty"`
+	Id     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+func FromImportReport(r transfer.ImportReport) ImportReport {
+	return ImportReport{
+		DryRun:   r.DryRun,
+		Created:  r.Created,
+		Merged:   r.Merged,
+		Rejected: r.Rejected,
+		Results: mapSlice(r.Results, func(r model.ImportResult) ImportResult {
+			return ImportResult{Index: r.Index, Line: r.Line, Id: r.Id, Status: string(r.Status), Reason: r.Reason}
+		}),
+	}
+}
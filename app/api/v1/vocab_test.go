@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestVocab(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	vocab := model.Vocab{
+		Id:           uuid.New(),
+		Definition:   "house",
+		PartOfSpeech: model.PartOfSpeechNoun,
+		Forms: []model.VocabForm{{
+			Id:           uuid.New(),
+			Value:        "et hus",
+			Form:         string(model.NounFormIndefiniteSingular),
+			Level:        2,
+			LastSuccess:  now,
+			SuccessInRow: 3,
+		}},
+		PausedUntil: &now,
+		CreatedAt:   now,
+		DueAt:       now.Add(time.Hour),
+	}
+
+	require.Equal(t, vocab, FromVocab(vocab).ToModel())
+
+	// The first version matches the JSON served before versioning.
+	modelJSON, err := json.Marshal(vocab)
+	require.NoError(t, err)
+	dtoJSON, err := json.Marshal(FromVocab(vocab))
+	require.NoError(t, err)
+	require.JSONEq(t, string(modelJSON), string(dtoJSON))
+
+	require.Nil(t, FromVocabs(nil))
+	require.Nil(t, ToVocabs(nil))
+}
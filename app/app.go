@@ -1,25 +1,46 @@
 package app
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
 	"go.uber.org/fx"
 
 	"github.com/vladazn/danish/app/classroom"
-	"github.com/vladazn/danish/app/server"
+	"github.com/vladazn/danish/app/metrics"
 	"github.com/vladazn/danish/app/storage"
+	"github.com/vladazn/danish/app/tracing"
 	"github.com/vladazn/danish/common/logger"
 	"github.com/vladazn/danish/common/rand"
 	"github.com/vladazn/danish/config"
 )
 
+// core holds the modules shared by the server and the command-line modes.
+var core = fx.Options(
+	config.Module,
+	fx.Provide(
+		rand.New,
+		logger.NewLogger,
+	),
+	metrics.Module,
+	tracing.Module,
+	storage.Module,
+	classroom.Module,
+)
+
+// Run starts the server, or runs a command when one is named on the
+// command line.
 func Run() {
-	fx.New(
-		config.Module,
-		fx.Provide(
-			rand.New,
-			logger.NewLogger,
-		),
-		storage.Module,
-		classroom.Module,
-		server.Module,
-	).Run()
+	name, args := "serve", []string(nil)
+	if len(os.Args) > 1 {
+		name, args = os.Args[1], os.Args[2:]
+	}
+
+	err := runCommand(name, args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
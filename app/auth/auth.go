@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Token is a verified bearer token.
+type Token struct {
+	UID string
+	// Claims holds all claims of the token, custom ones included.
+	Claims map[string]any
+}
+
+// TokenVerifier checks bearer tokens of incoming requests.
+type TokenVerifier interface {
+	Verify(ctx context.Context, rawToken string) (*Token, error)
+}
+
+// Pinger is implemented by verifiers that depend on a remote provider, to
+// check that the provider can be reached.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	firebase "firebase.google.com/go/v4"
+	fbauth "firebase.google.com/go/v4/auth"
+	"go.uber.org/fx"
+	"google.golang.org/api/option"
+
+	"github.com/vladazn/danish/config"
+)
+
+type FirebaseParams struct {
+	fx.In
+	Cfg *config.FirebaseConfig
+}
+
+// FirebaseClient verifies Firebase ID tokens.
+type FirebaseClient struct {
+	cfg *config.FirebaseConfig
+	app *firebase.App
+}
+
+func (c *FirebaseClient) Connect(ctx context.Context) error {
+	app, err := firebase.NewApp(ctx,
+		&firebase.Config{ProjectID: c.cfg.ProjectId},
+		option.WithCredentialsJSON([]byte(c.cfg.AuthJSON.Value())),
+	)
+	if err != nil {
+		return err
+	}
+	c.app = app
+
+	return nil
+}
+
+func NewFirebaseClient(p FirebaseParams) *FirebaseClient {
+	return &FirebaseClient{
+		cfg: p.Cfg,
+	}
+}
+
+func (c *FirebaseClient) Verify(ctx context.Context, rawToken string) (*Token, error) {
+	if c.app == nil {
+		return nil, errors.New("firebase client is not connected")
+	}
+
+	authClient, err := c.app.Auth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase auth: %w", err)
+	}
+
+	token, err := authClient.VerifyIDToken(ctx, rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return &Token{UID: token.UID, Claims: token.Claims}, nil
+}
+
+// Ping checks that Firebase Auth can be reached with the credentials by
+// looking up a user that does not exist.
+func (c *FirebaseClient) Ping(ctx context.Context) error {
+	if c.app == nil {
+		return errors.New("firebase client is not connected")
+	}
+
+	authClient, err := c.app.Auth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize firebase auth: %w", err)
+	}
+
+	_, err = authClient.GetUser(ctx, "health-check")
+	if err != nil && !fbauth.IsUserNotFound(err) {
+		return fmt.Errorf("failed to reach firebase auth: %w", err)
+	}
+	return nil
+}
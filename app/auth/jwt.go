@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/vladazn/danish/config"
+)
+
+// JWTVerifier verifies tokens minted by a JWTIssuer with the same config.
+type JWTVerifier struct {
+	method jwt.SigningMethod
+	key    any
+	issuer string
+}
+
+func NewJWTVerifier(cfg config.JWTConfig) (*JWTVerifier, error) {
+	method, err := signingMethod(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &JWTVerifier{method: method, issuer: cfg.Issuer}
+	switch method {
+	case jwt.SigningMethodHS256:
+		v.key, err = hmacSecret(cfg)
+	case jwt.SigningMethodRS256:
+		v.key, err = rsaPublicKey(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (v *JWTVerifier) Verify(_ context.Context, rawToken string) (*Token, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(*jwt.Token) (any, error) {
+		return v.key, nil
+	}, jwt.WithValidMethods([]string{v.method.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if !claims.VerifyIssuer(v.issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+
+	uid, _ := claims["sub"].(string)
+	if uid == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return &Token{UID: uid, Claims: claims}, nil
+}
+
+// JWTIssuer mints tokens for development and tests.
+type JWTIssuer struct {
+	method jwt.SigningMethod
+	key    any
+	issuer string
+	ttl    time.Duration
+}
+
+func NewJWTIssuer(cfg config.JWTConfig) (*JWTIssuer, error) {
+	method, err := signingMethod(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &JWTIssuer{method: method, issuer: cfg.Issuer, ttl: cfg.TTL}
+	switch method {
+	case jwt.SigningMethodHS256:
+		i.key, err = hmacSecret(cfg)
+	case jwt.SigningMethodRS256:
+		i.key, err = rsaPrivateKey(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// Mint returns a token for the user with the given custom claims, valid
+// for ttl or the configured TTL if ttl is 0.
+func (i *JWTIssuer) Mint(uid string, customClaims map[string]any, ttl time.Duration) (string, error) {
+	if uid == "" {
+		return "", errors.New("uid is required")
+	}
+	if ttl == 0 {
+		ttl = i.ttl
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{}
+	for name, value := range customClaims {
+		claims[name] = value
+	}
+	claims["iss"] = i.issuer
+	claims["sub"] = uid
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+
+	token, err := jwt.NewWithClaims(i.method, claims).SignedString(i.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, nil
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		return jwt.SigningMethodHS256, nil
+	case jwt.SigningMethodRS256.Alg():
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %q, expected HS256 or RS256", algorithm)
+	}
+}
+
+func hmacSecret(cfg config.JWTConfig) ([]byte, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("a jwt secret is required for HS256")
+	}
+	return []byte(cfg.Secret.Value()), nil
+}
+
+func rsaPrivateKey(cfg config.JWTConfig) (*rsa.PrivateKey, error) {
+	if cfg.PrivateKeyFile == "" {
+		return nil, errors.New("a private key file is required to sign RS256 tokens")
+	}
+
+	data, err := os.ReadFile(cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return key, nil
+}
+
+// rsaPublicKey reads the public key, or derives it from the private key
+// when only that is configured.
+func rsaPublicKey(cfg config.JWTConfig) (*rsa.PublicKey, error) {
+	if cfg.PublicKeyFile == "" {
+		key, err := rsaPrivateKey(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("no public key file configured: %w", err)
+		}
+		return &key.PublicKey, nil
+	}
+
+	data, err := os.ReadFile(cfg.PublicKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/config"
+)
+
+func TestJWT_HS256(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.JWTConfig{Algorithm: "HS256", Secret: "secret", Issuer: "test", TTL: time.Hour}
+
+	issuer, err := NewJWTIssuer(cfg)
+	require.NoError(t, err)
+	verifier, err := NewJWTVerifier(cfg)
+	require.NoError(t, err)
+
+	raw, err := issuer.Mint("user", map[string]any{"role": "teacher"}, 0)
+	require.NoError(t, err)
+
+	token, err := verifier.Verify(ctx, raw)
+	require.NoError(t, err)
+	require.Equal(t, "user", token.UID)
+	require.Equal(t, "teacher", token.Claims["role"])
+
+	t.Run("expired", func(t *testing.T) {
+		raw, err := issuer.Mint("user", nil, -time.Minute)
+		require.NoError(t, err)
+		_, err = verifier.Verify(ctx, raw)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		other, err := NewJWTVerifier(config.JWTConfig{Algorithm: "HS256", Secret: "other", Issuer: "test"})
+		require.NoError(t, err)
+		_, err = other.Verify(ctx, raw)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("other issuer", func(t *testing.T) {
+		other, err := NewJWTVerifier(config.JWTConfig{Algorithm: "HS256", Secret: "secret", Issuer: "prod"})
+		require.NoError(t, err)
+		_, err = other.Verify(ctx, raw)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := NewJWTVerifier(config.JWTConfig{Algorithm: "HS256"})
+		require.Error(t, err)
+	})
+}
+
+func TestJWT_RS256(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateFile := filepath.Join(dir, "private.pem")
+	require.NoError(t, os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0o600))
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicFile := filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{
+		Type: "PUBLIC KEY", Bytes: publicDER,
+	}), 0o600))
+
+	issuer, err := NewJWTIssuer(config.JWTConfig{
+		Algorithm: "RS256", PrivateKeyFile: privateFile, Issuer: "test", TTL: time.Hour,
+	})
+	require.NoError(t, err)
+	raw, err := issuer.Mint("user", nil, 0)
+	require.NoError(t, err)
+
+	for name, cfg := range map[string]config.JWTConfig{
+		"public key":  {Algorithm: "RS256", PublicKeyFile: publicFile, Issuer: "test"},
+		"private key": {Algorithm: "RS256", PrivateKeyFile: privateFile, Issuer: "test"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			verifier, err := NewJWTVerifier(cfg)
+			require.NoError(t, err)
+			token, err := verifier.Verify(ctx, raw)
+			require.NoError(t, err)
+			require.Equal(t, "user", token.UID)
+		})
+	}
+
+	t.Run("HS256 token", func(t *testing.T) {
+		hmacIssuer, err := NewJWTIssuer(config.JWTConfig{Algorithm: "HS256", Secret: "secret", Issuer: "test"})
+		require.NoError(t, err)
+		raw, err := hmacIssuer.Mint("user", nil, time.Hour)
+		require.NoError(t, err)
+
+		verifier, err := NewJWTVerifier(config.JWTConfig{Algorithm: "RS256", PublicKeyFile: publicFile, Issuer: "test"})
+		require.NoError(t, err)
+		_, err = verifier.Verify(ctx, raw)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
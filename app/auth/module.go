@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/config"
+)
+
+var Module = fx.Module(
+	"auth",
+	fx.Provide(
+		NewFirebaseClient,
+		NewTokenVerifier,
+	),
+	fx.Invoke(
+		RegisterHooks,
+	),
+)
+
+type NewTokenVerifierParams struct {
+	fx.In
+	Cfg      *config.AuthConfig
+	Firebase *FirebaseClient
+}
+
+// NewTokenVerifier returns the verifier selected by the config.
+func NewTokenVerifier(p NewTokenVerifierParams) (TokenVerifier, error) {
+	switch p.Cfg.Verifier {
+	case config.AuthVerifierFirebase:
+		return p.Firebase, nil
+	case config.AuthVerifierJWT:
+		verifier, err := NewJWTVerifier(p.Cfg.JWT)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create jwt verifier: %w", err)
+		}
+		return verifier, nil
+	default:
+		return nil, fmt.Errorf("unknown token verifier %q", p.Cfg.Verifier)
+	}
+}
+
+type HooksParams struct {
+	fx.In
+	Cfg            *config.AuthConfig
+	Lifecycle      fx.Lifecycle
+	FirebaseClient *FirebaseClient
+}
+
+func RegisterHooks(p HooksParams) {
+	if p.Cfg.Verifier != config.AuthVerifierFirebase {
+		return
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := p.FirebaseClient.Connect(ctx); err != nil {
+				return fmt.Errorf("connect firebase client: %w", err)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,180 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+)
+
+// A backup is a gzip compressed JSON Lines file: a header, one record per
+// user holding the user's account archive, and a footer. Every record
+// carries the SHA-256 of its archive; the footer carries the number of
+// records and a SHA-256 over all record checksums, so a truncated or
+// altered file is detected before anything is restored.
+
+// FormatVersion is the version of the backup file format.
+const FormatVersion = 1
+
+const (
+	kindHeader = "header"
+	kindUser   = "user"
+	kindFooter = "footer"
+)
+
+type entry struct {
+	Kind      string          `json:"kind"`
+	Version   int             `json:"version,omitempty"`
+	CreatedAt *time.Time      `json:"created_at,omitempty"`
+	UserId    string          `json:"user_id,omitempty"`
+	Archive   json.RawMessage `json:"archive,omitempty"`
+	Users     int             `json:"users,omitempty"`
+	SHA256    string          `json:"sha256,omitempty"`
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writer writes backup entries, flushing after each so that everything
+// written before a crash can be read back when resuming.
+type writer struct {
+	gz    *gzip.Writer
+	enc   *json.Encoder
+	sums  hash.Hash
+	users int
+}
+
+func newWriter(w io.Writer) *writer {
+	gz := gzip.NewWriter(w)
+	return &writer{
+		gz:   gz,
+		enc:  json.NewEncoder(gz),
+		sums: sha256.New(),
+	}
+}
+
+func (w *writer) write(e entry) error {
+	if err := w.enc.Encode(e); err != nil {
+		return err
+	}
+	return w.gz.Flush()
+}
+
+func (w *writer) header(createdAt time.Time) error {
+	return w.write(entry{Kind: kindHeader, Version: FormatVersion, CreatedAt: &createdAt})
+}
+
+func (w *writer) user(userId string, archive json.RawMessage) error {
+	e := entry{Kind: kindUser, UserId: userId, Archive: archive, SHA256: checksum(archive)}
+	if err := w.write(e); err != nil {
+		return err
+	}
+	w.sums.Write([]byte(e.SHA256))
+	w.users++
+	return nil
+}
+
+func (w *writer) footer() error {
+	return w.write(entry{Kind: kindFooter, Users: w.users, SHA256: hex.EncodeToString(w.sums.Sum(nil))})
+}
+
+func (w *writer) Close() error {
+	return w.gz.Close()
+}
+
+// reader reads and verifies backup entries.
+type reader struct {
+	gz     *gzip.Reader
+	buf    *bufio.Reader
+	sums   hash.Hash
+	users  int
+	header *entry
+	footer *entry
+}
+
+func newReader(r io.Reader) (*reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup file: %w", err)
+	}
+
+	rd := &reader{gz: gz, buf: bufio.NewReader(gz), sums: sha256.New()}
+	header, err := rd.next()
+	if err != nil {
+		return nil, err
+	}
+	if header == nil || header.Kind != kindHeader {
+		return nil, errors.New("backup has no header")
+	}
+	if header.Version < 1 || header.Version > FormatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", header.Version)
+	}
+	rd.header = header
+
+	return rd, nil
+}
+
+func (rd *reader) next() (*entry, error) {
+	line, err := rd.buf.ReadBytes('\n')
+	if errors.Is(err, io.EOF) && len(line) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backup is truncated: %w", err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(line, &e); err != nil {
+		return nil, fmt.Errorf("backup entry %d is corrupt: %w", rd.users+1, err)
+	}
+	return &e, nil
+}
+
+// user returns the next verified user record, or nil after the footer. It
+// fails on records whose checksum does not match.
+func (rd *reader) user() (*entry, error) {
+	if rd.footer != nil {
+		return nil, nil
+	}
+
+	e, err := rd.next()
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, errors.New("backup is truncated: no footer")
+	}
+
+	switch e.Kind {
+	case kindUser:
+		if checksum(e.Archive) != e.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for user %s", e.UserId)
+		}
+		rd.sums.Write([]byte(e.SHA256))
+		rd.users++
+		return e, nil
+	case kindFooter:
+		if e.Users != rd.users {
+			return nil, fmt.Errorf("backup has %d users, footer expects %d", rd.users, e.Users)
+		}
+		if sum := hex.EncodeToString(rd.sums.Sum(nil)); sum != e.SHA256 {
+			return nil, errors.New("checksum mismatch for backup")
+		}
+		rd.footer = e
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected backup entry %q", e.Kind)
+	}
+}
+
+func (rd *reader) Close() error {
+	return rd.gz.Close()
+}
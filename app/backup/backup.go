@@ -0,0 +1,336 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/model"
+)
+
+var Module = fx.Module(
+	"backup",
+	fx.Provide(
+		NewService,
+	),
+)
+
+// Service dumps the data of all users of the configured storage backend to
+// a backup file and restores it from one.
+type Service struct {
+	store   classroom.Firestore
+	account *classroom.AccountService
+	logger  *zap.Logger
+}
+
+type NewServiceParams struct {
+	fx.In
+	Store   classroom.Firestore
+	Account *classroom.AccountService
+	Logger  *zap.Logger
+}
+
+func NewService(p NewServiceParams) *Service {
+	return &Service{
+		store:   p.Store,
+		account: p.Account,
+		logger:  p.Logger.With(zap.String("type", "backup")),
+	}
+}
+
+// Stats counts the users handled by a backup or restore.
+type Stats struct {
+	Users   int
+	Skipped int
+	Vocab   int
+}
+
+// Backup writes all users to path. The file is written as path.partial and
+// only renamed to path once complete; with resume, users already in an
+// existing path.partial are kept instead of being exported again.
+func (s *Service) Backup(ctx context.Context, path string, resume bool) (Stats, error) {
+	var stats Stats
+	partial := path + ".partial"
+
+	var previous []entry
+	if resume {
+		var err error
+		previous, err = readValidUsers(partial)
+		if err != nil {
+			return stats, err
+		}
+		s.logger.Info("resuming backup", zap.Int("users", len(previous)))
+	}
+
+	userIds, err := s.store.ListUsers(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	// Kept users are copied into a fresh file which then replaces the old
+	// one, so a crash at any point leaves a readable partial backup.
+	f, err := os.Create(partial + ".tmp")
+	if err != nil {
+		return stats, fmt.Errorf("could not create backup: %w", err)
+	}
+	defer f.Close()
+
+	w := newWriter(f)
+	if err := w.header(time.Now()); err != nil {
+		return stats, err
+	}
+
+	done := map[string]bool{}
+	for _, e := range previous {
+		if err := w.user(e.UserId, e.Archive); err != nil {
+			return stats, err
+		}
+		done[e.UserId] = true
+		stats.Skipped++
+	}
+	if err := os.Rename(partial+".tmp", partial); err != nil {
+		return stats, fmt.Errorf("could not create backup: %w", err)
+	}
+
+	for _, userId := range userIds {
+		if done[userId] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		archive, err := s.account.Export(ctx, userId)
+		if err != nil {
+			return stats, fmt.Errorf("could not export user %s: %w", userId, err)
+		}
+		data, err := json.Marshal(archive)
+		if err != nil {
+			return stats, err
+		}
+		if err := w.user(userId, data); err != nil {
+			return stats, fmt.Errorf("could not write user %s: %w", userId, err)
+		}
+
+		stats.Users++
+		stats.Vocab += len(archive.Vocab)
+		s.logger.Info("backed up user", zap.String("user_id", userId), zap.Int("vocab", len(archive.Vocab)))
+	}
+
+	if err := w.footer(); err != nil {
+		return stats, err
+	}
+	if err := w.Close(); err != nil {
+		return stats, err
+	}
+	if err := f.Sync(); err != nil {
+		return stats, err
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return stats, fmt.Errorf("could not finish backup: %w", err)
+	}
+
+	return stats, nil
+}
+
+// readValidUsers returns the user records of a partial backup up to the
+// first one that cannot be read.
+func readValidUsers(path string) ([]entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	rd, err := newReader(f)
+	if err != nil {
+		return nil, nil
+	}
+
+	var users []entry
+	for {
+		e, err := rd.user()
+		if err != nil || e == nil {
+			return users, nil
+		}
+		users = append(users, *e)
+	}
+}
+
+// Verify reads the whole backup and checks every checksum.
+func Verify(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	rd, err := newReader(f)
+	if err != nil {
+		return 0, err
+	}
+	defer rd.Close()
+
+	for {
+		e, err := rd.user()
+		if err != nil {
+			return rd.users, err
+		}
+		if e == nil {
+			return rd.users, nil
+		}
+	}
+}
+
+// Restore imports every user of a verified backup into the configured
+// backend. Accounts must be empty. Every user is recorded in path.restored
+// as started before it is imported and as restored after; with resume the
+// restored users are skipped and the accounts of users started but never
+// restored are deleted and imported again, so a restore that stopped
+// halfway can be finished. Accounts the restore never started are left
+// alone.
+func (s *Service) Restore(ctx context.Context, path string, resume bool) (Stats, error) {
+	var stats Stats
+
+	if _, err := Verify(path); err != nil {
+		return stats, fmt.Errorf("backup failed verification: %w", err)
+	}
+
+	progressPath := path + ".restored"
+	started, done := map[string]bool{}, map[string]bool{}
+	if resume {
+		var err error
+		started, done, err = readProgress(progressPath)
+		if err != nil {
+			return stats, err
+		}
+		s.logger.Info("resuming restore", zap.Int("users", len(done)))
+	} else if err := os.Remove(progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return stats, err
+	}
+
+	progress, err := os.OpenFile(progressPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return stats, fmt.Errorf("could not record restore progress: %w", err)
+	}
+	defer progress.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return stats, err
+	}
+	defer f.Close()
+
+	rd, err := newReader(f)
+	if err != nil {
+		return stats, err
+	}
+	defer rd.Close()
+
+	for {
+		e, err := rd.user()
+		if err != nil {
+			return stats, err
+		}
+		if e == nil {
+			break
+		}
+		if done[e.UserId] {
+			stats.Skipped++
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		var archive model.AccountArchive
+		if err := json.Unmarshal(e.Archive, &archive); err != nil {
+			return stats, fmt.Errorf("could not decode user %s: %w", e.UserId, err)
+		}
+
+		// Only what this restore wrote is cleared, an account it never
+		// started may hold data of its own.
+		if started[e.UserId] {
+			if err := s.account.Delete(ctx, e.UserId); err != nil {
+				return stats, fmt.Errorf("could not clear user %s: %w", e.UserId, err)
+			}
+		} else if err := recordProgress(progress, progressStarted, e.UserId); err != nil {
+			return stats, err
+		}
+		if err := s.account.Import(ctx, e.UserId, archive); err != nil {
+			return stats, fmt.Errorf("could not restore user %s: %w", e.UserId, err)
+		}
+		if err := recordProgress(progress, progressRestored, e.UserId); err != nil {
+			return stats, err
+		}
+
+		stats.Users++
+		stats.Vocab += len(archive.Vocab)
+		s.logger.Info("restored user", zap.String("user_id", e.UserId), zap.Int("vocab", len(archive.Vocab)))
+	}
+
+	progress.Close()
+	if err := os.Remove(progressPath); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// States of a user in the progress file of a restore.
+const (
+	progressStarted  = "started"
+	progressRestored = "restored"
+)
+
+// recordProgress appends a line with the state of the user to the progress
+// file and syncs it, so the state survives a crash right after.
+func recordProgress(f *os.File, state, userId string) error {
+	if _, err := fmt.Fprintln(f, state, userId); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// readProgress returns the users a restore started and the users it
+// restored.
+func readProgress(path string) (started, done map[string]bool, err error) {
+	started, done = map[string]bool{}, map[string]bool{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return started, done, nil
+		}
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		state, userId, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if userId == "" {
+			continue
+		}
+		switch state {
+		case progressStarted:
+			started[userId] = true
+		case progressRestored:
+			done[userId] = true
+		}
+	}
+
+	return started, done, scanner.Err()
+}
@@ -0,0 +1,264 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/model"
+)
+
+func newTestService(t *testing.T) (*Service, *classroom.LocalStore) {
+	t.Helper()
+
+	store, err := classroom.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	return NewService(NewServiceParams{
+		Store:   store,
+		Account: classroom.NewAccountService(classroom.NewAccountServiceParams{Store: store}),
+		Logger:  zap.NewNop(),
+	}), store
+}
+
+// seed stores a vocab and a set for each user.
+func seed(t *testing.T, store *classroom.LocalStore, userIds ...string) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, userId := range userIds {
+		vocab := model.Vocab{
+			Id:           uuid.New(),
+			Definition:   "house of " + userId,
+			PartOfSpeech: model.PartOfSpeechNoun,
+			Forms:        []model.VocabForm{{Id: uuid.New(), Form: "singular", Value: "hus", Level: 2}},
+			CreatedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		vocab.DueAt = vocab.NextDue()
+		require.NoError(t, store.AddVocabulary(ctx, userId, vocab))
+		require.NoError(t, store.SetVocabSet(ctx, userId, model.VocabSet{
+			Id: uuid.New(), Name: "Home", VocabIds: []uuid.UUID{vocab.Id},
+		}))
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "backup.jsonl.gz")
+
+	source, sourceStore := newTestService(t)
+	seed(t, sourceStore, "alice", "bob")
+
+	stats, err := source.Backup(ctx, path, false)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Users: 2, Vocab: 2}, stats)
+	require.NoFileExists(t, path+".partial")
+
+	users, err := Verify(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, users)
+
+	target, targetStore := newTestService(t)
+	stats, err = target.Restore(ctx, path, false)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Users: 2, Vocab: 2}, stats)
+	require.NoFileExists(t, path+".restored")
+
+	for _, userId := range []string{"alice", "bob"} {
+		want, err := sourceStore.FetchUserVocabulary(ctx, userId)
+		require.NoError(t, err)
+		got, err := targetStore.FetchUserVocabulary(ctx, userId)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+
+		wantSets, err := sourceStore.FetchUserVocabSets(ctx, userId)
+		require.NoError(t, err)
+		gotSets, err := targetStore.FetchUserVocabSets(ctx, userId)
+		require.NoError(t, err)
+		require.Equal(t, wantSets, gotSets)
+	}
+}
+
+func TestBackup_Resume(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "backup.jsonl.gz")
+
+	service, store := newTestService(t)
+	seed(t, store, "alice", "bob")
+
+	// A backup that stopped after alice: header and one record, no footer.
+	f, err := os.Create(path + ".partial")
+	require.NoError(t, err)
+	w := newWriter(f)
+	require.NoError(t, w.header(time.Now()))
+	require.NoError(t, w.user("alice", []byte(`{"version":1,"vocab":[],"sets":[]}`)))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	stats, err := service.Backup(ctx, path, true)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Users: 1, Skipped: 1, Vocab: 1}, stats)
+
+	users, err := Verify(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, users)
+}
+
+func TestRestore_Resume(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "backup.jsonl.gz")
+
+	source, sourceStore := newTestService(t)
+	seed(t, sourceStore, "alice", "bob", "carol")
+	_, err := source.Backup(ctx, path, false)
+	require.NoError(t, err)
+
+	// A restore that finished alice and stopped halfway through bob. The
+	// half of bob is cleared on resume, carol was never started so her
+	// account is left as it is.
+	target, targetStore := newTestService(t)
+	seed(t, targetStore, "bob", "carol")
+	carol, err := targetStore.FetchUserVocabulary(ctx, "carol")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path+".restored", []byte("started alice\nrestored alice\nstarted bob\n"), 0o600))
+
+	_, err = target.Restore(ctx, path, true)
+	require.ErrorIs(t, err, classroom.ErrAccountNotEmpty)
+
+	bob, err := targetStore.FetchUserVocabulary(ctx, "bob")
+	require.NoError(t, err)
+	want, err := sourceStore.FetchUserVocabulary(ctx, "bob")
+	require.NoError(t, err)
+	require.Equal(t, want, bob)
+
+	kept, err := targetStore.FetchUserVocabulary(ctx, "carol")
+	require.NoError(t, err)
+	require.Equal(t, carol, kept)
+
+	// Once carol's account is empty the restore finishes.
+	require.NoError(t, target.account.Delete(ctx, "carol"))
+	stats, err := target.Restore(ctx, path, true)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Users: 1, Skipped: 2, Vocab: 1}, stats)
+	require.NoFileExists(t, path+".restored")
+}
+
+func TestVerify_RejectsDamagedBackups(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backup.jsonl.gz")
+
+	service, store := newTestService(t)
+	seed(t, store, "alice", "bob")
+	_, err := service.Backup(ctx, path, false)
+	require.NoError(t, err)
+
+	compressed, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := backupLines(t, compressed)
+	require.Len(t, lines, 4)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{
+			name:    "not gzip",
+			data:    []byte("not a backup"),
+			wantErr: "not a backup file",
+		},
+		{
+			name:    "truncated file",
+			data:    compressed[:len(compressed)*2/3],
+			wantErr: "truncated",
+		},
+		{
+			name:    "missing footer",
+			data:    gzipLines(t, lines[:3]),
+			wantErr: "no footer",
+		},
+		{
+			name:    "missing user",
+			data:    gzipLines(t, [][]byte{lines[0], lines[1], lines[3]}),
+			wantErr: "footer expects 2",
+		},
+		{
+			name:    "altered archive",
+			data:    gzipLines(t, [][]byte{lines[0], bytes.Replace(lines[1], []byte("hus"), []byte("hut"), 1), lines[2], lines[3]}),
+			wantErr: "checksum mismatch for user",
+		},
+		{
+			name:    "corrupt entry",
+			data:    gzipLines(t, [][]byte{lines[0], []byte("{\"kind\":\n"), lines[2], lines[3]}),
+			wantErr: "is corrupt",
+		},
+		{
+			name:    "no header",
+			data:    gzipLines(t, lines[1:]),
+			wantErr: "no header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			damaged := filepath.Join(t.TempDir(), "damaged.jsonl.gz")
+			require.NoError(t, os.WriteFile(damaged, tt.data, 0o600))
+
+			_, err := Verify(damaged)
+			require.ErrorContains(t, err, tt.wantErr)
+
+			// Nothing is restored from a backup that fails verification.
+			target, targetStore := newTestService(t)
+			_, err = target.Restore(ctx, damaged, false)
+			require.ErrorContains(t, err, tt.wantErr)
+			users, err := targetStore.ListUsers(ctx)
+			require.NoError(t, err)
+			require.Empty(t, users)
+		})
+	}
+}
+
+// backupLines returns the uncompressed lines of a backup, newlines kept.
+func backupLines(t *testing.T, compressed []byte) [][]byte {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+
+	var lines [][]byte
+	rd := bufio.NewReader(bytes.NewReader(data))
+	for {
+		line, err := rd.ReadBytes('\n')
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+		if err != nil {
+			return lines
+		}
+	}
+}
+
+func gzipLines(t *testing.T, lines [][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(bytes.Join(lines, nil))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,259 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+var (
+	ErrAccountNotEmpty = newError(ErrConflict, "account_not_empty", "account is not empty")
+	ErrInvalidArchive  = newError(ErrValidation, "invalid_archive", "invalid account archive")
+)
+
+// AccountService exports, imports and deletes all data of a user.
+type AccountService struct {
+	storage Firestore
+}
+
+type NewAccountServiceParams struct {
+	fx.In
+	Store Firestore
+}
+
+func NewAccountService(p NewAccountServiceParams) *AccountService {
+	return &AccountService{
+		storage: p.Store,
+	}
+}
+
+// Export returns an archive of the user's vocab, sets and pool, along with
+// the rest of what Delete removes: published sets, API keys, the role and
+// class memberships.
+func (as *AccountService) Export(ctx context.Context, userId string) (*model.AccountArchive, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.Export")
+	defer span.End()
+
+	vocabs, err := as.storage.FetchUserVocabulary(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vocabulary: %w", err)
+	}
+
+	sets, err := as.storage.FetchUserVocabSets(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vocab sets: %w", err)
+	}
+
+	pool, err := as.storage.FetchUserPool(ctx, userId)
+	if err != nil && !errors.Is(err, ErrPoolNotFound) {
+		return nil, fmt.Errorf("failed to fetch pool: %w", err)
+	}
+
+	published, err := as.storage.ListPublishedSets(ctx, userId, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published sets: %w", err)
+	}
+
+	keys, err := as.storage.ListAPIKeys(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	role, err := as.storage.GetUserRole(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	classes, err := as.storage.ListUserClasses(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+	memberships := make([]model.ClassMembership, 0, len(classes))
+	for _, class := range classes {
+		memberships = append(memberships, model.ClassMembership{
+			ClassId: class.Id,
+			Name:    class.Name,
+			Teacher: class.TeacherId == userId,
+		})
+	}
+
+	return &model.AccountArchive{
+		Version:       model.AccountArchiveVersion,
+		ExportedAt:    time.Now(),
+		Vocab:         vocabs,
+		Sets:          sets,
+		Pool:          pool,
+		PublishedSets: published,
+		APIKeys:       keys,
+		Role:          role,
+		Classes:       memberships,
+	}, nil
+}
+
+// Delete removes every document stored for the user, including the sets
+// they published and the classes they teach, and leaves the classes they
+// attend.
+func (as *AccountService) Delete(ctx context.Context, userId string) error {
+	ctx, span := tracer.Start(ctx, "AccountService.Delete")
+	defer span.End()
+
+	classes, err := as.storage.ListUserClasses(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("failed to list classes: %w", err)
+	}
+	for _, class := range classes {
+		if class.TeacherId == userId {
+			if err := as.storage.RemoveClass(ctx, class.Id); err != nil {
+				return fmt.Errorf("failed to remove class: %w", err)
+			}
+			continue
+		}
+		if err := as.storage.RemoveClassStudent(ctx, class.Id, userId); err != nil {
+			return fmt.Errorf("failed to leave class: %w", err)
+		}
+	}
+
+	published, err := as.storage.ListPublishedSets(ctx, userId, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list published sets: %w", err)
+	}
+	for _, set := range published {
+		if err := as.storage.RemovePublishedSet(ctx, set.Id); err != nil {
+			return fmt.Errorf("failed to unpublish set: %w", err)
+		}
+	}
+
+	keys, err := as.storage.ListAPIKeys(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("failed to list api keys: %w", err)
+	}
+	for _, key := range keys {
+		if err := as.storage.RemoveAPIKey(ctx, key.Id); err != nil {
+			return fmt.Errorf("failed to revoke api key: %w", err)
+		}
+	}
+
+	if err := as.storage.SetUserRole(ctx, userId, ""); err != nil {
+		return fmt.Errorf("failed to remove role: %w", err)
+	}
+
+	if err := as.storage.DeleteUser(ctx, userId); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	return nil
+}
+
+// Import restores the vocab, sets and pool of an archive into an account
+// without vocab or sets. The archive is checked as a whole before anything
+// is written. Published sets, API keys, the role and class memberships are
+// not restored: keys cannot be without their secret, and the others are
+// granted by other users.
+func (as *AccountService) Import(ctx context.Context, userId string, archive model.AccountArchive) error {
+	ctx, span := tracer.Start(ctx, "AccountService.Import")
+	defer span.End()
+
+	if err := validateArchive(archive); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	vocabs, err := as.storage.FetchUserVocabulary(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch vocabulary: %w", err)
+	}
+	sets, err := as.storage.FetchUserVocabSets(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch vocab sets: %w", err)
+	}
+	if len(vocabs) > 0 || len(sets) > 0 {
+		return ErrAccountNotEmpty
+	}
+
+	var batch model.VocabBatch
+	now := time.Now()
+	for _, vocab := range archive.Vocab {
+		if vocab.CreatedAt.IsZero() {
+			vocab.CreatedAt = now
+		}
+		vocab.DueAt = vocab.NextDue()
+		batch.Set = append(batch.Set, vocab)
+		batch.Index = append(batch.Index, newSearchEntry(vocab))
+	}
+	if len(batch.Set) > 0 {
+		if err := as.storage.WriteVocabBatch(ctx, userId, batch); err != nil {
+			return fmt.Errorf("failed to save vocabulary: %w", err)
+		}
+	}
+
+	for _, set := range archive.Sets {
+		if err := as.storage.SetVocabSet(ctx, userId, set); err != nil {
+			return fmt.Errorf("failed to save vocab set: %w", err)
+		}
+	}
+
+	if archive.Pool != nil {
+		if err := as.storage.UpdatePool(ctx, userId, archive.Pool); err != nil {
+			return fmt.Errorf("failed to save pool: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validateArchive(archive model.AccountArchive) error {
+	if archive.Version < 1 || archive.Version > model.AccountArchiveVersion {
+		return fmt.Errorf("unsupported version %d", archive.Version)
+	}
+
+	vocabIds := make(map[uuid.UUID]bool, len(archive.Vocab))
+	for _, vocab := range archive.Vocab {
+		if vocab.Id == uuid.Nil {
+			return fmt.Errorf("vocab %q has no id", vocab.Definition)
+		}
+		if vocabIds[vocab.Id] {
+			return fmt.Errorf("vocab %s is listed more than once", vocab.Id)
+		}
+		vocabIds[vocab.Id] = true
+
+		for _, form := range vocab.Forms {
+			if form.Id == uuid.Nil {
+				return fmt.Errorf("form %q of vocab %s has no id", form.Value, vocab.Id)
+			}
+		}
+		if err := vocab.Validate(); err != nil {
+			return fmt.Errorf("vocab %s: %w", vocab.Id, err)
+		}
+	}
+
+	setIds := make(map[uuid.UUID]bool, len(archive.Sets))
+	for _, set := range archive.Sets {
+		if set.Id == uuid.Nil {
+			return fmt.Errorf("set %q has no id", set.Name)
+		}
+		if setIds[set.Id] {
+			return fmt.Errorf("set %s is listed more than once", set.Id)
+		}
+		setIds[set.Id] = true
+
+		for _, vocabId := range set.VocabIds {
+			if !vocabIds[vocabId] {
+				return fmt.Errorf("set %s refers to unknown vocab %s", set.Id, vocabId)
+			}
+		}
+	}
+
+	if archive.Pool != nil {
+		for _, vocab := range archive.Pool.Vocabs {
+			if !vocabIds[vocab.Id] {
+				return fmt.Errorf("pool refers to unknown vocab %s", vocab.Id)
+			}
+		}
+	}
+
+	return nil
+}
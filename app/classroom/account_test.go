@@ -0,0 +1,173 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestAccountService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabs := []model.Vocab{{Id: uuid.New(), Definition: "house"}}
+	sets := []model.VocabSet{{Id: uuid.New(), Name: "Home", VocabIds: []uuid.UUID{vocabs[0].Id}}}
+	pool := &model.Pool{Vocabs: vocabs}
+	published := []model.PublishedSet{{Id: "share", OwnerId: userId, Name: "Home"}}
+	keys := []model.APIKey{{Id: "key", UserId: userId, Name: "Laptop", Hash: "secret"}}
+	taught := model.Class{Id: uuid.New(), Name: "Danish 1", TeacherId: userId, StudentIds: []string{"student"}}
+	attended := model.Class{Id: uuid.New(), Name: "Danish 2", TeacherId: "teacher", StudentIds: []string{userId, "other"}}
+
+	mockStore.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return(vocabs, nil)
+	mockStore.EXPECT().FetchUserVocabSets(gomock.Any(), userId).Return(sets, nil)
+	mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(pool, nil)
+	mockStore.EXPECT().ListPublishedSets(gomock.Any(), userId, 0).Return(published, nil)
+	mockStore.EXPECT().ListAPIKeys(gomock.Any(), userId).Return(keys, nil)
+	mockStore.EXPECT().GetUserRole(gomock.Any(), userId).Return(model.RoleTeacher, nil)
+	mockStore.EXPECT().ListUserClasses(gomock.Any(), userId).Return([]model.Class{taught, attended}, nil)
+
+	service := &AccountService{storage: mockStore}
+
+	archive, err := service.Export(context.Background(), userId)
+	require.NoError(t, err)
+	require.Equal(t, model.AccountArchiveVersion, archive.Version)
+	require.Equal(t, vocabs, archive.Vocab)
+	require.Equal(t, sets, archive.Sets)
+	require.Equal(t, pool, archive.Pool)
+	require.Equal(t, published, archive.PublishedSets)
+	require.Equal(t, keys, archive.APIKeys)
+	require.Equal(t, model.RoleTeacher, archive.Role)
+	// Memberships leave out the other students of the class.
+	require.Equal(t, []model.ClassMembership{
+		{ClassId: taught.Id, Name: "Danish 1", Teacher: true},
+		{ClassId: attended.Id, Name: "Danish 2"},
+	}, archive.Classes)
+}
+
+func TestAccountService_Import(t *testing.T) {
+	userId := "test-user"
+	vocab := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		Forms:      []model.VocabForm{{Id: uuid.New(), Value: "hus"}},
+	}
+	set := model.VocabSet{Id: uuid.New(), Name: "Home", VocabIds: []uuid.UUID{vocab.Id}}
+	archive := model.AccountArchive{
+		Version: model.AccountArchiveVersion,
+		Vocab:   []model.Vocab{vocab},
+		Sets:    []model.VocabSet{set},
+		Pool:    &model.Pool{Vocabs: []model.Vocab{vocab}},
+	}
+
+	tests := []struct {
+		name        string
+		archive     model.AccountArchive
+		setupMock   func(*mocks.MockFirestore)
+		expectedErr error
+	}{
+		{
+			name:    "restores into an empty account",
+			archive: archive,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return([]model.Vocab{}, nil)
+				mock.EXPECT().FetchUserVocabSets(gomock.Any(), userId).Return([]model.VocabSet{}, nil)
+				mock.EXPECT().
+					WriteVocabBatch(gomock.Any(), userId, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, batch model.VocabBatch) error {
+						require.Len(t, batch.Set, 1)
+						require.Equal(t, vocab.Id, batch.Set[0].Id)
+						require.False(t, batch.Set[0].CreatedAt.IsZero())
+						require.Equal(t, []model.SearchEntry{newSearchEntry(vocab)}, batch.Index)
+						return nil
+					})
+				mock.EXPECT().SetVocabSet(gomock.Any(), userId, set).Return(nil)
+				mock.EXPECT().UpdatePool(gomock.Any(), userId, archive.Pool).Return(nil)
+			},
+		},
+		{
+			name:    "account is not empty",
+			archive: archive,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return([]model.Vocab{vocab}, nil)
+				mock.EXPECT().FetchUserVocabSets(gomock.Any(), userId).Return([]model.VocabSet{}, nil)
+			},
+			expectedErr: ErrAccountNotEmpty,
+		},
+		{
+			name:        "unsupported version",
+			archive:     model.AccountArchive{Version: model.AccountArchiveVersion + 1},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectedErr: ErrInvalidArchive,
+		},
+		{
+			name: "set refers to unknown vocab",
+			archive: model.AccountArchive{
+				Version: model.AccountArchiveVersion,
+				Sets:    []model.VocabSet{set},
+			},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectedErr: ErrInvalidArchive,
+		},
+		{
+			name: "invalid vocab",
+			archive: model.AccountArchive{
+				Version: model.AccountArchiveVersion,
+				Vocab:   []model.Vocab{{Id: uuid.New()}},
+			},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectedErr: ErrInvalidArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockFirestore(ctrl)
+			tt.setupMock(mockStore)
+
+			service := &AccountService{storage: mockStore}
+
+			err := service.Import(context.Background(), userId, tt.archive)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+
+	taught := model.Class{Id: uuid.New(), TeacherId: userId}
+	attended := model.Class{Id: uuid.New(), TeacherId: "teacher", StudentIds: []string{"other", userId}}
+	mockStore.EXPECT().ListUserClasses(gomock.Any(), userId).Return([]model.Class{taught, attended}, nil)
+	mockStore.EXPECT().RemoveClass(gomock.Any(), taught.Id).Return(nil)
+	mockStore.EXPECT().RemoveClassStudent(gomock.Any(), attended.Id, userId).Return(nil)
+	mockStore.EXPECT().ListPublishedSets(gomock.Any(), userId, 0).
+		Return([]model.PublishedSet{{Id: "share-id", OwnerId: userId}}, nil)
+	mockStore.EXPECT().RemovePublishedSet(gomock.Any(), "share-id").Return(nil)
+	mockStore.EXPECT().ListAPIKeys(gomock.Any(), userId).Return([]model.APIKey{{Id: "key-id", UserId: userId}}, nil)
+	mockStore.EXPECT().RemoveAPIKey(gomock.Any(), "key-id").Return(nil)
+	mockStore.EXPECT().SetUserRole(gomock.Any(), userId, model.Role("")).Return(nil)
+	mockStore.EXPECT().DeleteUser(gomock.Any(), userId).Return(errors.New("test error"))
+
+	service := &AccountService{storage: mockStore}
+	require.Error(t, service.Delete(context.Background(), userId))
+}
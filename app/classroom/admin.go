@@ -0,0 +1,230 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+// AdminService inspects and repairs the data of any user. It is meant for
+// operators, not for the users themselves.
+type AdminService struct {
+	storage Firestore
+}
+
+type NewAdminServiceParams struct {
+	fx.In
+	Store Firestore
+}
+
+func NewAdminService(p NewAdminServiceParams) *AdminService {
+	return &AdminService{
+		storage: p.Store,
+	}
+}
+
+// ListUsers returns the ids of all users with stored data.
+func (as *AdminService) ListUsers(ctx context.Context) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "AdminService.ListUsers")
+	defer span.End()
+
+	userIds, err := as.storage.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return userIds, nil
+}
+
+// Stats counts the vocab, sets and pool of a user.
+func (as *AdminService) Stats(ctx context.Context, userId string) (model.UserStats, error) {
+	ctx, span := tracer.Start(ctx, "AdminService.Stats")
+	defer span.End()
+
+	stats := model.UserStats{
+		UserId:         userId,
+		ByLevel:        map[int]int{},
+		ByPartOfSpeech: map[model.PartOfSpeech]int{},
+	}
+
+	vocabs, err := as.storage.FetchUserVocabulary(ctx, userId)
+	if err != nil {
+		return stats, fmt.Errorf("failed to fetch vocabulary: %w", err)
+	}
+	sets, err := as.storage.FetchUserVocabSets(ctx, userId)
+	if err != nil {
+		return stats, fmt.Errorf("failed to fetch vocab sets: %w", err)
+	}
+	pool, err := as.storage.FetchUserPool(ctx, userId)
+	if err != nil && !errors.Is(err, ErrPoolNotFound) {
+		return stats, fmt.Errorf("failed to fetch pool: %w", err)
+	}
+
+	now := time.Now()
+	stats.Vocab = len(vocabs)
+	stats.Sets = len(sets)
+	if pool != nil {
+		stats.PoolSize = len(pool.Vocabs)
+	}
+	for _, vocab := range vocabs {
+		stats.Forms += len(vocab.Forms)
+		stats.ByLevel[vocab.Level()]++
+		stats.ByPartOfSpeech[vocab.PartOfSpeech]++
+		if vocab.PausedUntil != nil {
+			stats.Paused++
+		} else if due := vocab.NextDue(); !due.IsZero() && !due.After(now) {
+			stats.Due++
+		}
+	}
+
+	return stats, nil
+}
+
+// Repair brings a user's data up to date with the current model: it gives
+// forms without an id one, fills in missing creation and stale due times,
+// rewrites the search index and drops set and pool entries of removed
+// vocab. With dryRun nothing is written.
+func (as *AdminService) Repair(ctx context.Context, userId string, dryRun bool) (model.RepairReport, error) {
+	ctx, span := tracer.Start(ctx, "AdminService.Repair")
+	defer span.End()
+
+	report := model.RepairReport{UserId: userId, DryRun: dryRun}
+
+	vocabs, err := as.storage.FetchUserVocabulary(ctx, userId)
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch vocabulary: %w", err)
+	}
+	report.Vocab = len(vocabs)
+
+	var batch model.VocabBatch
+	exists := make(map[uuid.UUID]bool, len(vocabs))
+	now := time.Now()
+	for _, vocab := range vocabs {
+		exists[vocab.Id] = true
+
+		changed := false
+		if slices.ContainsFunc(vocab.Forms, func(f model.VocabForm) bool { return f.Id == uuid.Nil }) {
+			assignIds(&vocab)
+			report.MissingIds++
+			changed = true
+		}
+		if due := vocab.NextDue(); vocab.CreatedAt.IsZero() || !vocab.DueAt.Equal(due) {
+			if vocab.CreatedAt.IsZero() {
+				vocab.CreatedAt = now
+			}
+			vocab.DueAt = due
+			report.StaleDates++
+			changed = true
+		}
+
+		if changed {
+			batch.Set = append(batch.Set, vocab)
+		}
+		batch.Index = append(batch.Index, newSearchEntry(vocab))
+	}
+	report.Reindexed = len(batch.Index)
+
+	sets, err := as.storage.FetchUserVocabSets(ctx, userId)
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch vocab sets: %w", err)
+	}
+	var changedSets []model.VocabSet
+	for _, set := range sets {
+		kept := slices.DeleteFunc(slices.Clone(set.VocabIds), func(id uuid.UUID) bool { return !exists[id] })
+		if len(kept) != len(set.VocabIds) {
+			report.DanglingSetIds += len(set.VocabIds) - len(kept)
+			set.VocabIds = kept
+			changedSets = append(changedSets, set)
+		}
+	}
+
+	pool, err := as.storage.FetchUserPool(ctx, userId)
+	if err != nil && !errors.Is(err, ErrPoolNotFound) {
+		return report, fmt.Errorf("failed to fetch pool: %w", err)
+	}
+	poolChanged := false
+	if pool != nil {
+		kept := slices.DeleteFunc(slices.Clone(pool.Vocabs), func(v model.Vocab) bool { return !exists[v.Id] })
+		if len(kept) != len(pool.Vocabs) {
+			report.DanglingPool = len(pool.Vocabs) - len(kept)
+			pool.Vocabs = kept
+			poolChanged = true
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	if len(batch.Set) > 0 || len(batch.Index) > 0 {
+		if err := as.storage.WriteVocabBatch(ctx, userId, batch); err != nil {
+			return report, fmt.Errorf("failed to save vocabulary: %w", err)
+		}
+	}
+	for _, set := range changedSets {
+		if err := as.storage.SetVocabSet(ctx, userId, set); err != nil {
+			return report, fmt.Errorf("failed to save vocab set: %w", err)
+		}
+	}
+	if poolChanged {
+		if err := as.storage.UpdatePool(ctx, userId, pool); err != nil {
+			return report, fmt.Errorf("failed to save pool: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// DataVersion is the version of the stored data the services expect.
+// Version 1 fills in the creation and due times vocab listings sort on
+// and the search index that search and duplicate detection look up;
+// version 2 adds whole-word terms to the search index.
+const DataVersion = 2
+
+// Migrate repairs the data of every user once after an upgrade: it does
+// nothing if the stored data version is current, and otherwise runs
+// Repair for each user before recording DataVersion. It returns the
+// number of users repaired.
+func (as *AdminService) Migrate(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "AdminService.Migrate")
+	defer span.End()
+
+	version, err := as.storage.GetDataVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get data version: %w", err)
+	}
+	if version >= DataVersion {
+		return 0, nil
+	}
+
+	userIds, err := as.storage.ListUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	for i, userId := range userIds {
+		if _, err := as.Repair(ctx, userId, false); err != nil {
+			return i, fmt.Errorf("failed to repair user %s: %w", userId, err)
+		}
+	}
+
+	if err := as.MarkMigrated(ctx); err != nil {
+		return len(userIds), err
+	}
+
+	return len(userIds), nil
+}
+
+// MarkMigrated records DataVersion, for callers that ran Repair for every
+// user themselves.
+func (as *AdminService) MarkMigrated(ctx context.Context) error {
+	if err := as.storage.SetDataVersion(ctx, DataVersion); err != nil {
+		return fmt.Errorf("failed to set data version: %w", err)
+	}
+	return nil
+}
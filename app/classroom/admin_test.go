@@ -0,0 +1,156 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestAdminService_Stats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	paused := time.Now().Add(time.Hour)
+	vocabs := []model.Vocab{
+		{Id: uuid.New(), PartOfSpeech: model.PartOfSpeechNoun, Forms: []model.VocabForm{{Id: uuid.New()}, {Id: uuid.New()}}},
+		{Id: uuid.New(), PartOfSpeech: model.PartOfSpeechVerb, Forms: []model.VocabForm{{Id: uuid.New()}}, PausedUntil: &paused},
+	}
+
+	mockStore.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return(vocabs, nil)
+	mockStore.EXPECT().FetchUserVocabSets(gomock.Any(), userId).Return([]model.VocabSet{{Id: uuid.New()}}, nil)
+	mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(&model.Pool{Vocabs: vocabs[:1]}, nil)
+
+	service := &AdminService{storage: mockStore}
+
+	stats, err := service.Stats(context.Background(), userId)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Vocab)
+	require.Equal(t, 3, stats.Forms)
+	require.Equal(t, 1, stats.Sets)
+	require.Equal(t, 1, stats.PoolSize)
+	require.Equal(t, 1, stats.Paused)
+	require.Equal(t, 1, stats.ByPartOfSpeech[model.PartOfSpeechNoun])
+}
+
+func TestAdminService_Repair(t *testing.T) {
+	userId := "test-user"
+	vocab := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		Forms:      []model.VocabForm{{Value: "hus"}},
+	}
+	removed := uuid.New()
+	set := model.VocabSet{Id: uuid.New(), Name: "Home", VocabIds: []uuid.UUID{vocab.Id, removed}}
+	pool := &model.Pool{Vocabs: []model.Vocab{vocab, {Id: removed}}}
+
+	tests := []struct {
+		name      string
+		dryRun    bool
+		setupMock func(*mocks.MockFirestore)
+	}{
+		{
+			name: "writes the repaired data",
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					WriteVocabBatch(gomock.Any(), userId, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, batch model.VocabBatch) error {
+						require.Len(t, batch.Set, 1)
+						require.NotEqual(t, uuid.Nil, batch.Set[0].Forms[0].Id)
+						require.False(t, batch.Set[0].CreatedAt.IsZero())
+						require.Len(t, batch.Index, 1)
+						return nil
+					})
+				mock.EXPECT().
+					SetVocabSet(gomock.Any(), userId, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, s model.VocabSet) error {
+						require.Equal(t, []uuid.UUID{vocab.Id}, s.VocabIds)
+						return nil
+					})
+				mock.EXPECT().
+					UpdatePool(gomock.Any(), userId, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, p *model.Pool) error {
+						require.Len(t, p.Vocabs, 1)
+						return nil
+					})
+			},
+		},
+		{
+			name:      "dry run writes nothing",
+			dryRun:    true,
+			setupMock: func(mock *mocks.MockFirestore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockFirestore(ctrl)
+			// Repair assigns form ids in place, so every run gets its own forms.
+			fetched := vocab
+			fetched.Forms = []model.VocabForm{{Value: "hus"}}
+			mockStore.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return([]model.Vocab{fetched}, nil)
+			mockStore.EXPECT().FetchUserVocabSets(gomock.Any(), userId).
+				Return([]model.VocabSet{{Id: set.Id, Name: set.Name, VocabIds: set.VocabIds}}, nil)
+			mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).
+				Return(&model.Pool{Vocabs: pool.Vocabs}, nil)
+			tt.setupMock(mockStore)
+
+			service := &AdminService{storage: mockStore}
+
+			report, err := service.Repair(context.Background(), userId, tt.dryRun)
+			require.NoError(t, err)
+			require.Equal(t, 1, report.MissingIds)
+			require.Equal(t, 1, report.StaleDates)
+			require.Equal(t, 1, report.DanglingSetIds)
+			require.Equal(t, 1, report.DanglingPool)
+		})
+	}
+}
+
+func TestAdminService_Migrate(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	// Written before creation times and the search index existed.
+	old := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		Forms:      []model.VocabForm{{Id: uuid.New(), Value: "hus"}},
+	}
+	require.NoError(t, store.AddVocabulary(ctx, "user", old))
+
+	service := &AdminService{storage: store}
+
+	users, err := service.Migrate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, users)
+
+	vocab, err := store.GetVocab(ctx, "user", old.Id)
+	require.NoError(t, err)
+	require.False(t, vocab.CreatedAt.IsZero())
+
+	ids, err := store.SearchVocabIds(ctx, "user", []string{"hus"}, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{old.Id}, ids)
+
+	version, err := store.GetDataVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, DataVersion, version)
+
+	// Once the version is recorded nothing runs again.
+	users, err = service.Migrate(ctx)
+	require.NoError(t, err)
+	require.Zero(t, users)
+}
@@ -0,0 +1,295 @@
+package classroom
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"golang.org/x/time/rate"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/validate"
+)
+
+var (
+	ErrAPIKeyNotFound = newError(ErrNotFound, "api_key_not_found", "api key not found")
+	ErrInvalidAPIKey  = newError(ErrValidation, "invalid_api_key", "invalid api key")
+	ErrTooManyAPIKeys = newError(ErrConflict, "too_many_api_keys", "too many api keys")
+)
+
+const (
+	// APIKeyPrefix starts every API key, telling it apart from ID tokens.
+	APIKeyPrefix = "dk_"
+	// MaxAPIKeys is the most keys a user may have.
+	MaxAPIKeys = 20
+	// DefaultAPIKeyRateLimit is the requests per minute of a key created
+	// without a limit.
+	DefaultAPIKeyRateLimit = 60
+	// MaxAPIKeyRateLimit is the highest limit a key may have.
+	MaxAPIKeyRateLimit = 600
+
+	maxAPIKeyNameLength = 100
+	// apiKeyIdBytes is the number of random bytes in the hex id of a key.
+	apiKeyIdBytes = 6
+	// lastUsedInterval is how often the last use of a key is written, so
+	// busy keys do not cost a write per request.
+	lastUsedInterval = time.Minute
+)
+
+// RateLimitError is returned when a key made more requests than its rate
+// limit allows.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// APIKeyService manages personal API keys. A key looks like
+// "dk_<id>_<secret>"; the id finds the stored key and the hash of the
+// whole key is compared with the stored hash.
+type APIKeyService struct {
+	storage Firestore
+
+	mu        sync.Mutex
+	limiters  map[string]*keyLimiter
+	lastSweep time.Time
+}
+
+// keyLimiter is the token bucket of a key. Buckets idle for a minute are
+// full again and are dropped, since a fresh bucket behaves the same.
+type keyLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+type NewAPIKeyServiceParams struct {
+	fx.In
+	Store Firestore
+}
+
+func NewAPIKeyService(p NewAPIKeyServiceParams) *APIKeyService {
+	return &APIKeyService{
+		storage:   p.Store,
+		limiters:  map[string]*keyLimiter{},
+		lastSweep: time.Now(),
+	}
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAPIKey returns a random key and its id.
+func newAPIKey() (key string, id string, err error) {
+	b := make([]byte, 30)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	id = hex.EncodeToString(b[:apiKeyIdBytes])
+	return APIKeyPrefix + id + "_" + base64.RawURLEncoding.EncodeToString(b[apiKeyIdBytes:]), id, nil
+}
+
+// validAPIKeyId reports whether id has the format newAPIKey gives ids, so
+// anything else is turned down before it reaches the store.
+func validAPIKeyId(id string) bool {
+	if len(id) != 2*apiKeyIdBytes {
+		return false
+	}
+	for _, c := range id {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Create adds a key to the user. The returned key is not stored and can
+// not be shown again.
+func (ks *APIKeyService) Create(
+	ctx context.Context, userId string, name string, scopes []model.APIKeyScope, rateLimit int,
+) (*model.CreatedAPIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Create")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if rateLimit == 0 {
+		rateLimit = DefaultAPIKeyRateLimit
+	}
+
+	var val validate.Validator
+	val.Required("name", name)
+	val.MaxLength("name", name, maxAPIKeyNameLength)
+	val.Check(len(scopes) > 0, "scopes", "is required")
+	for i, scope := range scopes {
+		val.Check(scope.Valid(), validate.Index("scopes", i), "is not a known scope")
+	}
+	val.Range("rate_limit", rateLimit, 1, MaxAPIKeyRateLimit)
+	if err := val.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAPIKey, err)
+	}
+
+	existing, err := ks.storage.ListAPIKeys(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	if len(existing) >= MaxAPIKeys {
+		return nil, fmt.Errorf("%w: at most %d per user", ErrTooManyAPIKeys, MaxAPIKeys)
+	}
+
+	rawKey, id, err := newAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	slices.Sort(scopes)
+	key := model.APIKey{
+		Id:        id,
+		UserId:    userId,
+		Name:      name,
+		Hash:      hashAPIKey(rawKey),
+		Scopes:    slices.Compact(scopes),
+		RateLimit: rateLimit,
+		CreatedAt: time.Now(),
+	}
+	if err := ks.storage.SetAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to store api key: %w", err)
+	}
+
+	key.Hash = ""
+	return &model.CreatedAPIKey{APIKey: key, Key: rawKey}, nil
+}
+
+// List returns the keys of the user.
+func (ks *APIKeyService) List(ctx context.Context, userId string) ([]model.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.List")
+	defer span.End()
+
+	keys, err := ks.storage.ListAPIKeys(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke removes a key of the user. Requests with it fail right away.
+func (ks *APIKeyService) Revoke(ctx context.Context, userId string, keyId string) error {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Revoke")
+	defer span.End()
+
+	if !validAPIKeyId(keyId) {
+		return ErrAPIKeyNotFound
+	}
+	key, err := ks.storage.GetAPIKey(ctx, keyId)
+	if err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if key.UserId != userId {
+		return ErrAPIKeyNotFound
+	}
+
+	if err := ks.storage.RemoveAPIKey(ctx, keyId); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	ks.mu.Lock()
+	delete(ks.limiters, keyId)
+	ks.mu.Unlock()
+
+	return nil
+}
+
+// IsAPIKey reports whether a credential looks like an API key rather than
+// an ID token.
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// Authenticate returns the stored key for a key sent with a request. It
+// fails with ErrAPIKeyNotFound for unknown keys and a *RateLimitError if
+// the key is over its rate limit.
+func (ks *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*model.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	id, _, ok := strings.Cut(strings.TrimPrefix(rawKey, APIKeyPrefix), "_")
+	if !IsAPIKey(rawKey) || !ok || !validAPIKeyId(id) {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	key, err := ks.storage.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashAPIKey(rawKey))) != 1 {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if retryAfter := ks.reserve(*key); retryAfter > 0 {
+		return nil, &RateLimitError{RetryAfter: retryAfter}
+	}
+
+	now := time.Now()
+	if now.Sub(key.LastUsedAt) >= lastUsedInterval {
+		if err := ks.storage.TouchAPIKey(ctx, key.Id, now); err != nil {
+			return nil, fmt.Errorf("failed to record api key use: %w", err)
+		}
+		key.LastUsedAt = now
+	}
+
+	key.Hash = ""
+	return key, nil
+}
+
+// reserve takes a request from the key's token bucket, returning how long
+// to wait if it is empty.
+func (ks *APIKeyService) reserve(key model.APIKey) time.Duration {
+	perMinute := key.RateLimit
+	if perMinute <= 0 {
+		perMinute = DefaultAPIKeyRateLimit
+	}
+
+	now := time.Now()
+
+	ks.mu.Lock()
+	if now.Sub(ks.lastSweep) > time.Minute {
+		ks.sweep(now)
+	}
+	kl, ok := ks.limiters[key.Id]
+	if !ok {
+		kl = &keyLimiter{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)}
+		ks.limiters[key.Id] = kl
+	}
+	kl.lastUsed = now
+	ks.mu.Unlock()
+
+	reservation := kl.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// sweep drops the buckets of keys idle for long enough to refill. ks.mu
+// must be held.
+func (ks *APIKeyService) sweep(now time.Time) {
+	for keyId, kl := range ks.limiters {
+		if now.Sub(kl.lastUsed) > time.Minute {
+			delete(ks.limiters, keyId)
+		}
+	}
+	ks.lastSweep = now
+}
@@ -0,0 +1,115 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/time/rate"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestAPIKeyService(t *testing.T) {
+	ctx := context.Background()
+	userId := "test-user"
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	service := NewAPIKeyService(NewAPIKeyServiceParams{Store: store})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := service.Create(ctx, userId, "", []model.APIKeyScope{model.APIKeyScopeRead}, 0)
+		require.ErrorIs(t, err, ErrInvalidAPIKey)
+		_, err = service.Create(ctx, userId, "script", nil, 0)
+		require.ErrorIs(t, err, ErrInvalidAPIKey)
+		_, err = service.Create(ctx, userId, "script", []model.APIKeyScope{"admin"}, 0)
+		require.ErrorIs(t, err, ErrInvalidAPIKey)
+		_, err = service.Create(ctx, userId, "script", []model.APIKeyScope{model.APIKeyScopeRead}, MaxAPIKeyRateLimit+1)
+		require.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	created, err := service.Create(ctx, userId, " import script ", []model.APIKeyScope{
+		model.APIKeyScopeWrite, model.APIKeyScopeRead, model.APIKeyScopeWrite,
+	}, 2)
+	require.NoError(t, err)
+	require.True(t, IsAPIKey(created.Key))
+	require.Equal(t, "import script", created.Name)
+	require.Equal(t, []model.APIKeyScope{model.APIKeyScopeRead, model.APIKeyScopeWrite}, created.Scopes)
+	require.Empty(t, created.Hash)
+
+	stored, err := store.GetAPIKey(ctx, created.Id)
+	require.NoError(t, err)
+	require.NotEmpty(t, stored.Hash)
+	require.NotEqual(t, created.Key, stored.Hash)
+
+	keys, err := service.List(ctx, userId)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.True(t, keys[0].LastUsedAt.IsZero())
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := service.Authenticate(ctx, created.Key+"x")
+		require.ErrorIs(t, err, ErrAPIKeyNotFound)
+		_, err = service.Authenticate(ctx, "dk_0123456789ab_secret")
+		require.ErrorIs(t, err, ErrAPIKeyNotFound)
+	})
+
+	t.Run("malformed id", func(t *testing.T) {
+		// The id is checked before the store is asked for the key.
+		service := NewAPIKeyService(NewAPIKeyServiceParams{Store: mocks.NewMockFirestore(gomock.NewController(t))})
+		for _, rawKey := range []string{"dk__x", "dk_a/b_x", "dk_unknown_secret", "dk_0123456789AB_x", "dk_0123456789abc_x"} {
+			_, err := service.Authenticate(ctx, rawKey)
+			require.ErrorIs(t, err, ErrAPIKeyNotFound, rawKey)
+		}
+		require.ErrorIs(t, service.Revoke(ctx, userId, "a/b"), ErrAPIKeyNotFound)
+	})
+
+	key, err := service.Authenticate(ctx, created.Key)
+	require.NoError(t, err)
+	require.Equal(t, userId, key.UserId)
+	require.True(t, key.Allows(model.APIKeyScopeRead))
+	require.True(t, key.Allows(model.APIKeyScopeWrite))
+
+	keys, err = service.List(ctx, userId)
+	require.NoError(t, err)
+	require.False(t, keys[0].LastUsedAt.IsZero())
+
+	// The key allows two requests per minute and the first one is used.
+	_, err = service.Authenticate(ctx, created.Key)
+	require.NoError(t, err)
+	_, err = service.Authenticate(ctx, created.Key)
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.Positive(t, rateLimitErr.RetryAfter)
+
+	require.ErrorIs(t, service.Revoke(ctx, "other-user", created.Id), ErrAPIKeyNotFound)
+	require.NoError(t, service.Revoke(ctx, userId, created.Id))
+	_, err = service.Authenticate(ctx, created.Key)
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyService_SweepsIdleLimiters(t *testing.T) {
+	service := NewAPIKeyService(NewAPIKeyServiceParams{})
+
+	idle := time.Now().Add(-2 * time.Minute)
+	service.limiters["idle"] = &keyLimiter{limiter: rate.NewLimiter(1, 1), lastUsed: idle}
+	service.lastSweep = idle
+
+	require.Zero(t, service.reserve(model.APIKey{Id: "active"}))
+	require.NotContains(t, service.limiters, "idle")
+	require.Contains(t, service.limiters, "active")
+}
+
+func TestAPIKey_Allows(t *testing.T) {
+	read := model.APIKey{Scopes: []model.APIKeyScope{model.APIKeyScopeRead}}
+	require.True(t, read.Allows(model.APIKeyScopeRead))
+	require.False(t, read.Allows(model.APIKeyScopeWrite))
+
+	write := model.APIKey{Scopes: []model.APIKeyScope{model.APIKeyScopeWrite}}
+	require.True(t, write.Allows(model.APIKeyScopeRead))
+	require.True(t, write.Allows(model.APIKeyScopeWrite))
+}
@@ -0,0 +1,210 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+// MaxBulkSize is the most items a single bulk request may carry.
+const MaxBulkSize = 300
+
+// BulkSaveWords creates or updates many vocab in one storage write. Vocab
+// with an id that already exists is updated, everything else is created
+// with the same validation and duplicate checks as AddWord. Items that fail,
+// in validation or in storage, are reported in their result and do not stop
+// the others.
+func (d *Dictionary) BulkSaveWords(ctx context.Context, vocabs []model.Vocab) ([]model.BulkResult, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.BulkSaveWords")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	if len(vocabs) > MaxBulkSize {
+		return nil, fmt.Errorf("%w: at most %d vocab per request", ErrInvalidVocab, MaxBulkSize)
+	}
+
+	var requestedIds []uuid.UUID
+	for _, vocab := range vocabs {
+		if vocab.Id != uuid.Nil {
+			requestedIds = append(requestedIds, vocab.Id)
+		}
+	}
+	stored, err := d.storage.GetMultipleVocabs(ctx, userId, requestedIds)
+	if err != nil {
+		return nil, fmt.Errorf("could not get existing vocab: %w", err)
+	}
+	existing := make(map[uuid.UUID]model.Vocab, len(stored))
+	for _, vocab := range stored {
+		existing[vocab.Id] = vocab
+	}
+
+	results := make([]model.BulkResult, len(vocabs))
+	seen := map[uuid.UUID]bool{}
+	var newTerms []string
+	for i := range vocabs {
+		assignIds(&vocabs[i])
+		results[i] = model.BulkResult{Index: i, Id: vocabs[i].Id}
+
+		// Storage rejects writing the same document twice in one batch.
+		if seen[vocabs[i].Id] {
+			results[i].Status = model.BulkStatusFailed
+			results[i].Reason = "vocab is listed more than once"
+			continue
+		}
+		seen[vocabs[i].Id] = true
+
+		if err := vocabs[i].Validate(); err != nil {
+			results[i].Status = model.BulkStatusFailed
+			results[i].Reason = err.Error()
+			continue
+		}
+
+		if _, ok := existing[vocabs[i].Id]; ok {
+			results[i].Status = model.BulkStatusUpdated
+		} else {
+			results[i].Status = model.BulkStatusCreated
+			newTerms = append(newTerms, exactTerms(vocabs[i])...)
+		}
+	}
+
+	candidates, err := d.findByExactTerms(ctx, userId, newTerms)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch model.VocabBatch
+	var updatedIds []uuid.UUID
+	now := time.Now()
+	for i, vocab := range vocabs {
+		switch results[i].Status {
+		case model.BulkStatusCreated:
+			// Earlier items of this request count as existing vocab too.
+			if duplicates := duplicatesOf(vocab, slices.Concat(candidates, batch.Set)); len(duplicates) > 0 {
+				results[i].Status = model.BulkStatusFailed
+				results[i].Reason = (&DuplicateVocabError{Existing: duplicates}).Error()
+				continue
+			}
+			vocab.CreatedAt = now
+		case model.BulkStatusUpdated:
+			vocab.CreatedAt = existing[vocab.Id].CreatedAt
+			updatedIds = append(updatedIds, vocab.Id)
+		default:
+			continue
+		}
+
+		vocab.DueAt = vocab.NextDue()
+		batch.Set = append(batch.Set, vocab)
+		batch.Index = append(batch.Index, newSearchEntry(vocab))
+	}
+
+	if len(batch.Set) == 0 {
+		return results, nil
+	}
+
+	failed, err := d.writeBulkBatch(ctx, userId, batch, results)
+	if err != nil {
+		return nil, fmt.Errorf("could not save vocab: %w", err)
+	}
+	updatedIds = slices.DeleteFunc(updatedIds, func(id uuid.UUID) bool { return failed[id] })
+
+	if len(updatedIds) > 0 {
+		if err := d.invalidatePool(ctx, userId, updatedIds...); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// BulkRemoveWords removes many vocab in one storage write, reporting ids
+// that do not exist or could not be removed as failed.
+func (d *Dictionary) BulkRemoveWords(ctx context.Context, vocabIds []uuid.UUID) ([]model.BulkResult, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.BulkRemoveWords")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	if len(vocabIds) > MaxBulkSize {
+		return nil, fmt.Errorf("%w: at most %d vocab per request", ErrInvalidVocab, MaxBulkSize)
+	}
+
+	stored, err := d.storage.GetMultipleVocabs(ctx, userId, vocabIds)
+	if err != nil {
+		return nil, fmt.Errorf("could not get existing vocab: %w", err)
+	}
+	found := make(map[uuid.UUID]bool, len(stored))
+	for _, vocab := range stored {
+		found[vocab.Id] = true
+	}
+
+	results := make([]model.BulkResult, len(vocabIds))
+	seen := map[uuid.UUID]bool{}
+	var batch model.VocabBatch
+	for i, vocabId := range vocabIds {
+		results[i] = model.BulkResult{Index: i, Id: vocabId, Status: model.BulkStatusDeleted}
+		switch {
+		case seen[vocabId]:
+			results[i].Status = model.BulkStatusFailed
+			results[i].Reason = "vocab is listed more than once"
+			continue
+		case !found[vocabId]:
+			results[i].Status = model.BulkStatusFailed
+			results[i].Reason = ErrVocabNotFound.Error()
+			continue
+		}
+		seen[vocabId] = true
+		batch.Delete = append(batch.Delete, vocabId)
+	}
+
+	if len(batch.Delete) == 0 {
+		return results, nil
+	}
+
+	failed, err := d.writeBulkBatch(ctx, userId, batch, results)
+	if err != nil {
+		return nil, fmt.Errorf("could not remove vocab: %w", err)
+	}
+	deleted := slices.DeleteFunc(batch.Delete, func(id uuid.UUID) bool { return failed[id] })
+
+	if len(deleted) > 0 {
+		if err := d.invalidatePool(ctx, userId, deleted...); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// writeBulkBatch writes the batch of a bulk request. Storage may apply
+// only part of it, so vocab whose writes failed are marked failed in
+// results instead of failing the request, and returned.
+func (d *Dictionary) writeBulkBatch(
+	ctx context.Context, userId string, batch model.VocabBatch, results []model.BulkResult,
+) (map[uuid.UUID]bool, error) {
+	err := d.storage.WriteVocabBatch(ctx, userId, batch)
+
+	var batchErr *BatchWriteError
+	if !errors.As(err, &batchErr) {
+		return nil, err
+	}
+
+	failed := make(map[uuid.UUID]bool, len(batchErr.Failed))
+	for i := range results {
+		writeErr, ok := batchErr.Failed[results[i].Id]
+		if ok && results[i].Status != model.BulkStatusFailed {
+			results[i].Status = model.BulkStatusFailed
+			results[i].Reason = writeErr.Error()
+			failed[results[i].Id] = true
+		}
+	}
+
+	return failed, nil
+}
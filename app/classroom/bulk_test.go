@@ -0,0 +1,229 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+func TestDictionary_BulkSaveWords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stored := model.Vocab{Id: uuid.New(), Definition: "house", CreatedAt: createdAt}
+	existingBread := model.Vocab{Id: uuid.New(), Definition: "bread"}
+
+	vocabs := []model.Vocab{
+		{Id: stored.Id, Definition: "a house"},
+		{Definition: "tree"},
+		{Definition: ""},
+		{Definition: "Bread"},
+		{Definition: "TREE"},
+		{Id: stored.Id, Definition: "house again"},
+	}
+
+	mockStore.EXPECT().
+		GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{stored.Id, stored.Id}).
+		Return([]model.Vocab{stored}, nil)
+	mockStore.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+	mockStore.EXPECT().
+		SearchVocabIds(gomock.Any(), userId, []string{"=tree", "=bread", "=tree"}, 0).
+		Return([]uuid.UUID{existingBread.Id}, nil)
+	mockStore.EXPECT().
+		GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{existingBread.Id}).
+		Return([]model.Vocab{existingBread}, nil)
+	mockStore.EXPECT().
+		WriteVocabBatch(gomock.Any(), userId, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, batch model.VocabBatch) error {
+			require.Len(t, batch.Set, 2)
+			require.Len(t, batch.Index, 2)
+			require.Empty(t, batch.Delete)
+			require.Equal(t, "a house", batch.Set[0].Definition)
+			require.Equal(t, createdAt, batch.Set[0].CreatedAt)
+			require.Equal(t, "tree", batch.Set[1].Definition)
+			require.False(t, batch.Set[1].CreatedAt.IsZero())
+			return nil
+		})
+	mockStore.EXPECT().
+		FetchUserPool(gomock.Any(), userId).
+		Return(&model.Pool{Vocabs: []model.Vocab{stored}}, nil)
+	mockStore.EXPECT().
+		UpdatePool(gomock.Any(), userId, &model.Pool{Vocabs: []model.Vocab{}}).
+		Return(nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	results, err := dict.BulkSaveWords(ctx, vocabs)
+	require.NoError(t, err)
+	require.Len(t, results, len(vocabs))
+
+	expected := []model.BulkStatus{
+		model.BulkStatusUpdated,
+		model.BulkStatusCreated,
+		model.BulkStatusFailed,
+		model.BulkStatusFailed,
+		model.BulkStatusFailed,
+		model.BulkStatusFailed,
+	}
+	for i, result := range results {
+		require.Equal(t, i, result.Index)
+		require.NotEqual(t, uuid.Nil, result.Id)
+		require.Equal(t, expected[i], result.Status, "item %d", i)
+	}
+	require.Contains(t, results[3].Reason, existingBread.Id.String())
+	require.Contains(t, results[4].Reason, results[1].Id.String())
+}
+
+func TestDictionary_BulkSaveWords_PartialWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	house := model.Vocab{Id: uuid.New(), Definition: "house"}
+	tree := model.Vocab{Id: uuid.New(), Definition: "tree"}
+
+	mockStore.EXPECT().
+		GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{house.Id, tree.Id}).
+		Return([]model.Vocab{house, tree}, nil)
+	mockStore.EXPECT().
+		WriteVocabBatch(gomock.Any(), userId, gomock.Any()).
+		Return(&BatchWriteError{Failed: map[uuid.UUID]error{tree.Id: errors.New("deadline exceeded")}})
+	// Only the vocab that was written leaves the pool.
+	mockStore.EXPECT().
+		FetchUserPool(gomock.Any(), userId).
+		Return(&model.Pool{Vocabs: []model.Vocab{house, tree}}, nil)
+	mockStore.EXPECT().
+		UpdatePool(gomock.Any(), userId, &model.Pool{Vocabs: []model.Vocab{tree}}).
+		Return(nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	results, err := dict.BulkSaveWords(ctx, []model.Vocab{house, tree})
+	require.NoError(t, err)
+	require.Equal(t, []model.BulkResult{
+		{Index: 0, Id: house.Id, Status: model.BulkStatusUpdated},
+		{Index: 1, Id: tree.Id, Status: model.BulkStatusFailed, Reason: "deadline exceeded"},
+	}, results)
+}
+
+func TestDictionary_BulkSaveWords_Errors(t *testing.T) {
+	userId := "test-user"
+
+	tests := []struct {
+		name      string
+		vocabs    []model.Vocab
+		setupMock func(*mocks.MockFirestore)
+	}{
+		{
+			name:      "too many vocab",
+			vocabs:    make([]model.Vocab, MaxBulkSize+1),
+			setupMock: func(mock *mocks.MockFirestore) {},
+		},
+		{
+			name:   "write fails",
+			vocabs: []model.Vocab{{Definition: "tree"}},
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetMultipleVocabs(gomock.Any(), userId, nil).Return([]model.Vocab{}, nil)
+				mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+				mock.EXPECT().SearchVocabIds(gomock.Any(), userId, []string{"=tree"}, 0).Return(nil, nil)
+				mock.EXPECT().WriteVocabBatch(gomock.Any(), userId, gomock.Any()).Return(errors.New("test error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockFirestore(ctrl)
+			tt.setupMock(mockStore)
+
+			dict := &Dictionary{storage: mockStore}
+			ctx := userid.ToCtx(context.Background(), userId)
+
+			results, err := dict.BulkSaveWords(ctx, tt.vocabs)
+			require.Error(t, err)
+			require.Nil(t, results)
+		})
+	}
+}
+
+func TestDictionary_BulkRemoveWords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	storedId := uuid.New()
+	missingId := uuid.New()
+	ids := []uuid.UUID{storedId, missingId, storedId}
+
+	mockStore.EXPECT().
+		GetMultipleVocabs(gomock.Any(), userId, ids).
+		Return([]model.Vocab{{Id: storedId}}, nil)
+	mockStore.EXPECT().
+		WriteVocabBatch(gomock.Any(), userId, model.VocabBatch{Delete: []uuid.UUID{storedId}}).
+		Return(nil)
+	mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	results, err := dict.BulkRemoveWords(ctx, ids)
+	require.NoError(t, err)
+	require.Equal(t, []model.BulkResult{
+		{Index: 0, Id: storedId, Status: model.BulkStatusDeleted},
+		{Index: 1, Id: missingId, Status: model.BulkStatusFailed, Reason: ErrVocabNotFound.Error()},
+		{Index: 2, Id: storedId, Status: model.BulkStatusFailed, Reason: "vocab is listed more than once"},
+	}, results)
+}
+
+func TestDictionary_BulkRemoveWords_PartialWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	deletedId, failedId := uuid.New(), uuid.New()
+	ids := []uuid.UUID{deletedId, failedId}
+
+	mockStore.EXPECT().
+		GetMultipleVocabs(gomock.Any(), userId, ids).
+		Return([]model.Vocab{{Id: deletedId}, {Id: failedId}}, nil)
+	mockStore.EXPECT().
+		WriteVocabBatch(gomock.Any(), userId, gomock.Any()).
+		Return(&BatchWriteError{Failed: map[uuid.UUID]error{failedId: errors.New("deadline exceeded")}})
+	mockStore.EXPECT().
+		FetchUserPool(gomock.Any(), userId).
+		Return(&model.Pool{Vocabs: []model.Vocab{{Id: deletedId}, {Id: failedId}}}, nil)
+	mockStore.EXPECT().
+		UpdatePool(gomock.Any(), userId, &model.Pool{Vocabs: []model.Vocab{{Id: failedId}}}).
+		Return(nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	results, err := dict.BulkRemoveWords(ctx, ids)
+	require.NoError(t, err)
+	require.Equal(t, []model.BulkResult{
+		{Index: 0, Id: deletedId, Status: model.BulkStatusDeleted},
+		{Index: 1, Id: failedId, Status: model.BulkStatusFailed, Reason: "deadline exceeded"},
+	}, results)
+}
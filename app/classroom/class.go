@@ -0,0 +1,537 @@
+package classroom
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+)
+
+var (
+	ErrClassNotFound          = newError(ErrNotFound, "class_not_found", "class not found")
+	ErrAssignmentNotFound     = newError(ErrNotFound, "assignment_not_found", "assignment not found")
+	ErrAssignmentCopyNotFound = newError(ErrNotFound, "assignment_copy_not_found", "assignment copy not found")
+	ErrInvalidInviteCode      = newError(ErrNotFound, "invalid_invite_code", "invalid invite code")
+	ErrNotClassTeacher        = newError(ErrForbidden, "not_class_teacher", "only the teacher of the class may do this")
+	ErrInvalidClass           = newError(ErrValidation, "invalid_class", "invalid class")
+)
+
+// inviteCodeAlphabet leaves out letters and digits that are easily mixed
+// up when a code is read aloud or copied from a board.
+const (
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 8
+)
+
+// MaxClassNameLength is the longest name a class may have.
+const MaxClassNameLength = 100
+
+// ClassService manages classes: a teacher creates a class, students join
+// it with the invite code, and sets the teacher assigns are copied into the
+// library of every student, including students who join later.
+type ClassService struct {
+	storage Firestore
+	dict    *Dictionary
+}
+
+type NewClassServiceParams struct {
+	fx.In
+	Store Firestore
+	Dict  *Dictionary
+}
+
+func NewClassService(p NewClassServiceParams) *ClassService {
+	return &ClassService{
+		storage: p.Store,
+		dict:    p.Dict,
+	}
+}
+
+func newInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// normalizeInviteCode accepts codes typed in lower case or with spaces and
+// dashes.
+func normalizeInviteCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+}
+
+// viewFor hides what only the teacher may see from everybody else.
+func viewFor(class model.Class, userId string) model.Class {
+	if class.TeacherId != userId {
+		class.InviteCode = ""
+		class.StudentIds = nil
+	}
+	return class
+}
+
+// memberClass returns the class if the user teaches or attends it. Other
+// users get ErrClassNotFound so class ids cannot be probed.
+func (cs *ClassService) memberClass(ctx context.Context, userId string, classId uuid.UUID) (*model.Class, error) {
+	class, err := cs.storage.GetClass(ctx, classId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+	if class.TeacherId != userId && !slices.Contains(class.StudentIds, userId) {
+		return nil, ErrClassNotFound
+	}
+	return class, nil
+}
+
+// teacherClass returns the class if the user teaches it.
+func (cs *ClassService) teacherClass(ctx context.Context, userId string, classId uuid.UUID) (*model.Class, error) {
+	class, err := cs.memberClass(ctx, userId, classId)
+	if err != nil {
+		return nil, err
+	}
+	if class.TeacherId != userId {
+		return nil, ErrNotClassTeacher
+	}
+	return class, nil
+}
+
+// CreateClass creates a class taught by the user.
+func (cs *ClassService) CreateClass(ctx context.Context, teacherId string, name string) (*model.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.CreateClass")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	var val validate.Validator
+	val.Required("name", name)
+	val.MaxLength("name", name, MaxClassNameLength)
+	if err := val.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidClass, err)
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite code: %w", err)
+	}
+
+	class := model.Class{
+		Id:         uuid.New(),
+		Name:       name,
+		TeacherId:  teacherId,
+		InviteCode: code,
+		CreatedAt:  time.Now(),
+	}
+	if err := cs.storage.SetClass(ctx, class); err != nil {
+		return nil, fmt.Errorf("failed to add class: %w", err)
+	}
+
+	return &class, nil
+}
+
+// ListClasses returns the classes the user teaches or attends.
+func (cs *ClassService) ListClasses(ctx context.Context, userId string) ([]model.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.ListClasses")
+	defer span.End()
+
+	classes, err := cs.storage.ListUserClasses(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+
+	result := make([]model.Class, len(classes))
+	for i, class := range classes {
+		result[i] = viewFor(class, userId)
+	}
+	return result, nil
+}
+
+// GetClass returns a class the user teaches or attends.
+func (cs *ClassService) GetClass(ctx context.Context, userId string, classId uuid.UUID) (*model.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.GetClass")
+	defer span.End()
+
+	class, err := cs.memberClass(ctx, userId, classId)
+	if err != nil {
+		return nil, err
+	}
+	view := viewFor(*class, userId)
+	return &view, nil
+}
+
+// DeleteClass removes the class and its assignments. The vocab copied into
+// the students' libraries stays theirs.
+func (cs *ClassService) DeleteClass(ctx context.Context, teacherId string, classId uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ClassService.DeleteClass")
+	defer span.End()
+
+	if _, err := cs.teacherClass(ctx, teacherId, classId); err != nil {
+		return err
+	}
+	if err := cs.storage.RemoveClass(ctx, classId); err != nil {
+		return fmt.Errorf("failed to remove class: %w", err)
+	}
+	return nil
+}
+
+// RotateInviteCode replaces the invite code so the old one stops working.
+func (cs *ClassService) RotateInviteCode(ctx context.Context, teacherId string, classId uuid.UUID) (*model.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.RotateInviteCode")
+	defer span.End()
+
+	class, err := cs.teacherClass(ctx, teacherId, classId)
+	if err != nil {
+		return nil, err
+	}
+
+	if class.InviteCode, err = newInviteCode(); err != nil {
+		return nil, fmt.Errorf("failed to create invite code: %w", err)
+	}
+	if err := cs.storage.SetClassInviteCode(ctx, classId, class.InviteCode); err != nil {
+		return nil, fmt.Errorf("failed to update class: %w", err)
+	}
+
+	return class, nil
+}
+
+// Join enrolls the user in the class with the invite code and copies every
+// assignment of the class into the user's library. Joining a class again
+// does nothing.
+func (cs *ClassService) Join(ctx context.Context, studentId string, code string) (*model.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.Join")
+	defer span.End()
+
+	code = normalizeInviteCode(code)
+	if code == "" {
+		return nil, ErrInvalidInviteCode
+	}
+
+	class, err := cs.storage.FindClassByInviteCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find class: %w", err)
+	}
+	if class.TeacherId == studentId {
+		return nil, fmt.Errorf("%w: teachers cannot join their own class", ErrInvalidClass)
+	}
+	if slices.Contains(class.StudentIds, studentId) {
+		view := viewFor(*class, studentId)
+		return &view, nil
+	}
+
+	if err := cs.storage.AddClassStudent(ctx, class.Id, studentId); err != nil {
+		return nil, fmt.Errorf("failed to update class: %w", err)
+	}
+	class.StudentIds = append(class.StudentIds, studentId)
+
+	assignments, err := cs.storage.ListAssignments(ctx, class.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		assignment.Vocabs, err = cs.storage.GetAssignmentVocabs(ctx, class.Id, assignment.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignment vocab: %w", err)
+		}
+		if err := cs.copyToStudent(ctx, class, &assignment, studentId); err != nil {
+			return nil, err
+		}
+	}
+
+	view := viewFor(*class, studentId)
+	return &view, nil
+}
+
+// RemoveStudent takes a student out of the class. The teacher may remove
+// anyone, students may only remove themselves.
+func (cs *ClassService) RemoveStudent(ctx context.Context, userId string, classId uuid.UUID, studentId string) error {
+	ctx, span := tracer.Start(ctx, "ClassService.RemoveStudent")
+	defer span.End()
+
+	class, err := cs.memberClass(ctx, userId, classId)
+	if err != nil {
+		return err
+	}
+	if class.TeacherId != userId && studentId != userId {
+		return ErrNotClassTeacher
+	}
+
+	if !slices.Contains(class.StudentIds, studentId) {
+		return fmt.Errorf("%w: not a student of the class", ErrInvalidClass)
+	}
+
+	if err := cs.storage.RemoveClassStudent(ctx, classId, studentId); err != nil {
+		return fmt.Errorf("failed to update class: %w", err)
+	}
+	return nil
+}
+
+// Assign assigns one of the teacher's sets to the class and copies its
+// vocab, without the teacher's progress, into every student's library. An
+// empty name defaults to the name of the set.
+//
+// The assignment is stored first so students joining meanwhile get it as
+// well. If a copy fails the assignment is removed again; the copies made
+// so far stay, and a retry updates them rather than adding more.
+func (cs *ClassService) Assign(
+	ctx context.Context, teacherId string, classId uuid.UUID, setId uuid.UUID, name string, dueAt time.Time,
+) (*model.Assignment, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.Assign")
+	defer span.End()
+
+	class, err := cs.teacherClass(ctx, teacherId, classId)
+	if err != nil {
+		return nil, err
+	}
+	if dueAt.IsZero() {
+		return nil, fmt.Errorf("%w: due date is required", ErrInvalidClass)
+	}
+
+	vocabSet, err := cs.storage.GetVocabSet(ctx, teacherId, setId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocab set: %w", err)
+	}
+	vocabs, err := cs.storage.GetMultipleVocabs(ctx, teacherId, vocabSet.VocabIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocab items for set: %w", err)
+	}
+	if len(vocabs) == 0 {
+		return nil, fmt.Errorf("%w: the set has no vocab", ErrInvalidVocab)
+	}
+	if len(vocabs) > MaxImportSize {
+		return nil, fmt.Errorf("%w: at most %d vocab per assignment", ErrInvalidVocab, MaxImportSize)
+	}
+
+	assignment := model.Assignment{
+		Id:         uuid.New(),
+		ClassId:    classId,
+		SetId:      setId,
+		Name:       strings.TrimSpace(name),
+		DueAt:      dueAt,
+		VocabCount: len(vocabs),
+		Vocabs:     make([]model.Vocab, len(vocabs)),
+		CreatedAt:  time.Now(),
+		Copies:     map[string]model.AssignmentCopy{},
+	}
+	if assignment.Name == "" {
+		assignment.Name = vocabSet.Name
+	}
+	for i, vocab := range vocabs {
+		assignment.Vocabs[i] = snapshot(vocab)
+	}
+
+	if err := cs.storage.SetAssignment(ctx, assignment); err != nil {
+		return nil, fmt.Errorf("failed to add assignment: %w", err)
+	}
+
+	for _, studentId := range class.StudentIds {
+		if err := cs.copyToStudent(ctx, class, &assignment, studentId); err != nil {
+			if removeErr := cs.storage.RemoveAssignment(ctx, classId, assignment.Id); removeErr != nil {
+				return nil, fmt.Errorf("%w; failed to remove assignment: %w", err, removeErr)
+			}
+			return nil, err
+		}
+	}
+
+	assignment.Vocabs = nil
+	return &assignment, nil
+}
+
+// assignmentSetId is the id of the set a student gets for an assignment of
+// a set to a class. It is the same on every attempt, so a repeated copy
+// updates the set instead of adding another one.
+func assignmentSetId(classId uuid.UUID, setId uuid.UUID, studentId string) uuid.UUID {
+	return uuid.NewSHA1(classId, []byte(setId.String()+"/"+studentId))
+}
+
+// copyToStudent imports the assignment's vocab into the student's library,
+// merging it with words the student already has, and adds a set holding
+// it. The copy is recorded once it is complete.
+func (cs *ClassService) copyToStudent(
+	ctx context.Context, class *model.Class, assignment *model.Assignment, studentId string,
+) error {
+	results, err := cs.dict.ImportWords(userid.ToCtx(ctx, studentId), assignment.Vocabs, false)
+	if err != nil {
+		return fmt.Errorf("failed to copy assignment to student %s: %w", studentId, err)
+	}
+
+	set := model.VocabSet{
+		Id:       assignmentSetId(class.Id, assignment.SetId, studentId),
+		Name:     class.Name + ": " + assignment.Name,
+		VocabIds: []uuid.UUID{},
+	}
+	for _, r := range results {
+		if r.Status != model.ImportStatusRejected && !slices.Contains(set.VocabIds, r.Id) {
+			set.VocabIds = append(set.VocabIds, r.Id)
+		}
+	}
+	if err := cs.storage.SetVocabSet(ctx, studentId, set); err != nil {
+		return fmt.Errorf("failed to add assignment set for student %s: %w", studentId, err)
+	}
+
+	copied := model.AssignmentCopy{SetId: set.Id, VocabIds: set.VocabIds}
+	if err := cs.storage.SetAssignmentCopy(ctx, class.Id, assignment.Id, studentId, copied); err != nil {
+		return fmt.Errorf("failed to record assignment copy for student %s: %w", studentId, err)
+	}
+
+	if assignment.Copies == nil {
+		assignment.Copies = map[string]model.AssignmentCopy{}
+	}
+	assignment.Copies[studentId] = copied
+	return nil
+}
+
+// ListAssignments returns the assignments of a class ordered by due date.
+// Students only see their own copy.
+func (cs *ClassService) ListAssignments(ctx context.Context, userId string, classId uuid.UUID) ([]model.Assignment, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.ListAssignments")
+	defer span.End()
+
+	class, err := cs.memberClass(ctx, userId, classId)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := cs.storage.ListAssignments(ctx, classId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+	for i := range assignments {
+		if class.TeacherId == userId {
+			assignments[i].Copies, err = cs.storage.ListAssignmentCopies(ctx, classId, assignments[i].Id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list assignment copies: %w", err)
+			}
+			continue
+		}
+
+		own, err := cs.storage.GetAssignmentCopy(ctx, classId, assignments[i].Id, userId)
+		switch {
+		case errors.Is(err, ErrAssignmentCopyNotFound):
+		case err != nil:
+			return nil, fmt.Errorf("failed to get assignment copy: %w", err)
+		default:
+			assignments[i].Copies = map[string]model.AssignmentCopy{userId: *own}
+		}
+	}
+
+	return assignments, nil
+}
+
+// RemoveAssignment removes an assignment from the class. The copies in the
+// students' libraries are kept.
+func (cs *ClassService) RemoveAssignment(
+	ctx context.Context, teacherId string, classId uuid.UUID, assignmentId uuid.UUID,
+) error {
+	ctx, span := tracer.Start(ctx, "ClassService.RemoveAssignment")
+	defer span.End()
+
+	if _, err := cs.teacherClass(ctx, teacherId, classId); err != nil {
+		return err
+	}
+
+	assignments, err := cs.storage.ListAssignments(ctx, classId)
+	if err != nil {
+		return fmt.Errorf("failed to list assignments: %w", err)
+	}
+	if !slices.ContainsFunc(assignments, func(a model.Assignment) bool { return a.Id == assignmentId }) {
+		return ErrAssignmentNotFound
+	}
+
+	if err := cs.storage.RemoveAssignment(ctx, classId, assignmentId); err != nil {
+		return fmt.Errorf("failed to remove assignment: %w", err)
+	}
+	return nil
+}
+
+// Dashboard shows the teacher each student's progress on each assignment,
+// computed from the levels of the assigned forms in the student's library.
+func (cs *ClassService) Dashboard(ctx context.Context, teacherId string, classId uuid.UUID) (*model.ClassDashboard, error) {
+	ctx, span := tracer.Start(ctx, "ClassService.Dashboard")
+	defer span.End()
+
+	class, err := cs.teacherClass(ctx, teacherId, classId)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := cs.storage.ListAssignments(ctx, classId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+
+	now := time.Now()
+	dashboard := &model.ClassDashboard{
+		Class:       *class,
+		Assignments: make([]model.Assignment, len(assignments)),
+		Students:    make([]model.StudentProgress, 0, len(class.StudentIds)),
+	}
+	copies := make([]map[string]model.AssignmentCopy, len(assignments))
+	for i, assignment := range assignments {
+		dashboard.Assignments[i] = assignment
+		copies[i], err = cs.storage.ListAssignmentCopies(ctx, classId, assignment.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assignment copies: %w", err)
+		}
+	}
+
+	for _, studentId := range class.StudentIds {
+		student := model.StudentProgress{
+			StudentId:   studentId,
+			Assignments: make([]model.AssignmentProgress, 0, len(assignments)),
+		}
+		for i, assignment := range assignments {
+			var vocabs []model.Vocab
+			if copied, ok := copies[i][studentId]; ok {
+				vocabs, err = cs.storage.GetMultipleVocabs(ctx, studentId, copied.VocabIds)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get vocab of student %s: %w", studentId, err)
+				}
+			}
+			student.Assignments = append(student.Assignments, assignmentProgress(assignment, vocabs, now))
+		}
+		dashboard.Students = append(dashboard.Students, student)
+	}
+
+	return dashboard, nil
+}
+
+// assignmentProgress sums up the levels of all forms the student has of the
+// assigned vocab, so forms the student added count as well.
+func assignmentProgress(assignment model.Assignment, vocabs []model.Vocab, now time.Time) model.AssignmentProgress {
+	progress := model.AssignmentProgress{AssignmentId: assignment.Id}
+
+	levels := 0
+	for _, vocab := range vocabs {
+		for _, form := range vocab.Forms {
+			progress.Forms++
+			levels += form.Level
+			if form.Level > 0 {
+				progress.Learned++
+			}
+		}
+	}
+
+	if progress.Forms > 0 {
+		progress.AverageLevel = float64(levels) / float64(progress.Forms)
+		progress.Percent = 100 * progress.Learned / progress.Forms
+	}
+	progress.Overdue = now.After(assignment.DueAt) && (progress.Forms == 0 || progress.Learned < progress.Forms)
+
+	return progress
+}
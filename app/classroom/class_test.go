@@ -0,0 +1,183 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestClassService(t *testing.T) {
+	ctx := context.Background()
+	teacher, early, late := "teacher", "early-student", "late-student"
+
+	store, dict := newLocalDictionary(t)
+	service := &ClassService{storage: store, dict: dict}
+
+	set := addSet(t, dict, teacher, "Home",
+		model.Vocab{Definition: "house", Forms: []model.VocabForm{
+			{Value: "hus", Form: "indefinite_singular", Level: 5},
+			{Value: "huset", Form: "definite_singular", Level: 5},
+		}},
+		model.Vocab{Definition: "tree", Forms: []model.VocabForm{{Value: "træ", Form: "indefinite_singular", Level: 5}}},
+	)
+
+	class, err := service.CreateClass(ctx, teacher, "Danish 1")
+	require.NoError(t, err)
+	require.Len(t, class.InviteCode, inviteCodeLength)
+
+	_, err = service.Join(ctx, teacher, class.InviteCode)
+	require.ErrorIs(t, err, ErrInvalidClass)
+	_, err = service.Join(ctx, early, "WRONG123")
+	require.ErrorIs(t, err, ErrInvalidInviteCode)
+
+	joined, err := service.Join(ctx, early, strings.ToLower(class.InviteCode))
+	require.NoError(t, err)
+	require.Empty(t, joined.InviteCode, "students do not see the invite code")
+
+	_, err = service.Assign(ctx, early, class.Id, set.Id, "", time.Now().Add(time.Hour))
+	require.ErrorIs(t, err, ErrNotClassTeacher)
+	_, err = service.Assign(ctx, teacher, class.Id, set.Id, "", time.Time{})
+	require.ErrorIs(t, err, ErrInvalidClass)
+
+	dueAt := time.Now().Add(24 * time.Hour)
+	assignment, err := service.Assign(ctx, teacher, class.Id, set.Id, "", dueAt)
+	require.NoError(t, err)
+	require.Equal(t, "Home", assignment.Name)
+	require.Contains(t, assignment.Copies, early)
+
+	// The copy carries no progress of the teacher.
+	copied, err := store.FetchUserVocabulary(ctx, early)
+	require.NoError(t, err)
+	require.Len(t, copied, 2)
+	for _, vocab := range copied {
+		for _, form := range vocab.Forms {
+			require.Zero(t, form.Level)
+		}
+	}
+	sets, err := store.FetchUserVocabSets(ctx, early)
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+	require.Equal(t, "Danish 1: Home", sets[0].Name)
+
+	// A student joining later gets the assignment as well.
+	_, err = service.Join(ctx, late, class.InviteCode)
+	require.NoError(t, err)
+	copied, err = store.FetchUserVocabulary(ctx, late)
+	require.NoError(t, err)
+	require.Len(t, copied, 2)
+
+	// The early student learns one of the three forms.
+	copied, err = store.FetchUserVocabulary(ctx, early)
+	require.NoError(t, err)
+	learned := copied[0]
+	learned.Forms[0].Level = 2
+	require.NoError(t, store.AddVocabulary(ctx, early, learned))
+
+	_, err = service.Dashboard(ctx, early, class.Id)
+	require.ErrorIs(t, err, ErrNotClassTeacher)
+
+	dashboard, err := service.Dashboard(ctx, teacher, class.Id)
+	require.NoError(t, err)
+	require.Len(t, dashboard.Assignments, 1)
+	require.Len(t, dashboard.Students, 2)
+	progress := dashboard.Students[0].Assignments[0]
+	require.Equal(t, early, dashboard.Students[0].StudentId)
+	require.Equal(t, 3, progress.Forms)
+	require.Equal(t, 1, progress.Learned)
+	require.Equal(t, 33, progress.Percent)
+	require.False(t, progress.Overdue)
+	require.Zero(t, dashboard.Students[1].Assignments[0].Learned)
+
+	listed, err := service.ListAssignments(ctx, late, class.Id)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Len(t, listed[0].Copies, 1)
+	require.Contains(t, listed[0].Copies, late)
+
+	require.ErrorIs(t, service.RemoveStudent(ctx, late, class.Id, early), ErrNotClassTeacher)
+	require.NoError(t, service.RemoveStudent(ctx, late, class.Id, late))
+	_, err = service.GetClass(ctx, late, class.Id)
+	require.ErrorIs(t, err, ErrClassNotFound)
+
+	require.NoError(t, service.DeleteClass(ctx, teacher, class.Id))
+	classes, err := service.ListClasses(ctx, early)
+	require.NoError(t, err)
+	require.Empty(t, classes)
+}
+
+// failingStore fails to add sets for one user until it is told otherwise.
+type failingStore struct {
+	*LocalStore
+	failUser string
+}
+
+func (s *failingStore) SetVocabSet(ctx context.Context, userId string, vocabSet model.VocabSet) error {
+	if userId == s.failUser {
+		return errors.New("test error")
+	}
+	return s.LocalStore.SetVocabSet(ctx, userId, vocabSet)
+}
+
+func TestClassService_AssignRetry(t *testing.T) {
+	ctx := context.Background()
+	teacher, first, second := "teacher", "first-student", "second-student"
+
+	local, dict := newLocalDictionary(t)
+	store := &failingStore{LocalStore: local, failUser: second}
+	service := &ClassService{storage: store, dict: &Dictionary{storage: store}}
+
+	set := addSet(t, dict, teacher, "Home", model.Vocab{
+		Definition: "house",
+		Forms:      []model.VocabForm{{Value: "hus", Form: "indefinite_singular"}},
+	})
+	class, err := service.CreateClass(ctx, teacher, "Danish 1")
+	require.NoError(t, err)
+	for _, student := range []string{first, second} {
+		require.NoError(t, store.AddClassStudent(ctx, class.Id, student))
+	}
+
+	dueAt := time.Now().Add(time.Hour)
+	_, err = service.Assign(ctx, teacher, class.Id, set.Id, "", dueAt)
+	require.Error(t, err)
+	assignments, err := service.ListAssignments(ctx, teacher, class.Id)
+	require.NoError(t, err)
+	require.Empty(t, assignments, "a failed assignment is removed")
+
+	store.failUser = ""
+	assignment, err := service.Assign(ctx, teacher, class.Id, set.Id, "", dueAt)
+	require.NoError(t, err)
+	require.Len(t, assignment.Copies, 2)
+
+	// The retry updated the set the first student got before.
+	sets, err := store.FetchUserVocabSets(ctx, first)
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+	require.Equal(t, assignment.Copies[first].SetId, sets[0].Id)
+	vocabs, err := store.FetchUserVocabulary(ctx, first)
+	require.NoError(t, err)
+	require.Len(t, vocabs, 1)
+
+	classes, err := service.ListClasses(ctx, "stranger")
+	require.NoError(t, err)
+	require.NotNil(t, classes)
+}
+
+func TestAssignmentProgress(t *testing.T) {
+	now := time.Now()
+	assignment := model.Assignment{Id: uuid.New(), DueAt: now.Add(-time.Hour)}
+	vocabs := []model.Vocab{{Forms: []model.VocabForm{{Level: 2}, {Level: 1}, {Level: 0}, {Level: 1}}}}
+
+	progress := assignmentProgress(assignment, vocabs, now)
+	require.Equal(t, 4, progress.Forms)
+	require.Equal(t, 3, progress.Learned)
+	require.Equal(t, 75, progress.Percent)
+	require.InDelta(t, 1.0, progress.AverageLevel, 0.001)
+	require.True(t, progress.Overdue)
+}
@@ -2,7 +2,10 @@ package classroom
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,12 +13,36 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/mergepatch"
+	"github.com/vladazn/danish/common/textnorm"
 	"github.com/vladazn/danish/common/userid"
 )
 
+var (
+	ErrVocabNotFound  = newError(ErrNotFound, "vocab_not_found", "vocab not found")
+	ErrFormNotFound   = newError(ErrNotFound, "form_not_found", "vocab form not found")
+	ErrInvalidVocab   = newError(ErrValidation, "invalid_vocab", "invalid vocab")
+	ErrInvalidQuery   = newError(ErrValidation, "invalid_query", "invalid vocab query")
+	ErrDuplicateVocab = newError(ErrConflict, "duplicate_vocab", "vocab already exists")
+)
+
+// DuplicateVocabError is returned when a word already exists in the user's
+// vocabulary under another id.
+type DuplicateVocabError struct {
+	Existing []model.Vocab
+}
+
+func (e *DuplicateVocabError) Error() string {
+	return fmt.Sprintf("vocab already exists as %s", e.Existing[0].Id)
+}
+
+func (e *DuplicateVocabError) Unwrap() error {
+	return ErrDuplicateVocab
+}
+
 type NewDictionaryParams struct {
 	fx.In
-	Store *FirebaseStore
+	Store Firestore
 }
 
 func NewDictionary(p NewDictionaryParams) *Dictionary {
@@ -29,19 +56,419 @@ type Dictionary struct {
 }
 
 func (d *Dictionary) AddWord(ctx context.Context, vocab model.Vocab) (model.Vocab, error) {
-	if vocab.Id == uuid.Nil {
-		vocab.Id = uuid.New()
+	ctx, span := tracer.Start(ctx, "Dictionary.AddWord")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	assignIds(&vocab)
+	if err := vocab.Validate(); err != nil {
+		return vocab, fmt.Errorf("%w: %w", ErrInvalidVocab, err)
 	}
 
-	for i, form := range vocab.Forms {
-		if form.Id == uuid.Nil {
-			vocab.Forms[i].Id = uuid.New()
-		}
+	duplicates, err := d.findDuplicates(ctx, userId, vocab)
+	if err != nil {
+		return vocab, err
+	}
+	if len(duplicates) > 0 {
+		return vocab, &DuplicateVocabError{Existing: duplicates}
+	}
+
+	vocab.CreatedAt = time.Now()
+	vocab.DueAt = vocab.NextDue()
+
+	if err := d.storage.AddVocabulary(ctx, userId, vocab); err != nil {
+		return vocab, err
+	}
+
+	if err := d.storage.SetSearchEntry(ctx, userId, newSearchEntry(vocab)); err != nil {
+		return vocab, fmt.Errorf("could not index vocab: %w", err)
 	}
-	return vocab, d.storage.AddVocabulary(ctx, userid.MustFromCtx(ctx), vocab)
+
+	return vocab, nil
 }
 
 func (d *Dictionary) UpdateWord(ctx context.Context, vocab model.Vocab) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.UpdateWord")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	assignIds(&vocab)
+
+	// Clients are not trusted with the creation time, keep the stored one.
+	existing, err := d.storage.GetVocab(ctx, userId, vocab.Id)
+	switch {
+	case errors.Is(err, ErrVocabNotFound):
+		vocab.CreatedAt = time.Now()
+	case err != nil:
+		return vocab, fmt.Errorf("could not get vocab: %w", err)
+	default:
+		vocab.CreatedAt = existing.CreatedAt
+	}
+
+	if err := d.saveWord(ctx, userId, &vocab); err != nil {
+		return vocab, err
+	}
+
+	return vocab, nil
+}
+
+// GetWord returns a single vocab of the user.
+func (d *Dictionary) GetWord(ctx context.Context, vocabId uuid.UUID) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.GetWord")
+	defer span.End()
+
+	return d.loadWord(ctx, userid.MustFromCtx(ctx), vocabId)
+}
+
+// ListWords returns a filtered and sorted page of the user's vocabulary.
+func (d *Dictionary) ListWords(ctx context.Context, query model.VocabQuery) (*model.VocabPage, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.ListWords")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	return d.storage.QueryUserVocabulary(ctx, userId, query)
+}
+
+// PatchWord applies a JSON merge patch to the stored vocab.
+func (d *Dictionary) PatchWord(ctx context.Context, vocabId uuid.UUID, patch []byte) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.PatchWord")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	vocab, err := d.loadWord(ctx, userId, vocabId)
+	if err != nil {
+		return model.Vocab{}, err
+	}
+
+	if err := applyPatch(&vocab, patch); err != nil {
+		return model.Vocab{}, err
+	}
+	vocab.Id = vocabId
+
+	assignIds(&vocab)
+	if err := d.saveWord(ctx, userId, &vocab); err != nil {
+		return vocab, err
+	}
+
+	return vocab, nil
+}
+
+// PatchForm applies a JSON merge patch to a single form of the stored vocab.
+func (d *Dictionary) PatchForm(
+	ctx context.Context, vocabId uuid.UUID, formId uuid.UUID, patch []byte,
+) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.PatchForm")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	vocab, err := d.loadWord(ctx, userId, vocabId)
+	if err != nil {
+		return model.Vocab{}, err
+	}
+
+	form, ok := vocab.Form(formId)
+	if !ok {
+		return model.Vocab{}, ErrFormNotFound
+	}
+
+	if err := applyPatch(&form, patch); err != nil {
+		return model.Vocab{}, err
+	}
+	form.Id = formId
+	vocab.ReplaceForm(form)
+
+	if err := d.saveWord(ctx, userId, &vocab); err != nil {
+		return vocab, err
+	}
+
+	return vocab, nil
+}
+
+// AddForm appends a form to the stored vocab.
+func (d *Dictionary) AddForm(ctx context.Context, vocabId uuid.UUID, form model.VocabForm) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.AddForm")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	vocab, err := d.loadWord(ctx, userId, vocabId)
+	if err != nil {
+		return model.Vocab{}, err
+	}
+
+	vocab.Forms = append(vocab.Forms, form)
+
+	assignIds(&vocab)
+	if err := d.saveWord(ctx, userId, &vocab); err != nil {
+		return vocab, err
+	}
+
+	return vocab, nil
+}
+
+// RemoveForm removes a form from the stored vocab.
+func (d *Dictionary) RemoveForm(ctx context.Context, vocabId uuid.UUID, formId uuid.UUID) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.RemoveForm")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	vocab, err := d.loadWord(ctx, userId, vocabId)
+	if err != nil {
+		return model.Vocab{}, err
+	}
+
+	if !vocab.RemoveForm(formId) {
+		return model.Vocab{}, ErrFormNotFound
+	}
+
+	if err := d.saveWord(ctx, userId, &vocab); err != nil {
+		return vocab, err
+	}
+
+	return vocab, nil
+}
+
+// MergeWords folds the duplicates into the target vocab: their forms are
+// combined keeping the higher progress, sets referencing them are pointed to
+// the target and the duplicates are removed.
+func (d *Dictionary) MergeWords(ctx context.Context, targetId uuid.UUID, duplicateIds []uuid.UUID) (model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.MergeWords")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	target, err := d.loadWord(ctx, userId, targetId)
+	if err != nil {
+		return model.Vocab{}, err
+	}
+
+	merged := map[uuid.UUID]bool{}
+	for _, id := range duplicateIds {
+		if id == targetId || merged[id] {
+			continue
+		}
+
+		duplicate, err := d.loadWord(ctx, userId, id)
+		if err != nil {
+			return model.Vocab{}, err
+		}
+
+		target.MergeForms(duplicate, textnorm.Normalize)
+		if !duplicate.CreatedAt.IsZero() && (target.CreatedAt.IsZero() || duplicate.CreatedAt.Before(target.CreatedAt)) {
+			target.CreatedAt = duplicate.CreatedAt
+		}
+		merged[id] = true
+	}
+
+	if err := d.saveWord(ctx, userId, &target); err != nil {
+		return target, err
+	}
+
+	if err := d.replaceInSets(ctx, userId, merged, targetId); err != nil {
+		return target, err
+	}
+
+	for id := range merged {
+		if err := d.RemoveWord(ctx, id); err != nil {
+			return target, fmt.Errorf("could not remove merged vocab %s: %w", id, err)
+		}
+	}
+
+	return target, nil
+}
+
+// findDuplicates looks up vocab sharing a normalized definition or form
+// value with vocab.
+func (d *Dictionary) findDuplicates(ctx context.Context, userId string, vocab model.Vocab) ([]model.Vocab, error) {
+	candidates, err := d.findByExactTerms(ctx, userId, exactTerms(vocab))
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicatesOf(vocab, candidates), nil
+}
+
+// findByExactTerms returns the vocab having any of the exact terms, looked
+// up through the search index. Until the stored data is migrated the index
+// may miss older vocab, so the terms of the whole vocabulary are compared
+// instead.
+func (d *Dictionary) findByExactTerms(ctx context.Context, userId string, terms []string) ([]model.Vocab, error) {
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	version, err := d.storage.GetDataVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get data version: %w", err)
+	}
+	if version < DataVersion {
+		return d.scanExactTerms(ctx, userId, terms)
+	}
+
+	var ids []uuid.UUID
+	for chunk := range slices.Chunk(terms, maxSearchTerms) {
+		chunkIds, err := d.storage.SearchVocabIds(ctx, userId, chunk, 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not look up duplicates: %w", err)
+		}
+		for _, id := range chunkIds {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	candidates, err := d.storage.GetMultipleVocabs(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not get duplicates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// scanExactTerms is findByExactTerms without the search index.
+func (d *Dictionary) scanExactTerms(ctx context.Context, userId string, terms []string) ([]model.Vocab, error) {
+	vocabs, err := d.storage.FetchUserVocabulary(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not look up duplicates: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+
+	var candidates []model.Vocab
+	for _, vocab := range vocabs {
+		if slices.ContainsFunc(exactTerms(vocab), func(term string) bool { return wanted[term] }) {
+			candidates = append(candidates, vocab)
+		}
+	}
+
+	return candidates, nil
+}
+
+// duplicatesOf picks the candidates sharing an exact term with vocab. The
+// index may be stale, so the terms are recomputed from the stored vocab.
+func duplicatesOf(vocab model.Vocab, candidates []model.Vocab) []model.Vocab {
+	terms := exactTerms(vocab)
+
+	var duplicates []model.Vocab
+	for _, candidate := range candidates {
+		if candidate.Id == vocab.Id {
+			continue
+		}
+		if slices.ContainsFunc(exactTerms(candidate), func(term string) bool {
+			return slices.Contains(terms, term)
+		}) {
+			duplicates = append(duplicates, candidate)
+		}
+	}
+
+	return duplicates
+}
+
+func (d *Dictionary) replaceInSets(ctx context.Context, userId string, replaced map[uuid.UUID]bool, with uuid.UUID) error {
+	sets, err := d.storage.FetchUserVocabSets(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("could not fetch vocab sets: %w", err)
+	}
+
+	for _, set := range sets {
+		changed := false
+		vocabIds := make([]uuid.UUID, 0, len(set.VocabIds))
+		for _, id := range set.VocabIds {
+			if replaced[id] {
+				id = with
+				changed = true
+			}
+			if !slices.Contains(vocabIds, id) {
+				vocabIds = append(vocabIds, id)
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		set.VocabIds = vocabIds
+		if err := d.storage.SetVocabSet(ctx, userId, set); err != nil {
+			return fmt.Errorf("could not update vocab set %s: %w", set.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dictionary) loadWord(ctx context.Context, userId string, vocabId uuid.UUID) (model.Vocab, error) {
+	vocab, err := d.storage.GetVocab(ctx, userId, vocabId)
+	if err != nil {
+		return model.Vocab{}, fmt.Errorf("could not get vocab: %w", err)
+	}
+
+	return *vocab, nil
+}
+
+// saveWord validates and stores an existing vocab, re-indexes it and drops
+// its stale copy from the user's pool.
+func (d *Dictionary) saveWord(ctx context.Context, userId string, vocab *model.Vocab) error {
+	if err := vocab.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVocab, err)
+	}
+	vocab.DueAt = vocab.NextDue()
+
+	if err := d.storage.AddVocabulary(ctx, userId, *vocab); err != nil {
+		return err
+	}
+
+	if err := d.storage.SetSearchEntry(ctx, userId, newSearchEntry(*vocab)); err != nil {
+		return fmt.Errorf("could not index vocab: %w", err)
+	}
+
+	return d.invalidatePool(ctx, userId, vocab.Id)
+}
+
+// invalidatePool drops the given vocab from the user's pool so stale copies
+// are not reviewed.
+func (d *Dictionary) invalidatePool(ctx context.Context, userId string, vocabIds ...uuid.UUID) error {
+	pool, err := d.storage.FetchUserPool(ctx, userId)
+	if errors.Is(err, ErrPoolNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not fetch pool: %w", err)
+	}
+
+	changed := false
+	for _, vocabId := range vocabIds {
+		if pool.RemoveVocab(vocabId) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := d.storage.UpdatePool(ctx, userId, pool); err != nil {
+		return fmt.Errorf("could not invalidate pool: %w", err)
+	}
+
+	return nil
+}
+
+func assignIds(vocab *model.Vocab) {
 	if vocab.Id == uuid.Nil {
 		vocab.Id = uuid.New()
 	}
@@ -50,24 +477,46 @@ func (d *Dictionary) UpdateWord(ctx context.Context, vocab model.Vocab) (model.V
 			vocab.Forms[i].Id = uuid.New()
 		}
 	}
-	return vocab, d.storage.AddVocabulary(ctx, userid.MustFromCtx(ctx), vocab)
+}
+
+func applyPatch[T any](v *T, patch []byte) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	patched, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVocab, err)
+	}
+
+	var result T
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVocab, err)
+	}
+	*v = result
+
+	return nil
 }
 
 func (d *Dictionary) RegisterProgress(
 	ctx context.Context, withoutMistakes []model.Vocab, withMistakes []model.Vocab,
 ) error {
+	ctx, span := tracer.Start(ctx, "Dictionary.RegisterProgress")
+	defer span.End()
+
 	userId := userid.MustFromCtx(ctx)
 	m := map[uuid.UUID]*model.Vocab{}
 
 	for _, vocabToUpdate := range withoutMistakes {
 		if _, ok := m[vocabToUpdate.Id]; !ok {
 			vocab, err := d.storage.GetVocab(ctx, userId, vocabToUpdate.Id)
+			if errors.Is(err, ErrVocabNotFound) {
+				continue
+			}
 			if err != nil {
 				return fmt.Errorf("could not find vocab: %w", err)
 			}
-			if vocab == nil {
-				continue
-			}
 			m[vocabToUpdate.Id] = vocab
 		}
 		for _, form := range vocabToUpdate.Forms {
@@ -78,12 +527,12 @@ func (d *Dictionary) RegisterProgress(
 	for _, vocabToUpdate := range withMistakes {
 		if _, ok := m[vocabToUpdate.Id]; !ok {
 			vocab, err := d.storage.GetVocab(ctx, userId, vocabToUpdate.Id)
+			if errors.Is(err, ErrVocabNotFound) {
+				continue
+			}
 			if err != nil {
 				return fmt.Errorf("could not find vocab: %w", err)
 			}
-			if vocab == nil {
-				continue
-			}
 			m[vocabToUpdate.Id] = vocab
 		}
 
@@ -95,6 +544,7 @@ func (d *Dictionary) RegisterProgress(
 	eg, egCtx := errgroup.WithContext(ctx)
 
 	for _, updatedVocabs := range m {
+		updatedVocabs.DueAt = updatedVocabs.NextDue()
 		eg.Go(func() error {
 			return d.storage.AddVocabulary(egCtx, userId, *updatedVocabs)
 		})
@@ -109,9 +559,25 @@ func (d *Dictionary) RegisterProgress(
 }
 
 func (d *Dictionary) RemoveWord(ctx context.Context, vocabId uuid.UUID) error {
-	return d.storage.RemoveVocabulary(ctx, userid.MustFromCtx(ctx), vocabId)
+	ctx, span := tracer.Start(ctx, "Dictionary.RemoveWord")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	if err := d.storage.RemoveVocabulary(ctx, userId, vocabId); err != nil {
+		return err
+	}
+
+	if err := d.storage.RemoveSearchEntry(ctx, userId, vocabId); err != nil {
+		return fmt.Errorf("could not unindex vocab: %w", err)
+	}
+
+	return d.invalidatePool(ctx, userId, vocabId)
 }
 
 func (d *Dictionary) GetAllWords(ctx context.Context) ([]model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.GetAllWords")
+	defer span.End()
+
 	return d.storage.FetchUserVocabulary(ctx, userid.MustFromCtx(ctx))
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -45,9 +46,16 @@ func TestDictionary_AddWord(t *testing.T) {
 				},
 			},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+				mock.EXPECT().
+					SearchVocabIds(gomock.Any(), "test-user", gomock.Any(), 0).
+					Return([]uuid.UUID{}, nil)
 				mock.EXPECT().
 					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
 					Return(nil)
+				mock.EXPECT().
+					SetSearchEntry(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil)
 			},
 			expectError: false,
 		},
@@ -62,9 +70,16 @@ func TestDictionary_AddWord(t *testing.T) {
 				},
 			},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+				mock.EXPECT().
+					SearchVocabIds(gomock.Any(), "test-user", gomock.Any(), 0).
+					Return([]uuid.UUID{}, nil)
 				mock.EXPECT().
 					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
 					Return(nil)
+				mock.EXPECT().
+					SetSearchEntry(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil)
 			},
 			expectError: false,
 		},
@@ -79,12 +94,91 @@ func TestDictionary_AddWord(t *testing.T) {
 				},
 			},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+				mock.EXPECT().
+					SearchVocabIds(gomock.Any(), "test-user", gomock.Any(), 0).
+					Return([]uuid.UUID{}, nil)
 				mock.EXPECT().
 					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
 					Return(errors.New("test error"))
 			},
 			expectError: true,
 		},
+		{
+			name: "reject word without definition",
+			vocab: model.Vocab{
+				PartOfSpeech: model.PartOfSpeechNoun,
+				Forms: []model.VocabForm{
+					{Value: "hus", Form: "indefinite_singular"},
+				},
+			},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectError: true,
+		},
+		{
+			name: "reject form without value",
+			vocab: model.Vocab{
+				Definition:   "house",
+				PartOfSpeech: model.PartOfSpeechNoun,
+				Forms: []model.VocabForm{
+					{Value: " ", Form: "indefinite_singular"},
+				},
+			},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectError: true,
+		},
+		{
+			name: "reject duplicate of existing word",
+			vocab: model.Vocab{
+				Definition:   "House",
+				PartOfSpeech: model.PartOfSpeechNoun,
+				Forms: []model.VocabForm{
+					{Value: "hús", Form: "indefinite_singular"},
+				},
+			},
+			setupMock: func(mock *mocks.MockFirestore) {
+				existingId := uuid.New()
+				mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+				mock.EXPECT().
+					SearchVocabIds(gomock.Any(), "test-user", []string{"=house", "=hus"}, 0).
+					Return([]uuid.UUID{existingId}, nil)
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), "test-user", []uuid.UUID{existingId}).
+					Return([]model.Vocab{{
+						Id:         existingId,
+						Definition: "building",
+						Forms:      []model.VocabForm{{Value: "hus", Form: "indefinite_singular"}},
+					}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name: "stale index hit is not a duplicate",
+			vocab: model.Vocab{
+				Definition:   "house",
+				PartOfSpeech: model.PartOfSpeechNoun,
+				Forms: []model.VocabForm{
+					{Value: "hus", Form: "indefinite_singular"},
+				},
+			},
+			setupMock: func(mock *mocks.MockFirestore) {
+				existingId := uuid.New()
+				mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+				mock.EXPECT().
+					SearchVocabIds(gomock.Any(), "test-user", gomock.Any(), 0).
+					Return([]uuid.UUID{existingId}, nil)
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), "test-user", []uuid.UUID{existingId}).
+					Return([]model.Vocab{{Id: existingId, Definition: "tree"}}, nil)
+				mock.EXPECT().
+					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil)
+				mock.EXPECT().
+					SetSearchEntry(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil)
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -144,9 +238,18 @@ func TestDictionary_UpdateWord(t *testing.T) {
 				},
 			},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetVocab(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil, ErrVocabNotFound)
 				mock.EXPECT().
 					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
 					Return(nil)
+				mock.EXPECT().
+					SetSearchEntry(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil)
+				mock.EXPECT().
+					FetchUserPool(gomock.Any(), "test-user").
+					Return(nil, ErrPoolNotFound)
 			},
 			expectError: false,
 		},
@@ -161,9 +264,18 @@ func TestDictionary_UpdateWord(t *testing.T) {
 				},
 			},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetVocab(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil, ErrVocabNotFound)
 				mock.EXPECT().
 					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
 					Return(nil)
+				mock.EXPECT().
+					SetSearchEntry(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil)
+				mock.EXPECT().
+					FetchUserPool(gomock.Any(), "test-user").
+					Return(nil, ErrPoolNotFound)
 			},
 			expectError: false,
 		},
@@ -178,6 +290,9 @@ func TestDictionary_UpdateWord(t *testing.T) {
 				},
 			},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetVocab(gomock.Any(), "test-user", gomock.Any()).
+					Return(nil, ErrVocabNotFound)
 				mock.EXPECT().
 					AddVocabulary(gomock.Any(), "test-user", gomock.Any()).
 					Return(errors.New("test error"))
@@ -204,6 +319,7 @@ func TestDictionary_UpdateWord(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, result)
+				require.False(t, result.CreatedAt.IsZero())
 
 				// Check that IDs were generated if they were nil
 				if tt.vocab.Id == uuid.Nil {
@@ -293,7 +409,7 @@ func TestDictionary_RegisterProgress(t *testing.T) {
 			setupMock: func(mock *mocks.MockFirestore) {
 				mock.EXPECT().
 					GetVocab(gomock.Any(), userId, vocabId1).
-					Return(nil, nil) // vocab not found
+					Return(nil, ErrVocabNotFound) // vocab not found
 			},
 			expectError: false, // should continue without error
 		},
@@ -367,6 +483,19 @@ func TestDictionary_RemoveWord(t *testing.T) {
 				mock.EXPECT().
 					RemoveVocabulary(gomock.Any(), userId, vocabId).
 					Return(nil)
+				mock.EXPECT().
+					RemoveSearchEntry(gomock.Any(), userId, vocabId).
+					Return(nil)
+				mock.EXPECT().
+					FetchUserPool(gomock.Any(), userId).
+					Return(&model.Pool{Vocabs: []model.Vocab{{Id: vocabId}, {Id: uuid.New()}}}, nil)
+				mock.EXPECT().
+					UpdatePool(gomock.Any(), userId, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, pool *model.Pool) error {
+						require.Len(t, pool.Vocabs, 1)
+						require.NotEqual(t, vocabId, pool.Vocabs[0].Id)
+						return nil
+					})
 			},
 			expectError: false,
 		},
@@ -487,6 +616,459 @@ func TestDictionary_GetAllWords(t *testing.T) {
 	}
 }
 
+func TestDictionary_UpdateWord_KeepsCreatedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabId := uuid.New()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mockStore.EXPECT().
+		GetVocab(gomock.Any(), userId, vocabId).
+		Return(&model.Vocab{Id: vocabId, Definition: "house", CreatedAt: createdAt}, nil)
+	mockStore.EXPECT().
+		AddVocabulary(gomock.Any(), userId, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, vocab model.Vocab) error {
+			require.Equal(t, createdAt, vocab.CreatedAt)
+			require.False(t, vocab.DueAt.IsZero())
+			return nil
+		})
+	mockStore.EXPECT().SetSearchEntry(gomock.Any(), userId, gomock.Any()).Return(nil)
+	mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	result, err := dict.UpdateWord(ctx, model.Vocab{
+		Id:         vocabId,
+		Definition: "a house",
+		Forms:      []model.VocabForm{{Value: "hus", Form: "indefinite_singular"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, createdAt, result.CreatedAt)
+}
+
+func TestDictionary_GetWord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabId := uuid.New()
+
+	mockStore.EXPECT().
+		GetVocab(gomock.Any(), userId, vocabId).
+		Return(&model.Vocab{Id: vocabId, Definition: "house"}, nil)
+	mockStore.EXPECT().
+		GetVocab(gomock.Any(), userId, gomock.Not(vocabId)).
+		Return(nil, ErrVocabNotFound)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	vocab, err := dict.GetWord(ctx, vocabId)
+	require.NoError(t, err)
+	require.Equal(t, "house", vocab.Definition)
+
+	_, err = dict.GetWord(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrVocabNotFound)
+}
+
+func TestDictionary_ListWords(t *testing.T) {
+	userId := "test-user"
+	one, three := 1, 3
+
+	tests := []struct {
+		name        string
+		query       model.VocabQuery
+		setupMock   func(*mocks.MockFirestore)
+		expectError error
+	}{
+		{
+			name: "query is passed to storage",
+			query: model.VocabQuery{
+				PartOfSpeech: model.PartOfSpeechNoun,
+				MinLevel:     &one,
+				MaxLevel:     &three,
+				SortBy:       model.VocabSortDueAt,
+				Limit:        20,
+			},
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					QueryUserVocabulary(gomock.Any(), userId, model.VocabQuery{
+						PartOfSpeech: model.PartOfSpeechNoun,
+						MinLevel:     &one,
+						MaxLevel:     &three,
+						SortBy:       model.VocabSortDueAt,
+						Limit:        20,
+					}).
+					Return(&model.VocabPage{Vocabs: []model.Vocab{}}, nil)
+			},
+		},
+		{
+			name:        "unknown sort",
+			query:       model.VocabQuery{SortBy: "level"},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectError: ErrInvalidQuery,
+		},
+		{
+			name:        "inverted level range",
+			query:       model.VocabQuery{MinLevel: &three, MaxLevel: &one},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectError: ErrInvalidQuery,
+		},
+		{
+			name:        "limit too large",
+			query:       model.VocabQuery{Limit: model.MaxVocabPageSize + 1},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectError: ErrInvalidQuery,
+		},
+		{
+			name:        "malformed cursor",
+			query:       model.VocabQuery{Cursor: "abc"},
+			setupMock:   func(mock *mocks.MockFirestore) {},
+			expectError: ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockFirestore(ctrl)
+			tt.setupMock(mockStore)
+
+			dict := &Dictionary{storage: mockStore}
+			ctx := userid.ToCtx(context.Background(), userId)
+
+			page, err := dict.ListWords(ctx, tt.query)
+
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, page)
+			}
+		})
+	}
+}
+
+func TestDictionary_PatchWord(t *testing.T) {
+	userId := "test-user"
+	vocabId := uuid.New()
+	formId := uuid.New()
+
+	stored := func() *model.Vocab {
+		return &model.Vocab{
+			Id:           vocabId,
+			Definition:   "house",
+			PartOfSpeech: model.PartOfSpeechNoun,
+			Forms: []model.VocabForm{
+				{Id: formId, Value: "hus", Form: "indefinite_singular", Level: 2},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		patch       string
+		setupMock   func(*mocks.MockFirestore)
+		expectErr   error
+		checkResult func(*testing.T, model.Vocab)
+	}{
+		{
+			name:  "patch definition keeps forms",
+			patch: `{"definition": "a house"}`,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetVocab(gomock.Any(), userId, vocabId).Return(stored(), nil)
+				mock.EXPECT().AddVocabulary(gomock.Any(), userId, gomock.Any()).Return(nil)
+				mock.EXPECT().SetSearchEntry(gomock.Any(), userId, gomock.Any()).Return(nil)
+				mock.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound)
+			},
+			checkResult: func(t *testing.T, v model.Vocab) {
+				require.Equal(t, "a house", v.Definition)
+				require.Len(t, v.Forms, 1)
+				require.Equal(t, 2, v.Forms[0].Level)
+			},
+		},
+		{
+			name:  "patch cannot change id",
+			patch: `{"id": "` + uuid.New().String() + `"}`,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetVocab(gomock.Any(), userId, vocabId).Return(stored(), nil)
+				mock.EXPECT().AddVocabulary(gomock.Any(), userId, gomock.Any()).Return(nil)
+				mock.EXPECT().SetSearchEntry(gomock.Any(), userId, gomock.Any()).Return(nil)
+				mock.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound)
+			},
+			checkResult: func(t *testing.T, v model.Vocab) {
+				require.Equal(t, vocabId, v.Id)
+			},
+		},
+		{
+			name:  "patch removing definition is invalid",
+			patch: `{"definition": null}`,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetVocab(gomock.Any(), userId, vocabId).Return(stored(), nil)
+			},
+			expectErr: ErrInvalidVocab,
+		},
+		{
+			name:  "malformed patch",
+			patch: `{"definition":`,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetVocab(gomock.Any(), userId, vocabId).Return(stored(), nil)
+			},
+			expectErr: ErrInvalidVocab,
+		},
+		{
+			name:  "vocab not found",
+			patch: `{"definition": "a house"}`,
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().GetVocab(gomock.Any(), userId, vocabId).Return(nil, ErrVocabNotFound)
+			},
+			expectErr: ErrVocabNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockFirestore(ctrl)
+			tt.setupMock(mockStore)
+
+			dict := &Dictionary{storage: mockStore}
+			ctx := userid.ToCtx(context.Background(), userId)
+
+			result, err := dict.PatchWord(ctx, vocabId, []byte(tt.patch))
+
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+				tt.checkResult(t, result)
+			}
+		})
+	}
+}
+
+func TestDictionary_PatchForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabId := uuid.New()
+	formId := uuid.New()
+	otherFormId := uuid.New()
+
+	mockStore.EXPECT().
+		GetVocab(gomock.Any(), userId, vocabId).
+		Return(&model.Vocab{
+			Id:         vocabId,
+			Definition: "house",
+			Forms: []model.VocabForm{
+				{Id: formId, Value: "hus", Form: "indefinite_singular"},
+				{Id: otherFormId, Value: "huse", Form: "indefinite_plural"},
+			},
+		}, nil).
+		Times(2)
+	mockStore.EXPECT().AddVocabulary(gomock.Any(), userId, gomock.Any()).Return(nil)
+	mockStore.EXPECT().SetSearchEntry(gomock.Any(), userId, gomock.Any()).Return(nil)
+	mockStore.EXPECT().
+		FetchUserPool(gomock.Any(), userId).
+		Return(&model.Pool{Vocabs: []model.Vocab{{Id: vocabId}}}, nil)
+	mockStore.EXPECT().UpdatePool(gomock.Any(), userId, gomock.Any()).Return(nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	result, err := dict.PatchForm(ctx, vocabId, formId, []byte(`{"value": "huset", "level": 3}`))
+	require.NoError(t, err)
+	require.Equal(t, "huset", result.Forms[0].Value)
+	require.Equal(t, 3, result.Forms[0].Level)
+	require.Equal(t, formId, result.Forms[0].Id)
+	require.Equal(t, "huse", result.Forms[1].Value)
+
+	_, err = dict.PatchForm(ctx, vocabId, uuid.New(), []byte(`{"value": "huset"}`))
+	require.ErrorIs(t, err, ErrFormNotFound)
+}
+
+func TestDictionary_AddAndRemoveForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabId := uuid.New()
+	formId := uuid.New()
+
+	mockStore.EXPECT().
+		GetVocab(gomock.Any(), userId, vocabId).
+		DoAndReturn(func(context.Context, string, uuid.UUID) (*model.Vocab, error) {
+			return &model.Vocab{
+				Id:         vocabId,
+				Definition: "house",
+				Forms: []model.VocabForm{
+					{Id: formId, Value: "hus", Form: "indefinite_singular"},
+				},
+			}, nil
+		}).
+		AnyTimes()
+	mockStore.EXPECT().AddVocabulary(gomock.Any(), userId, gomock.Any()).Return(nil).Times(2)
+	mockStore.EXPECT().SetSearchEntry(gomock.Any(), userId, gomock.Any()).Return(nil).Times(2)
+	mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound).Times(2)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	added, err := dict.AddForm(ctx, vocabId, model.VocabForm{Value: "huse", Form: "indefinite_plural"})
+	require.NoError(t, err)
+	require.Len(t, added.Forms, 2)
+	require.NotEqual(t, uuid.Nil, added.Forms[1].Id)
+
+	_, err = dict.AddForm(ctx, vocabId, model.VocabForm{Form: "indefinite_plural"})
+	require.ErrorIs(t, err, ErrInvalidVocab)
+
+	removed, err := dict.RemoveForm(ctx, vocabId, formId)
+	require.NoError(t, err)
+	require.Empty(t, removed.Forms)
+
+	_, err = dict.RemoveForm(ctx, vocabId, uuid.New())
+	require.ErrorIs(t, err, ErrFormNotFound)
+}
+
+func TestDictionary_AddWord_DuplicateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	existing := model.Vocab{Id: uuid.New(), Definition: "house"}
+
+	mockStore.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+	mockStore.EXPECT().
+		SearchVocabIds(gomock.Any(), userId, []string{"=house"}, 0).
+		Return([]uuid.UUID{existing.Id}, nil)
+	mockStore.EXPECT().
+		GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{existing.Id}).
+		Return([]model.Vocab{existing}, nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	_, err := dict.AddWord(ctx, model.Vocab{Definition: "House"})
+
+	var duplicateErr *DuplicateVocabError
+	require.ErrorAs(t, err, &duplicateErr)
+	require.Equal(t, []model.Vocab{existing}, duplicateErr.Existing)
+}
+
+func TestDictionary_AddWord_DuplicateOfUnindexedVocab(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	// Stored before the search index existed and not migrated yet.
+	existing := model.Vocab{Id: uuid.New(), Definition: "house", Forms: []model.VocabForm{{Id: uuid.New(), Value: "hus"}}}
+	require.NoError(t, store.AddVocabulary(ctx, "test-user", existing))
+
+	dict := &Dictionary{storage: store}
+	_, err = dict.AddWord(userid.ToCtx(ctx, "test-user"), model.Vocab{Definition: "home", Forms: []model.VocabForm{{Value: "Hus"}}})
+
+	var duplicateErr *DuplicateVocabError
+	require.ErrorAs(t, err, &duplicateErr)
+	require.Equal(t, existing.Id, duplicateErr.Existing[0].Id)
+}
+
+func TestDictionary_MergeWords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	now := time.Now()
+
+	targetFormId := uuid.New()
+	target := &model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		CreatedAt:  now,
+		Forms: []model.VocabForm{
+			{Id: targetFormId, Value: "hus", Form: "indefinite_singular", Level: 1},
+		},
+	}
+	duplicate := &model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		CreatedAt:  now.Add(-time.Hour),
+		Forms: []model.VocabForm{
+			{Id: uuid.New(), Value: "Hus", Form: "indefinite_singular", Level: 3, LastSuccess: now},
+			{Id: uuid.New(), Value: "huse", Form: "indefinite_plural", Level: 2},
+		},
+	}
+	setId := uuid.New()
+	otherSetId := uuid.New()
+
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, target.Id).Return(target, nil)
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, duplicate.Id).Return(duplicate, nil)
+	mockStore.EXPECT().
+		AddVocabulary(gomock.Any(), userId, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, vocab model.Vocab) error {
+			require.Equal(t, target.Id, vocab.Id)
+			require.Len(t, vocab.Forms, 2)
+			require.Equal(t, targetFormId, vocab.Forms[0].Id)
+			require.Equal(t, "hus", vocab.Forms[0].Value)
+			require.Equal(t, 3, vocab.Forms[0].Level)
+			require.Equal(t, "huse", vocab.Forms[1].Value)
+			require.Equal(t, duplicate.CreatedAt, vocab.CreatedAt)
+			return nil
+		})
+	mockStore.EXPECT().SetSearchEntry(gomock.Any(), userId, gomock.Any()).Return(nil)
+	mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound).Times(2)
+	mockStore.EXPECT().
+		FetchUserVocabSets(gomock.Any(), userId).
+		Return([]model.VocabSet{
+			{Id: setId, Name: "both", VocabIds: []uuid.UUID{target.Id, duplicate.Id}},
+			{Id: otherSetId, Name: "other", VocabIds: []uuid.UUID{uuid.New()}},
+		}, nil)
+	mockStore.EXPECT().
+		SetVocabSet(gomock.Any(), userId, model.VocabSet{Id: setId, Name: "both", VocabIds: []uuid.UUID{target.Id}}).
+		Return(nil)
+	mockStore.EXPECT().RemoveVocabulary(gomock.Any(), userId, duplicate.Id).Return(nil)
+	mockStore.EXPECT().RemoveSearchEntry(gomock.Any(), userId, duplicate.Id).Return(nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	merged, err := dict.MergeWords(ctx, target.Id, []uuid.UUID{duplicate.Id, target.Id})
+	require.NoError(t, err)
+	require.Len(t, merged.Forms, 2)
+}
+
+func TestDictionary_MergeWords_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	target := &model.Vocab{Id: uuid.New(), Definition: "house"}
+	missingId := uuid.New()
+
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, target.Id).Return(target, nil)
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, missingId).Return(nil, ErrVocabNotFound)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	_, err := dict.MergeWords(ctx, target.Id, []uuid.UUID{missingId})
+	require.ErrorIs(t, err, ErrVocabNotFound)
+}
+
 func TestDictionary_RegisterProgress_ConcurrentUpdates(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -559,4 +1141,12 @@ func TestDictionary_ContextWithoutUserID(t *testing.T) {
 	require.Panics(t, func() {
 		dict.GetAllWords(ctx)
 	})
+
+	require.Panics(t, func() {
+		dict.GetWord(ctx, uuid.New())
+	})
+
+	require.Panics(t, func() {
+		dict.ListWords(ctx, model.VocabQuery{})
+	})
 }
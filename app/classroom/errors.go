@@ -0,0 +1,55 @@
+package classroom
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Error kinds. Every error of this package that is the client's doing
+// matches one of them with errors.Is; anything else is a server error.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	ErrForbidden  = errors.New("forbidden")
+)
+
+// Error is a domain error of one of the kinds above. Code identifies it
+// for clients and does not change with the message.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+}
+
+func newError(kind error, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is match the error's kind as well as the error itself.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// BatchWriteError is returned by Firestore.WriteVocabBatch when the writes
+// of some vocab failed. Storage applies the writes of a batch one by one,
+// so the writes of every other vocab went through.
+type BatchWriteError struct {
+	Failed map[uuid.UUID]error
+}
+
+func (e *BatchWriteError) Error() string {
+	ids := slices.SortedFunc(maps.Keys(e.Failed), func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return fmt.Sprintf("failed to write %d vocab, first %s: %v", len(ids), ids[0], e.Failed[ids[0]])
+}
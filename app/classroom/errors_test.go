@@ -0,0 +1,31 @@
+package classroom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestErrorKinds(t *testing.T) {
+	err := fmt.Errorf("failed to get set: %w", ErrSetNotFound)
+	require.ErrorIs(t, err, ErrSetNotFound)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NotErrorIs(t, err, ErrClassNotFound)
+	require.NotErrorIs(t, err, ErrValidation)
+
+	var domainErr *Error
+	require.ErrorAs(t, fmt.Errorf("%w: definition is required", ErrInvalidVocab), &domainErr)
+	require.Equal(t, "invalid_vocab", domainErr.Code)
+	require.ErrorIs(t, domainErr, ErrValidation)
+
+	duplicate := &DuplicateVocabError{Existing: []model.Vocab{{}}}
+	require.ErrorIs(t, duplicate, ErrConflict)
+	require.ErrorAs(t, duplicate, &domainErr)
+	require.Equal(t, "duplicate_vocab", domainErr.Code)
+
+	require.NotErrorIs(t, errors.New("vocab set not found"), ErrNotFound)
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"google.golang.org/api/iterator"
@@ -15,9 +20,14 @@ import (
 	"github.com/vladazn/danish/app/storage"
 )
 
+// Firestore stores the data of all users. Methods getting a single item
+// fail with the *Error of kind ErrNotFound for that item if it does not
+// exist, never with a nil result.
+//
 //go:generate go run go.uber.org/mock/mockgen@latest -source=firebase.go -destination=private/mocks/firebase_mock.go -package=mocks
 type Firestore interface {
 	FetchUserVocabulary(ctx context.Context, userId string) ([]model.Vocab, error)
+	QueryUserVocabulary(ctx context.Context, userId string, query model.VocabQuery) (*model.VocabPage, error)
 	AddVocabulary(ctx context.Context, userId string, vocab model.Vocab) error
 	RemoveVocabulary(ctx context.Context, userId string, vocabId uuid.UUID) error
 	UpdatePool(ctx context.Context, userId string, pool *model.Pool) error
@@ -28,6 +38,46 @@ type Firestore interface {
 	GetVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) (*model.VocabSet, error)
 	FetchUserVocabSets(ctx context.Context, userId string) ([]model.VocabSet, error)
 	RemoveVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) error
+	SetSearchEntry(ctx context.Context, userId string, entry model.SearchEntry) error
+	RemoveSearchEntry(ctx context.Context, userId string, vocabId uuid.UUID) error
+	SearchVocabIds(ctx context.Context, userId string, terms []string, limit int) ([]uuid.UUID, error)
+	WriteVocabBatch(ctx context.Context, userId string, batch model.VocabBatch) error
+	DeleteUser(ctx context.Context, userId string) error
+	ListUsers(ctx context.Context) ([]string, error)
+	SetPublishedSet(ctx context.Context, set model.PublishedSet) error
+	GetPublishedSet(ctx context.Context, id string) (*model.PublishedSet, error)
+	ListPublishedSets(ctx context.Context, ownerId string, limit int) ([]model.PublishedSet, error)
+	RemovePublishedSet(ctx context.Context, id string) error
+	SetClass(ctx context.Context, class model.Class) error
+	SetClassInviteCode(ctx context.Context, classId uuid.UUID, code string) error
+	AddClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error
+	RemoveClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error
+	GetClass(ctx context.Context, classId uuid.UUID) (*model.Class, error)
+	FindClassByInviteCode(ctx context.Context, code string) (*model.Class, error)
+	ListUserClasses(ctx context.Context, userId string) ([]model.Class, error)
+	RemoveClass(ctx context.Context, classId uuid.UUID) error
+	SetAssignment(ctx context.Context, assignment model.Assignment) error
+	ListAssignments(ctx context.Context, classId uuid.UUID) ([]model.Assignment, error)
+	GetAssignmentVocabs(ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID) ([]model.Vocab, error)
+	SetAssignmentCopy(
+		ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string, copied model.AssignmentCopy,
+	) error
+	GetAssignmentCopy(
+		ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string,
+	) (*model.AssignmentCopy, error)
+	ListAssignmentCopies(
+		ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+	) (map[string]model.AssignmentCopy, error)
+	RemoveAssignment(ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID) error
+	GetUserRole(ctx context.Context, userId string) (model.Role, error)
+	SetUserRole(ctx context.Context, userId string, role model.Role) error
+	SetAPIKey(ctx context.Context, key model.APIKey) error
+	GetAPIKey(ctx context.Context, keyId string) (*model.APIKey, error)
+	ListAPIKeys(ctx context.Context, userId string) ([]model.APIKey, error)
+	TouchAPIKey(ctx context.Context, keyId string, usedAt time.Time) error
+	RemoveAPIKey(ctx context.Context, keyId string) error
+	GetDataVersion(ctx context.Context) (int, error)
+	SetDataVersion(ctx context.Context, version int) error
 }
 
 type FirebaseStore struct {
@@ -95,7 +145,7 @@ func (fs *FirebaseStore) GetVocab(ctx context.Context, userId string, vocabId uu
 		Doc(vocabId.String()).Get(ctx)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, nil
+			return nil, ErrVocabNotFound
 		}
 		return nil, err
 	}
@@ -132,6 +182,110 @@ func (fs *FirebaseStore) FetchUserVocabulary(ctx context.Context, userId string)
 	return results, nil
 }
 
+var vocabSortFields = map[model.VocabSort]string{
+	model.VocabSortDefinition: "Definition",
+	model.VocabSortCreatedAt:  "CreatedAt",
+	model.VocabSortDueAt:      "DueAt",
+}
+
+// QueryUserVocabulary answers a query for a set from the set's vocab
+// alone. Otherwise Firestore filters by part of speech and unpaused vocab
+// and sorts, using the composite indexes in firestore.indexes.json; the
+// level and paused-only filters are applied while iterating, so pages stay
+// full but a selective level filter reads through the vocab it skips.
+func (fs *FirebaseStore) QueryUserVocabulary(
+	ctx context.Context, userId string, q model.VocabQuery,
+) (*model.VocabPage, error) {
+	if q.SetId != uuid.Nil {
+		return fs.querySetVocabulary(ctx, userId, q)
+	}
+
+	collection := fs.client.Client.Collection("users").Doc(userId).Collection("vocab")
+
+	query := collection.Query
+	if q.PartOfSpeech != "" {
+		query = query.Where("PartOfSpeech", "==", q.PartOfSpeech)
+	}
+	if q.Paused != nil && !*q.Paused {
+		query = query.Where("PausedUntil", "==", nil)
+	}
+	if field, ok := vocabSortFields[q.SortBy]; ok {
+		direction := firestore.Asc
+		if q.Descending {
+			direction = firestore.Desc
+		}
+		query = query.OrderBy(field, direction)
+	}
+
+	if q.Cursor != "" {
+		cursor, err := collection.Doc(q.Cursor).Get(ctx)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, fmt.Errorf("%w: cursor vocab no longer exists", ErrInvalidQuery)
+			}
+			return nil, fmt.Errorf("failed to fetch cursor vocab: %w", err)
+		}
+		query = query.StartAfter(cursor)
+	}
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	page := &model.VocabPage{Vocabs: []model.Vocab{}}
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to query vocab iter: %w", err)
+		}
+
+		var v model.Vocab
+		if err := doc.DataTo(&v); err != nil {
+			continue
+		}
+		if !q.Matches(v, nil) {
+			continue
+		}
+		if q.Limit > 0 && len(page.Vocabs) == q.Limit {
+			page.NextCursor = page.Vocabs[len(page.Vocabs)-1].Id.String()
+			break
+		}
+		page.Vocabs = append(page.Vocabs, v)
+	}
+
+	return page, nil
+}
+
+// querySetVocabulary reads the vocab of the set by id and applies the rest
+// of the query in memory; a set is small enough to hold at once.
+func (fs *FirebaseStore) querySetVocabulary(
+	ctx context.Context, userId string, q model.VocabQuery,
+) (*model.VocabPage, error) {
+	vocabSet, err := fs.GetVocabSet(ctx, userId, q.SetId)
+	if err != nil && !errors.Is(err, ErrSetNotFound) {
+		return nil, fmt.Errorf("failed to fetch vocab set: %w", err)
+	}
+
+	inSet := map[uuid.UUID]bool{}
+	var vocabs []model.Vocab
+	if vocabSet != nil {
+		for _, id := range vocabSet.VocabIds {
+			inSet[id] = true
+		}
+		vocabs, err = fs.GetMultipleVocabs(ctx, userId, vocabSet.VocabIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(vocabs, func(a, b model.Vocab) int {
+		return strings.Compare(a.Id.String(), b.Id.String())
+	})
+
+	return pageVocabs(vocabs, q, inSet)
+}
+
 func (fs *FirebaseStore) AddVocabulary(ctx context.Context, userId string, vocab model.Vocab) error {
 	_, err := fs.client.Client.Collection("users").Doc(userId).Collection("vocab").
 		Doc(vocab.Id.String()).Set(ctx, vocab)
@@ -170,7 +324,7 @@ func (fs *FirebaseStore) FetchUserPool(ctx context.Context, userId string) (*mod
 		Doc("main").Get(ctx)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, nil
+			return nil, ErrPoolNotFound
 		}
 		return nil, err
 	}
@@ -189,16 +343,21 @@ func (fs *FirebaseStore) GetMultipleVocabs(ctx context.Context, userId string, v
 		return []model.Vocab{}, nil
 	}
 
-	var results []model.Vocab
 	collection := fs.client.Client.Collection("users").Doc(userId).Collection("vocab")
+	refs := make([]*firestore.DocumentRef, len(vocabIds))
+	for i, vocabId := range vocabIds {
+		refs[i] = collection.Doc(vocabId.String())
+	}
 
-	for _, vocabId := range vocabIds {
-		doc, err := collection.Doc(vocabId.String()).Get(ctx)
-		if err != nil {
-			if status.Code(err) == codes.NotFound {
-				continue
-			}
-			return nil, fmt.Errorf("failed to fetch vocab %s: %w", vocabId.String(), err)
+	docs, err := fs.client.Client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vocabs: %w", err)
+	}
+
+	var results []model.Vocab
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
 		}
 
 		var vocab model.Vocab
@@ -229,7 +388,7 @@ func (fs *FirebaseStore) GetVocabSet(ctx context.Context, userId string, vocabSe
 		Doc(vocabSetId.String()).Get(ctx)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, nil
+			return nil, ErrSetNotFound
 		}
 		return nil, err
 	}
@@ -281,3 +440,774 @@ func (fs *FirebaseStore) RemoveVocabSet(ctx context.Context, userId string, voca
 
 	return nil
 }
+
+// StorageSearchEntry is a Firestore-compatible version of SearchEntry
+type StorageSearchEntry struct {
+	Terms []string `firestore:"terms"`
+}
+
+func (fs *FirebaseStore) SetSearchEntry(ctx context.Context, userId string, entry model.SearchEntry) error {
+	_, err := fs.client.Client.Collection("users").Doc(userId).Collection("search").
+		Doc(entry.VocabId.String()).Set(ctx, StorageSearchEntry{Terms: entry.Terms})
+
+	if err != nil {
+		return fmt.Errorf("failed to set search entry: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *FirebaseStore) RemoveSearchEntry(ctx context.Context, userId string, vocabId uuid.UUID) error {
+	_, err := fs.client.Client.Collection("users").Doc(userId).Collection("search").
+		Doc(vocabId.String()).Delete(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to remove search entry: %w", err)
+	}
+
+	return nil
+}
+
+// SearchVocabIds returns the ids of vocab whose search entry contains any of
+// the terms. Firestore accepts at most 30 terms per query.
+func (fs *FirebaseStore) SearchVocabIds(
+	ctx context.Context, userId string, terms []string, limit int,
+) ([]uuid.UUID, error) {
+	if len(terms) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	query := fs.client.Client.Collection("users").Doc(userId).Collection("search").
+		Where("terms", "array-contains-any", terms)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	results := []uuid.UUID{}
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to search vocab iter: %w", err)
+		}
+
+		id, err := uuid.Parse(doc.Ref.ID)
+		if err != nil {
+			continue
+		}
+		results = append(results, id)
+	}
+
+	return results, nil
+}
+
+// WriteVocabBatch applies the batch with a BulkWriter, which groups the
+// writes into batched commits instead of one round trip per document. The
+// commits are not atomic: failed writes are reported per vocab in a
+// *BatchWriteError.
+func (fs *FirebaseStore) WriteVocabBatch(ctx context.Context, userId string, batch model.VocabBatch) error {
+	user := fs.client.Client.Collection("users").Doc(userId)
+	bw := fs.client.Client.BulkWriter(ctx)
+
+	type vocabJob struct {
+		vocabId uuid.UUID
+		job     *firestore.BulkWriterJob
+	}
+	var jobs []vocabJob
+	failed := map[uuid.UUID]error{}
+	enqueue := func(vocabId uuid.UUID, job *firestore.BulkWriterJob, err error) {
+		if err != nil {
+			failed[vocabId] = err
+			return
+		}
+		jobs = append(jobs, vocabJob{vocabId: vocabId, job: job})
+	}
+
+	for _, vocab := range batch.Set {
+		job, err := bw.Set(user.Collection("vocab").Doc(vocab.Id.String()), vocab)
+		enqueue(vocab.Id, job, err)
+	}
+	for _, entry := range batch.Index {
+		job, err := bw.Set(user.Collection("search").Doc(entry.VocabId.String()), StorageSearchEntry{Terms: entry.Terms})
+		enqueue(entry.VocabId, job, err)
+	}
+	for _, vocabId := range batch.Delete {
+		job, err := bw.Delete(user.Collection("vocab").Doc(vocabId.String()))
+		enqueue(vocabId, job, err)
+		job, err = bw.Delete(user.Collection("search").Doc(vocabId.String()))
+		enqueue(vocabId, job, err)
+	}
+	bw.End()
+
+	for _, j := range jobs {
+		if _, err := j.job.Results(); err != nil {
+			if _, ok := failed[j.vocabId]; !ok {
+				failed[j.vocabId] = err
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return &BatchWriteError{Failed: failed}
+	}
+
+	return nil
+}
+
+// DeleteUser removes the user document and everything below it.
+func (fs *FirebaseStore) DeleteUser(ctx context.Context, userId string) error {
+	if err := fs.deleteTree(ctx, fs.client.Client.Collection("users").Doc(userId)); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+// deleteTree removes the document and every document in its
+// subcollections, nested ones included, so no data is left behind by
+// collections added after this was written.
+func (fs *FirebaseStore) deleteTree(ctx context.Context, root *firestore.DocumentRef) error {
+	bw := fs.client.Client.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	var deleteDoc func(doc *firestore.DocumentRef) error
+	deleteDoc = func(doc *firestore.DocumentRef) error {
+		collections := doc.Collections(ctx)
+		for {
+			collection, err := collections.Next()
+			if err != nil {
+				if errors.Is(err, iterator.Done) {
+					break
+				}
+				return err
+			}
+
+			// DocumentRefs also lists documents that only exist as the
+			// parent of a subcollection.
+			refs := collection.DocumentRefs(ctx)
+			for {
+				ref, err := refs.Next()
+				if err != nil {
+					if errors.Is(err, iterator.Done) {
+						break
+					}
+					return err
+				}
+				if err := deleteDoc(ref); err != nil {
+					return err
+				}
+			}
+		}
+
+		job, err := bw.Delete(doc)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+		return nil
+	}
+
+	err := deleteDoc(root)
+	bw.End()
+	if err != nil {
+		return fmt.Errorf("failed to enqueue deletion: %w", err)
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ListUsers returns the ids of all users with stored data. User documents
+// are never written themselves, so the ids come from the document refs,
+// which include documents that only hold subcollections.
+func (fs *FirebaseStore) ListUsers(ctx context.Context) ([]string, error) {
+	refs := fs.client.Client.Collection("users").DocumentRefs(ctx)
+
+	var userIds []string
+	for {
+		ref, err := refs.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		userIds = append(userIds, ref.ID)
+	}
+
+	return userIds, nil
+}
+
+// publishedSetSummaryFields are the fields of a published set read for
+// listings, everything but the vocab.
+var publishedSetSummaryFields = []string{"Id", "OwnerId", "SourceSetId", "Name", "VocabCount", "PublishedAt"}
+
+// SetPublishedSet stores the published set with its vocab in a "vocabs"
+// subcollection, so the size of a snapshot is not bound by the document
+// size limit.
+func (fs *FirebaseStore) SetPublishedSet(ctx context.Context, set model.PublishedSet) error {
+	vocabs := set.Vocabs
+	set.Vocabs = nil
+	doc := fs.client.Client.Collection("published_sets").Doc(set.Id)
+	if err := fs.writeSnapshot(ctx, doc, set, vocabs); err != nil {
+		return fmt.Errorf("failed to set published set: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) GetPublishedSet(ctx context.Context, id string) (*model.PublishedSet, error) {
+	doc, err := fs.client.Client.Collection("published_sets").Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrPublishedSetNotFound
+		}
+		return nil, fmt.Errorf("failed to get published set: %w", err)
+	}
+
+	var set model.PublishedSet
+	if err := doc.DataTo(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode published set: %w", err)
+	}
+
+	vocabs, err := fs.readSnapshot(ctx, doc.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get published set vocab: %w", err)
+	}
+	// Sets published before the vocab moved into the subcollection still
+	// carry it in the document.
+	if len(vocabs) > 0 {
+		set.Vocabs = vocabs
+	}
+
+	return &set, nil
+}
+
+// ListPublishedSets returns the newest published sets without their vocab,
+// only those of ownerId if it is set. A limit of 0 returns all of them.
+func (fs *FirebaseStore) ListPublishedSets(
+	ctx context.Context, ownerId string, limit int,
+) ([]model.PublishedSet, error) {
+	query := fs.client.Client.Collection("published_sets").Select(publishedSetSummaryFields...)
+	if ownerId != "" {
+		// Owners have few sets, sorting them here saves a composite index.
+		query = query.Where("OwnerId", "==", ownerId)
+	} else {
+		query = query.OrderBy("PublishedAt", firestore.Desc)
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+	}
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	results := []model.PublishedSet{}
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to list published sets: %w", err)
+		}
+
+		var set model.PublishedSet
+		if err := doc.DataTo(&set); err != nil {
+			continue
+		}
+		results = append(results, set)
+	}
+
+	if ownerId != "" {
+		slices.SortFunc(results, func(a, b model.PublishedSet) int {
+			return b.PublishedAt.Compare(a.PublishedAt)
+		})
+		if limit > 0 && len(results) > limit {
+			results = results[:limit]
+		}
+	}
+
+	return results, nil
+}
+
+func (fs *FirebaseStore) RemovePublishedSet(ctx context.Context, id string) error {
+	doc := fs.client.Client.Collection("published_sets").Doc(id)
+	if err := fs.deleteTree(ctx, doc); err != nil {
+		return fmt.Errorf("failed to remove published set: %w", err)
+	}
+	return nil
+}
+
+// snapshotVocabId is the id of the document of the i-th vocab of a
+// snapshot. The ids sort in snapshot order.
+func snapshotVocabId(i int) string {
+	return fmt.Sprintf("%06d", i)
+}
+
+// writeSnapshot stores data as the document doc and every vocab as a
+// document of its "vocabs" subcollection, removing vocab left over from a
+// larger earlier snapshot. The vocab is written before the document, so
+// a new snapshot is never visible without its vocab.
+func (fs *FirebaseStore) writeSnapshot(ctx context.Context, doc *firestore.DocumentRef, data any, vocabs []model.Vocab) error {
+	existing, err := doc.Collection("vocabs").DocumentRefs(ctx).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to list snapshot vocab: %w", err)
+	}
+
+	bw := fs.client.Client.BulkWriter(ctx)
+	var jobs []*firestore.BulkWriterJob
+	for i, vocab := range vocabs {
+		job, err := bw.Set(doc.Collection("vocabs").Doc(snapshotVocabId(i)), vocab)
+		if err != nil {
+			bw.End()
+			return fmt.Errorf("failed to enqueue snapshot vocab: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	for _, ref := range existing {
+		if i, err := strconv.Atoi(ref.ID); err == nil && i < len(vocabs) && ref.ID == snapshotVocabId(i) {
+			continue
+		}
+		job, err := bw.Delete(ref)
+		if err != nil {
+			bw.End()
+			return fmt.Errorf("failed to enqueue snapshot vocab deletion: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return fmt.Errorf("failed to write snapshot vocab: %w", err)
+		}
+	}
+
+	if _, err := doc.Set(ctx, data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// readSnapshot returns the vocab of the snapshot stored at doc in order.
+func (fs *FirebaseStore) readSnapshot(ctx context.Context, doc *firestore.DocumentRef) ([]model.Vocab, error) {
+	iter := doc.Collection("vocabs").OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	var vocabs []model.Vocab
+	for {
+		snap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+
+		var vocab model.Vocab
+		if err := snap.DataTo(&vocab); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot vocab %s: %w", snap.Ref.ID, err)
+		}
+		vocabs = append(vocabs, vocab)
+	}
+
+	return vocabs, nil
+}
+
+func (fs *FirebaseStore) SetClass(ctx context.Context, class model.Class) error {
+	_, err := fs.client.Client.Collection("classes").Doc(class.Id.String()).Set(ctx, class)
+	if err != nil {
+		return fmt.Errorf("failed to set class: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) GetClass(ctx context.Context, classId uuid.UUID) (*model.Class, error) {
+	doc, err := fs.client.Client.Collection("classes").Doc(classId.String()).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrClassNotFound
+		}
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+
+	var class model.Class
+	if err := doc.DataTo(&class); err != nil {
+		return nil, fmt.Errorf("failed to decode class: %w", err)
+	}
+
+	return &class, nil
+}
+
+// queryClasses returns every class matched by query.
+func queryClasses(ctx context.Context, query firestore.Query) ([]model.Class, error) {
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	results := make([]model.Class, 0)
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+
+		var class model.Class
+		if err := doc.DataTo(&class); err != nil {
+			continue
+		}
+		results = append(results, class)
+	}
+
+	return results, nil
+}
+
+// updateClass applies updates to the fields of a class without rewriting
+// the rest of it.
+func (fs *FirebaseStore) updateClass(ctx context.Context, classId uuid.UUID, updates ...firestore.Update) error {
+	_, err := fs.client.Client.Collection("classes").Doc(classId.String()).Update(ctx, updates)
+	if status.Code(err) == codes.NotFound {
+		return ErrClassNotFound
+	}
+	return err
+}
+
+func (fs *FirebaseStore) SetClassInviteCode(ctx context.Context, classId uuid.UUID, code string) error {
+	if err := fs.updateClass(ctx, classId, firestore.Update{Path: "InviteCode", Value: code}); err != nil {
+		return fmt.Errorf("failed to set invite code: %w", err)
+	}
+	return nil
+}
+
+// AddClassStudent adds the student to the class unless they attend it
+// already. Concurrent changes of the student list are not lost.
+func (fs *FirebaseStore) AddClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error {
+	err := fs.updateClass(ctx, classId, firestore.Update{Path: "StudentIds", Value: firestore.ArrayUnion(studentId)})
+	if err != nil {
+		return fmt.Errorf("failed to add student: %w", err)
+	}
+	return nil
+}
+
+// RemoveClassStudent removes the student from the class if they attend
+// it. Concurrent changes of the student list are not lost.
+func (fs *FirebaseStore) RemoveClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error {
+	err := fs.updateClass(ctx, classId, firestore.Update{Path: "StudentIds", Value: firestore.ArrayRemove(studentId)})
+	if err != nil {
+		return fmt.Errorf("failed to remove student: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) FindClassByInviteCode(ctx context.Context, code string) (*model.Class, error) {
+	query := fs.client.Client.Collection("classes").Where("InviteCode", "==", code).Limit(1)
+	classes, err := queryClasses(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find class: %w", err)
+	}
+	if len(classes) == 0 {
+		return nil, ErrInvalidInviteCode
+	}
+	return &classes[0], nil
+}
+
+// ListUserClasses returns the classes the user teaches followed by the
+// classes the user is enrolled in.
+func (fs *FirebaseStore) ListUserClasses(ctx context.Context, userId string) ([]model.Class, error) {
+	classes := fs.client.Client.Collection("classes")
+
+	teaching, err := queryClasses(ctx, classes.Where("TeacherId", "==", userId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list taught classes: %w", err)
+	}
+	enrolled, err := queryClasses(ctx, classes.Where("StudentIds", "array-contains", userId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrolled classes: %w", err)
+	}
+
+	return append(teaching, enrolled...), nil
+}
+
+// RemoveClass deletes the class together with its assignments.
+func (fs *FirebaseStore) RemoveClass(ctx context.Context, classId uuid.UUID) error {
+	if err := fs.deleteTree(ctx, fs.client.Client.Collection("classes").Doc(classId.String())); err != nil {
+		return fmt.Errorf("failed to remove class: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) assignmentDoc(classId uuid.UUID, assignmentId uuid.UUID) *firestore.DocumentRef {
+	return fs.client.Client.Collection("classes").Doc(classId.String()).
+		Collection("assignments").Doc(assignmentId.String())
+}
+
+// SetAssignment stores the assignment with its vocab in a "vocabs"
+// subcollection. The copies are stored on their own with
+// SetAssignmentCopy.
+func (fs *FirebaseStore) SetAssignment(ctx context.Context, assignment model.Assignment) error {
+	vocabs := assignment.Vocabs
+	assignment.Vocabs = nil
+	assignment.Copies = nil
+	doc := fs.assignmentDoc(assignment.ClassId, assignment.Id)
+	if err := fs.writeSnapshot(ctx, doc, assignment, vocabs); err != nil {
+		return fmt.Errorf("failed to set assignment: %w", err)
+	}
+	return nil
+}
+
+// ListAssignments returns the assignments of the class without their
+// vocab and copies.
+func (fs *FirebaseStore) ListAssignments(ctx context.Context, classId uuid.UUID) ([]model.Assignment, error) {
+	iter := fs.client.Client.Collection("classes").Doc(classId.String()).
+		Collection("assignments").OrderBy("DueAt", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	results := []model.Assignment{}
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to list assignments: %w", err)
+		}
+
+		var assignment model.Assignment
+		if err := doc.DataTo(&assignment); err != nil {
+			continue
+		}
+		results = append(results, assignment)
+	}
+
+	return results, nil
+}
+
+func (fs *FirebaseStore) GetAssignmentVocabs(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) ([]model.Vocab, error) {
+	vocabs, err := fs.readSnapshot(ctx, fs.assignmentDoc(classId, assignmentId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment vocab: %w", err)
+	}
+	return vocabs, nil
+}
+
+// SetAssignmentCopy records the copy of an assignment in a student's
+// library as a document of the assignment's "copies" subcollection.
+func (fs *FirebaseStore) SetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string, copied model.AssignmentCopy,
+) error {
+	_, err := fs.assignmentDoc(classId, assignmentId).Collection("copies").Doc(studentId).Set(ctx, copied)
+	if err != nil {
+		return fmt.Errorf("failed to set assignment copy: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) GetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string,
+) (*model.AssignmentCopy, error) {
+	doc, err := fs.assignmentDoc(classId, assignmentId).Collection("copies").Doc(studentId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrAssignmentCopyNotFound
+		}
+		return nil, fmt.Errorf("failed to get assignment copy: %w", err)
+	}
+
+	var copied model.AssignmentCopy
+	if err := doc.DataTo(&copied); err != nil {
+		return nil, fmt.Errorf("failed to decode assignment copy: %w", err)
+	}
+	return &copied, nil
+}
+
+// ListAssignmentCopies returns the copies of an assignment by student id.
+func (fs *FirebaseStore) ListAssignmentCopies(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) (map[string]model.AssignmentCopy, error) {
+	iter := fs.assignmentDoc(classId, assignmentId).Collection("copies").Documents(ctx)
+	defer iter.Stop()
+
+	results := map[string]model.AssignmentCopy{}
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to list assignment copies: %w", err)
+		}
+
+		var copied model.AssignmentCopy
+		if err := doc.DataTo(&copied); err != nil {
+			continue
+		}
+		results[doc.Ref.ID] = copied
+	}
+
+	return results, nil
+}
+
+// RemoveAssignment deletes the assignment together with its vocab and
+// copies.
+func (fs *FirebaseStore) RemoveAssignment(ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID) error {
+	if err := fs.deleteTree(ctx, fs.assignmentDoc(classId, assignmentId)); err != nil {
+		return fmt.Errorf("failed to remove assignment: %w", err)
+	}
+	return nil
+}
+
+// storageRole is the document of a user in the roles collection.
+type storageRole struct {
+	Role string `firestore:"role"`
+}
+
+// GetUserRole returns the role stored for the user, or "" if none is.
+func (fs *FirebaseStore) GetUserRole(ctx context.Context, userId string) (model.Role, error) {
+	doc, err := fs.client.Client.Collection("roles").Doc(userId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+
+	var role storageRole
+	if err := doc.DataTo(&role); err != nil {
+		return "", fmt.Errorf("failed to decode user role: %w", err)
+	}
+
+	return model.Role(role.Role), nil
+}
+
+// SetUserRole stores the role of the user; an empty role removes it.
+func (fs *FirebaseStore) SetUserRole(ctx context.Context, userId string, role model.Role) error {
+	doc := fs.client.Client.Collection("roles").Doc(userId)
+
+	var err error
+	if role == "" {
+		_, err = doc.Delete(ctx)
+	} else {
+		_, err = doc.Set(ctx, storageRole{Role: string(role)})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set user role: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *FirebaseStore) SetAPIKey(ctx context.Context, key model.APIKey) error {
+	_, err := fs.client.Client.Collection("api_keys").Doc(key.Id).Set(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to set api key: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) GetAPIKey(ctx context.Context, keyId string) (*model.APIKey, error) {
+	doc, err := fs.client.Client.Collection("api_keys").Doc(keyId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	var key model.APIKey
+	if err := doc.DataTo(&key); err != nil {
+		return nil, fmt.Errorf("failed to decode api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// ListAPIKeys returns the keys of the user, oldest first.
+func (fs *FirebaseStore) ListAPIKeys(ctx context.Context, userId string) ([]model.APIKey, error) {
+	iter := fs.client.Client.Collection("api_keys").Where("UserId", "==", userId).Documents(ctx)
+	defer iter.Stop()
+
+	results := []model.APIKey{}
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to list api keys: %w", err)
+		}
+
+		var key model.APIKey
+		if err := doc.DataTo(&key); err != nil {
+			continue
+		}
+		results = append(results, key)
+	}
+
+	slices.SortFunc(results, func(a, b model.APIKey) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return results, nil
+}
+
+// TouchAPIKey records when a key was last used. Keys removed in the
+// meantime stay removed.
+func (fs *FirebaseStore) TouchAPIKey(ctx context.Context, keyId string, usedAt time.Time) error {
+	_, err := fs.client.Client.Collection("api_keys").Doc(keyId).Update(ctx, []firestore.Update{
+		{Path: "LastUsedAt", Value: usedAt},
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+	return nil
+}
+
+func (fs *FirebaseStore) RemoveAPIKey(ctx context.Context, keyId string) error {
+	_, err := fs.client.Client.Collection("api_keys").Doc(keyId).Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to remove api key: %w", err)
+	}
+	return nil
+}
+
+// storageDataVersion is the document in the meta collection recording up
+// to which version every user's data has been migrated.
+type storageDataVersion struct {
+	Version int `firestore:"version"`
+}
+
+// GetDataVersion returns the data version every user has been migrated
+// to, or 0 if no migration has finished yet.
+func (fs *FirebaseStore) GetDataVersion(ctx context.Context) (int, error) {
+	doc, err := fs.client.Client.Collection("meta").Doc("data").Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get data version: %w", err)
+	}
+
+	var version storageDataVersion
+	if err := doc.DataTo(&version); err != nil {
+		return 0, fmt.Errorf("failed to decode data version: %w", err)
+	}
+
+	return version.Version, nil
+}
+
+func (fs *FirebaseStore) SetDataVersion(ctx context.Context, version int) error {
+	_, err := fs.client.Client.Collection("meta").Doc("data").Set(ctx, storageDataVersion{Version: version})
+	if err != nil {
+		return fmt.Errorf("failed to set data version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+// newLocalDictionary returns a dictionary on a LocalStore in a temporary
+// directory.
+func newLocalDictionary(t *testing.T) (*LocalStore, *Dictionary) {
+	t.Helper()
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	return store, &Dictionary{storage: store}
+}
+
+// addSet imports the vocab into the user's library and adds a set of it.
+func addSet(t *testing.T, dict *Dictionary, userId string, name string, vocabs ...model.Vocab) model.VocabSet {
+	t.Helper()
+
+	ctx := userid.ToCtx(context.Background(), userId)
+	results, err := dict.ImportWords(ctx, vocabs, false)
+	require.NoError(t, err)
+
+	set := model.VocabSet{Id: uuid.New(), Name: name, VocabIds: make([]uuid.UUID, len(results))}
+	for i, result := range results {
+		set.VocabIds[i] = result.Id
+	}
+	require.NoError(t, dict.storage.SetVocabSet(ctx, userId, set))
+	return set
+}
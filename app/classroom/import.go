@@ -0,0 +1,143 @@
+package classroom
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/textnorm"
+	"github.com/vladazn/danish/common/userid"
+)
+
+// MaxImportSize is the most vocab a single import may carry.
+const MaxImportSize = 2000
+
+// ImportWords adds imported vocab to the user's vocabulary. Vocab matching
+// an existing or earlier imported word by id, definition or form value is
+// merged into it, keeping the higher progress; invalid vocab is rejected.
+// With dryRun nothing is written and the results show what would happen.
+func (d *Dictionary) ImportWords(ctx context.Context, vocabs []model.Vocab, dryRun bool) ([]model.ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.ImportWords")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	if len(vocabs) > MaxImportSize {
+		return nil, fmt.Errorf("%w: at most %d vocab per import", ErrInvalidVocab, MaxImportSize)
+	}
+
+	var requestedIds []uuid.UUID
+	var terms []string
+	for _, vocab := range vocabs {
+		if vocab.Id != uuid.Nil {
+			requestedIds = append(requestedIds, vocab.Id)
+		}
+		terms = append(terms, exactTerms(vocab)...)
+	}
+
+	stored, err := d.storage.GetMultipleVocabs(ctx, userId, requestedIds)
+	if err != nil {
+		return nil, fmt.Errorf("could not get existing vocab: %w", err)
+	}
+	candidates, err := d.findByExactTerms(ctx, userId, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	// known holds every vocab an import row may merge into, termIndex maps
+	// exact terms to them.
+	known := map[uuid.UUID]*model.Vocab{}
+	termIndex := map[string]uuid.UUID{}
+	remember := func(vocab *model.Vocab) {
+		known[vocab.Id] = vocab
+		for _, term := range exactTerms(*vocab) {
+			if _, ok := termIndex[term]; !ok {
+				termIndex[term] = vocab.Id
+			}
+		}
+	}
+	for _, vocab := range append(stored, candidates...) {
+		remember(&vocab)
+	}
+	existing := make(map[uuid.UUID]bool, len(known))
+	for id := range known {
+		existing[id] = true
+	}
+
+	results := make([]model.ImportResult, len(vocabs))
+	changed := map[uuid.UUID]bool{}
+	var order []uuid.UUID
+	now := time.Now()
+	for i, vocab := range vocabs {
+		results[i] = model.ImportResult{Index: i, Id: vocab.Id}
+
+		assignIds(&vocab)
+		if err := vocab.Validate(); err != nil {
+			results[i].Status = model.ImportStatusRejected
+			results[i].Reason = err.Error()
+			continue
+		}
+
+		target, ok := known[vocab.Id]
+		if !ok {
+			for _, term := range exactTerms(vocab) {
+				if id, found := termIndex[term]; found {
+					target = known[id]
+					break
+				}
+			}
+		}
+
+		if target != nil {
+			target.MergeForms(vocab, textnorm.Normalize)
+			remember(target)
+			results[i].Id = target.Id
+			results[i].Status = model.ImportStatusMerged
+		} else {
+			vocab.CreatedAt = now
+			target = &vocab
+			remember(target)
+			results[i].Id = vocab.Id
+			results[i].Status = model.ImportStatusCreated
+		}
+
+		if !changed[target.Id] {
+			changed[target.Id] = true
+			order = append(order, target.Id)
+		}
+	}
+
+	if dryRun || len(order) == 0 {
+		return results, nil
+	}
+
+	var batch model.VocabBatch
+	var mergedIds []uuid.UUID
+	for _, id := range order {
+		vocab := *known[id]
+		if err := vocab.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: merged vocab %s: %w", ErrInvalidVocab, id, err)
+		}
+		vocab.DueAt = vocab.NextDue()
+		batch.Set = append(batch.Set, vocab)
+		batch.Index = append(batch.Index, newSearchEntry(vocab))
+		if existing[id] {
+			mergedIds = append(mergedIds, id)
+		}
+	}
+
+	if err := d.storage.WriteVocabBatch(ctx, userId, batch); err != nil {
+		return nil, fmt.Errorf("could not save imported vocab: %w", err)
+	}
+
+	if len(mergedIds) > 0 {
+		if err := d.invalidatePool(ctx, userId, mergedIds...); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
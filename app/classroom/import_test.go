@@ -0,0 +1,123 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+func TestDictionary_ImportWords(t *testing.T) {
+	userId := "test-user"
+	lastSuccess := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stored := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		Forms:      []model.VocabForm{{Id: uuid.New(), Value: "hus", Form: "indefinite", Level: 1}},
+	}
+
+	vocabs := []model.Vocab{
+		{Definition: "House", Forms: []model.VocabForm{{Value: "hus", Form: "indefinite", Level: 3, LastSuccess: lastSuccess}}},
+		{Definition: "tree", Forms: []model.VocabForm{{Value: "træ"}}},
+		{Definition: ""},
+		{Definition: "TREE", Forms: []model.VocabForm{{Value: "træet", Form: "definite"}}},
+	}
+
+	setupMock := func(mock *mocks.MockFirestore) {
+		mock.EXPECT().
+			GetMultipleVocabs(gomock.Any(), userId, nil).
+			Return([]model.Vocab{}, nil)
+		mock.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+		mock.EXPECT().
+			SearchVocabIds(gomock.Any(), userId, gomock.Any(), 0).
+			Return([]uuid.UUID{stored.Id}, nil)
+		mock.EXPECT().
+			GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{stored.Id}).
+			Return([]model.Vocab{stored}, nil)
+	}
+
+	expected := []model.ImportStatus{
+		model.ImportStatusMerged,
+		model.ImportStatusCreated,
+		model.ImportStatusRejected,
+		model.ImportStatusMerged,
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockFirestore(ctrl)
+		setupMock(mockStore)
+
+		dict := &Dictionary{storage: mockStore}
+		ctx := userid.ToCtx(context.Background(), userId)
+
+		results, err := dict.ImportWords(ctx, vocabs, true)
+		require.NoError(t, err)
+		for i, result := range results {
+			require.Equal(t, expected[i], result.Status, "item %d", i)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockFirestore(ctrl)
+		setupMock(mockStore)
+		mockStore.EXPECT().
+			WriteVocabBatch(gomock.Any(), userId, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, batch model.VocabBatch) error {
+				require.Len(t, batch.Set, 2)
+				require.Len(t, batch.Index, 2)
+
+				house := batch.Set[0]
+				require.Equal(t, stored.Id, house.Id)
+				require.Len(t, house.Forms, 1)
+				require.Equal(t, stored.Forms[0].Id, house.Forms[0].Id)
+				require.Equal(t, 3, house.Forms[0].Level)
+
+				tree := batch.Set[1]
+				require.Equal(t, "tree", tree.Definition)
+				require.Len(t, tree.Forms, 2)
+				require.False(t, tree.CreatedAt.IsZero())
+				return nil
+			})
+		mockStore.EXPECT().FetchUserPool(gomock.Any(), userId).Return(nil, ErrPoolNotFound)
+
+		dict := &Dictionary{storage: mockStore}
+		ctx := userid.ToCtx(context.Background(), userId)
+
+		results, err := dict.ImportWords(ctx, vocabs, false)
+		require.NoError(t, err)
+		require.Len(t, results, len(vocabs))
+		for i, result := range results {
+			require.Equal(t, i, result.Index)
+			require.Equal(t, expected[i], result.Status, "item %d", i)
+		}
+		require.Equal(t, stored.Id, results[0].Id)
+		require.Equal(t, results[1].Id, results[3].Id)
+		require.NotEmpty(t, results[2].Reason)
+	})
+}
+
+func TestDictionary_ImportWords_TooMany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dict := &Dictionary{storage: mocks.NewMockFirestore(ctrl)}
+	ctx := userid.ToCtx(context.Background(), "test-user")
+
+	results, err := dict.ImportWords(ctx, make([]model.Vocab, MaxImportSize+1), false)
+	require.ErrorIs(t, err, ErrInvalidVocab)
+	require.Nil(t, results)
+}
@@ -0,0 +1,940 @@
+package classroom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+// LocalStore keeps every user's data in a JSON file under a directory. It
+// implements the same storage interface as FirebaseStore and is meant for
+// development, tests against real data and migrations, not for serving
+// many users.
+type LocalStore struct {
+	dir string
+
+	mu        sync.Mutex
+	users     map[string]*localUser
+	published map[string]model.PublishedSet
+	classes   map[uuid.UUID]*localClass
+	roles     map[string]model.Role
+	apiKeys   map[string]localAPIKey
+}
+
+// localAPIKey keeps the hash next to the key, which leaves it out of JSON.
+type localAPIKey struct {
+	Key  model.APIKey `json:"key"`
+	Hash string       `json:"hash"`
+}
+
+type localClass struct {
+	Class       model.Class                    `json:"class"`
+	Assignments map[uuid.UUID]model.Assignment `json:"assignments"`
+}
+
+type localUser struct {
+	Vocab  map[uuid.UUID]model.Vocab    `json:"vocab"`
+	Sets   map[uuid.UUID]model.VocabSet `json:"sets"`
+	Pool   *model.Pool                  `json:"pool,omitempty"`
+	Search map[uuid.UUID][]string       `json:"search"`
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "users"), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local store: %w", err)
+	}
+
+	return &LocalStore{
+		dir:   dir,
+		users: map[string]*localUser{},
+	}, nil
+}
+
+func (ls *LocalStore) userPath(userId string) string {
+	return filepath.Join(ls.dir, "users", url.PathEscape(userId)+".json")
+}
+
+// user returns the cached data of the user, loading it on first use. The
+// caller must hold ls.mu.
+func (ls *LocalStore) user(userId string) (*localUser, error) {
+	if user, ok := ls.users[userId]; ok {
+		return user, nil
+	}
+
+	user := &localUser{}
+	data, err := os.ReadFile(ls.userPath(userId))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read user %s: %w", userId, err)
+	default:
+		if err := json.Unmarshal(data, user); err != nil {
+			return nil, fmt.Errorf("failed to decode user %s: %w", userId, err)
+		}
+	}
+
+	if user.Vocab == nil {
+		user.Vocab = map[uuid.UUID]model.Vocab{}
+	}
+	if user.Sets == nil {
+		user.Sets = map[uuid.UUID]model.VocabSet{}
+	}
+	if user.Search == nil {
+		user.Search = map[uuid.UUID][]string{}
+	}
+
+	ls.users[userId] = user
+	return user, nil
+}
+
+// save writes the user file through a temporary file so a crash never
+// leaves it half written. The caller must hold ls.mu.
+func (ls *LocalStore) save(userId string, user *localUser) error {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to encode user %s: %w", userId, err)
+	}
+
+	path := ls.userPath(userId)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write user %s: %w", userId, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write user %s: %w", userId, err)
+	}
+
+	return nil
+}
+
+// update runs fn on the user's data and saves it afterwards.
+func (ls *LocalStore) update(userId string, fn func(user *localUser)) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	user, err := ls.user(userId)
+	if err != nil {
+		return err
+	}
+	fn(user)
+	return ls.save(userId, user)
+}
+
+// view runs fn on the user's data without saving it.
+func (ls *LocalStore) view(userId string, fn func(user *localUser)) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	user, err := ls.user(userId)
+	if err != nil {
+		return err
+	}
+	fn(user)
+	return nil
+}
+
+// copyVocab copies the forms of a vocab, so callers changing what they
+// passed in or got back do not change the cached data, just like with a
+// remote store.
+func copyVocab(vocab model.Vocab) model.Vocab {
+	vocab.Forms = slices.Clone(vocab.Forms)
+	return vocab
+}
+
+// sortedVocab returns the user's vocab in id order, like Firestore returns
+// documents.
+func (user *localUser) sortedVocab() []model.Vocab {
+	vocabs := make([]model.Vocab, 0, len(user.Vocab))
+	for _, vocab := range user.Vocab {
+		vocabs = append(vocabs, copyVocab(vocab))
+	}
+	slices.SortFunc(vocabs, func(a, b model.Vocab) int {
+		return strings.Compare(a.Id.String(), b.Id.String())
+	})
+	return vocabs
+}
+
+func (ls *LocalStore) FetchUserVocabulary(ctx context.Context, userId string) ([]model.Vocab, error) {
+	var results []model.Vocab
+	err := ls.view(userId, func(user *localUser) {
+		if len(user.Vocab) > 0 {
+			results = user.sortedVocab()
+		}
+	})
+	return results, err
+}
+
+func (ls *LocalStore) QueryUserVocabulary(
+	ctx context.Context, userId string, q model.VocabQuery,
+) (*model.VocabPage, error) {
+	var vocabs []model.Vocab
+	var inSet map[uuid.UUID]bool
+	err := ls.view(userId, func(user *localUser) {
+		vocabs = user.sortedVocab()
+		if q.SetId != uuid.Nil {
+			inSet = map[uuid.UUID]bool{}
+			for _, id := range user.Sets[q.SetId].VocabIds {
+				inSet[id] = true
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageVocabs(vocabs, q, inSet)
+}
+
+func (ls *LocalStore) AddVocabulary(ctx context.Context, userId string, vocab model.Vocab) error {
+	return ls.update(userId, func(user *localUser) {
+		user.Vocab[vocab.Id] = copyVocab(vocab)
+	})
+}
+
+func (ls *LocalStore) RemoveVocabulary(ctx context.Context, userId string, vocabId uuid.UUID) error {
+	return ls.update(userId, func(user *localUser) {
+		delete(user.Vocab, vocabId)
+	})
+}
+
+func (ls *LocalStore) UpdatePool(ctx context.Context, userId string, pool *model.Pool) error {
+	return ls.update(userId, func(user *localUser) {
+		user.Pool = pool
+	})
+}
+
+func (ls *LocalStore) FetchUserPool(ctx context.Context, userId string) (*model.Pool, error) {
+	var pool *model.Pool
+	err := ls.view(userId, func(user *localUser) {
+		pool = user.Pool
+	})
+	if err == nil && pool == nil {
+		return nil, ErrPoolNotFound
+	}
+	return pool, err
+}
+
+func (ls *LocalStore) GetVocab(ctx context.Context, userId string, vocabId uuid.UUID) (*model.Vocab, error) {
+	var result *model.Vocab
+	err := ls.view(userId, func(user *localUser) {
+		if vocab, ok := user.Vocab[vocabId]; ok {
+			vocab = copyVocab(vocab)
+			result = &vocab
+		}
+	})
+	if err == nil && result == nil {
+		return nil, ErrVocabNotFound
+	}
+	return result, err
+}
+
+func (ls *LocalStore) GetMultipleVocabs(ctx context.Context, userId string, vocabIds []uuid.UUID) ([]model.Vocab, error) {
+	if len(vocabIds) == 0 {
+		return []model.Vocab{}, nil
+	}
+
+	var results []model.Vocab
+	err := ls.view(userId, func(user *localUser) {
+		for _, vocabId := range vocabIds {
+			if vocab, ok := user.Vocab[vocabId]; ok {
+				results = append(results, copyVocab(vocab))
+			}
+		}
+	})
+	return results, err
+}
+
+func (ls *LocalStore) SetVocabSet(ctx context.Context, userId string, vocabSet model.VocabSet) error {
+	return ls.update(userId, func(user *localUser) {
+		user.Sets[vocabSet.Id] = vocabSet
+	})
+}
+
+func (ls *LocalStore) GetVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) (*model.VocabSet, error) {
+	var result *model.VocabSet
+	err := ls.view(userId, func(user *localUser) {
+		if vocabSet, ok := user.Sets[vocabSetId]; ok {
+			result = &vocabSet
+		}
+	})
+	if err == nil && result == nil {
+		return nil, ErrSetNotFound
+	}
+	return result, err
+}
+
+func (ls *LocalStore) FetchUserVocabSets(ctx context.Context, userId string) ([]model.VocabSet, error) {
+	results := []model.VocabSet{}
+	err := ls.view(userId, func(user *localUser) {
+		for _, vocabSet := range user.Sets {
+			results = append(results, vocabSet)
+		}
+	})
+	slices.SortFunc(results, func(a, b model.VocabSet) int {
+		return strings.Compare(a.Id.String(), b.Id.String())
+	})
+	return results, err
+}
+
+func (ls *LocalStore) RemoveVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) error {
+	return ls.update(userId, func(user *localUser) {
+		delete(user.Sets, vocabSetId)
+	})
+}
+
+func (ls *LocalStore) SetSearchEntry(ctx context.Context, userId string, entry model.SearchEntry) error {
+	return ls.update(userId, func(user *localUser) {
+		user.Search[entry.VocabId] = entry.Terms
+	})
+}
+
+func (ls *LocalStore) RemoveSearchEntry(ctx context.Context, userId string, vocabId uuid.UUID) error {
+	return ls.update(userId, func(user *localUser) {
+		delete(user.Search, vocabId)
+	})
+}
+
+func (ls *LocalStore) SearchVocabIds(
+	ctx context.Context, userId string, terms []string, limit int,
+) ([]uuid.UUID, error) {
+	results := []uuid.UUID{}
+	if len(terms) == 0 {
+		return results, nil
+	}
+
+	// Go through the entries in id order, like Firestore returns them.
+	err := ls.view(userId, func(user *localUser) {
+		vocabIds := slices.SortedFunc(maps.Keys(user.Search), func(a, b uuid.UUID) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, vocabId := range vocabIds {
+			if limit > 0 && len(results) == limit {
+				break
+			}
+			entryTerms := user.Search[vocabId]
+			if slices.ContainsFunc(terms, func(term string) bool { return slices.Contains(entryTerms, term) }) {
+				results = append(results, vocabId)
+			}
+		}
+	})
+	return results, err
+}
+
+func (ls *LocalStore) WriteVocabBatch(ctx context.Context, userId string, batch model.VocabBatch) error {
+	return ls.update(userId, func(user *localUser) {
+		for _, vocab := range batch.Set {
+			user.Vocab[vocab.Id] = copyVocab(vocab)
+		}
+		for _, entry := range batch.Index {
+			user.Search[entry.VocabId] = entry.Terms
+		}
+		for _, vocabId := range batch.Delete {
+			delete(user.Vocab, vocabId)
+			delete(user.Search, vocabId)
+		}
+	})
+}
+
+func (ls *LocalStore) DeleteUser(ctx context.Context, userId string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	delete(ls.users, userId)
+	if err := os.Remove(ls.userPath(userId)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete user %s: %w", userId, err)
+	}
+	return nil
+}
+
+func (ls *LocalStore) ListUsers(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(ls.dir, "users"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var userIds []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		userId, err := url.PathUnescape(name)
+		if err != nil {
+			continue
+		}
+		userIds = append(userIds, userId)
+	}
+
+	return userIds, nil
+}
+
+// readJSONFile decodes the file at path into v, leaving v as it is if the
+// file does not exist.
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeJSONFile writes v through a temporary file so a crash never leaves
+// the file half written.
+func writeJSONFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (ls *LocalStore) publishedPath() string {
+	return filepath.Join(ls.dir, "published.json")
+}
+
+// publishedSets returns the cached published sets, loading them on first
+// use. The caller must hold ls.mu.
+func (ls *LocalStore) publishedSets() (map[string]model.PublishedSet, error) {
+	if ls.published != nil {
+		return ls.published, nil
+	}
+
+	published := map[string]model.PublishedSet{}
+	if err := readJSONFile(ls.publishedPath(), &published); err != nil {
+		return nil, fmt.Errorf("failed to read published sets: %w", err)
+	}
+
+	ls.published = published
+	return published, nil
+}
+
+// updatePublished runs fn on the published sets and saves them afterwards.
+func (ls *LocalStore) updatePublished(fn func(published map[string]model.PublishedSet)) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	published, err := ls.publishedSets()
+	if err != nil {
+		return err
+	}
+	fn(published)
+
+	if err := writeJSONFile(ls.publishedPath(), published); err != nil {
+		return fmt.Errorf("failed to write published sets: %w", err)
+	}
+	return nil
+}
+
+func (ls *LocalStore) SetPublishedSet(ctx context.Context, set model.PublishedSet) error {
+	return ls.updatePublished(func(published map[string]model.PublishedSet) {
+		published[set.Id] = set
+	})
+}
+
+func (ls *LocalStore) GetPublishedSet(ctx context.Context, id string) (*model.PublishedSet, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	published, err := ls.publishedSets()
+	if err != nil {
+		return nil, err
+	}
+	set, ok := published[id]
+	if !ok {
+		return nil, ErrPublishedSetNotFound
+	}
+	return &set, nil
+}
+
+func (ls *LocalStore) ListPublishedSets(
+	ctx context.Context, ownerId string, limit int,
+) ([]model.PublishedSet, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	published, err := ls.publishedSets()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []model.PublishedSet{}
+	for _, set := range published {
+		if ownerId != "" && set.OwnerId != ownerId {
+			continue
+		}
+		set.Vocabs = nil
+		results = append(results, set)
+	}
+	slices.SortFunc(results, func(a, b model.PublishedSet) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+func (ls *LocalStore) RemovePublishedSet(ctx context.Context, id string) error {
+	return ls.updatePublished(func(published map[string]model.PublishedSet) {
+		delete(published, id)
+	})
+}
+
+func (ls *LocalStore) classesPath() string {
+	return filepath.Join(ls.dir, "classes.json")
+}
+
+// classList returns the cached classes, loading them on first use. The
+// caller must hold ls.mu.
+func (ls *LocalStore) classList() (map[uuid.UUID]*localClass, error) {
+	if ls.classes != nil {
+		return ls.classes, nil
+	}
+
+	classes := map[uuid.UUID]*localClass{}
+	if err := readJSONFile(ls.classesPath(), &classes); err != nil {
+		return nil, fmt.Errorf("failed to read classes: %w", err)
+	}
+	for _, class := range classes {
+		if class.Assignments == nil {
+			class.Assignments = map[uuid.UUID]model.Assignment{}
+		}
+	}
+
+	ls.classes = classes
+	return classes, nil
+}
+
+// viewClasses runs fn on the classes without saving them.
+func (ls *LocalStore) viewClasses(fn func(classes map[uuid.UUID]*localClass)) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	classes, err := ls.classList()
+	if err != nil {
+		return err
+	}
+	fn(classes)
+	return nil
+}
+
+// updateClasses runs fn on the classes and saves them afterwards.
+func (ls *LocalStore) updateClasses(fn func(classes map[uuid.UUID]*localClass)) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	classes, err := ls.classList()
+	if err != nil {
+		return err
+	}
+	fn(classes)
+
+	if err := writeJSONFile(ls.classesPath(), classes); err != nil {
+		return fmt.Errorf("failed to write classes: %w", err)
+	}
+	return nil
+}
+
+func (ls *LocalStore) SetClass(ctx context.Context, class model.Class) error {
+	return ls.updateClasses(func(classes map[uuid.UUID]*localClass) {
+		if existing, ok := classes[class.Id]; ok {
+			existing.Class = class
+			return
+		}
+		classes[class.Id] = &localClass{Class: class, Assignments: map[uuid.UUID]model.Assignment{}}
+	})
+}
+
+// updateClass runs fn on the class and saves it afterwards.
+func (ls *LocalStore) updateClass(classId uuid.UUID, fn func(class *model.Class)) error {
+	found := false
+	err := ls.updateClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			found = true
+			fn(&class.Class)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrClassNotFound
+	}
+	return nil
+}
+
+func (ls *LocalStore) SetClassInviteCode(ctx context.Context, classId uuid.UUID, code string) error {
+	return ls.updateClass(classId, func(class *model.Class) {
+		class.InviteCode = code
+	})
+}
+
+func (ls *LocalStore) AddClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error {
+	return ls.updateClass(classId, func(class *model.Class) {
+		if !slices.Contains(class.StudentIds, studentId) {
+			class.StudentIds = append(class.StudentIds, studentId)
+		}
+	})
+}
+
+func (ls *LocalStore) RemoveClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error {
+	return ls.updateClass(classId, func(class *model.Class) {
+		class.StudentIds = slices.DeleteFunc(class.StudentIds, func(id string) bool { return id == studentId })
+	})
+}
+
+func (ls *LocalStore) GetClass(ctx context.Context, classId uuid.UUID) (*model.Class, error) {
+	var result *model.Class
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			c := class.Class
+			result = &c
+		}
+	})
+	if err == nil && result == nil {
+		return nil, ErrClassNotFound
+	}
+	return result, err
+}
+
+func (ls *LocalStore) FindClassByInviteCode(ctx context.Context, code string) (*model.Class, error) {
+	var result *model.Class
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		for _, class := range classes {
+			if class.Class.InviteCode == code {
+				c := class.Class
+				result = &c
+				return
+			}
+		}
+	})
+	if err == nil && result == nil {
+		return nil, ErrInvalidInviteCode
+	}
+	return result, err
+}
+
+func (ls *LocalStore) ListUserClasses(ctx context.Context, userId string) ([]model.Class, error) {
+	teaching, enrolled := []model.Class{}, []model.Class{}
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		for _, class := range classes {
+			switch {
+			case class.Class.TeacherId == userId:
+				teaching = append(teaching, class.Class)
+			case slices.Contains(class.Class.StudentIds, userId):
+				enrolled = append(enrolled, class.Class)
+			}
+		}
+	})
+	byCreation := func(a, b model.Class) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	slices.SortFunc(teaching, byCreation)
+	slices.SortFunc(enrolled, byCreation)
+	return append(teaching, enrolled...), err
+}
+
+func (ls *LocalStore) RemoveClass(ctx context.Context, classId uuid.UUID) error {
+	return ls.updateClasses(func(classes map[uuid.UUID]*localClass) {
+		delete(classes, classId)
+	})
+}
+
+// SetAssignment stores the assignment and keeps the copies recorded for
+// it.
+func (ls *LocalStore) SetAssignment(ctx context.Context, assignment model.Assignment) error {
+	return ls.updateClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[assignment.ClassId]; ok {
+			assignment.Copies = class.Assignments[assignment.Id].Copies
+			class.Assignments[assignment.Id] = assignment
+		}
+	})
+}
+
+func (ls *LocalStore) ListAssignments(ctx context.Context, classId uuid.UUID) ([]model.Assignment, error) {
+	results := []model.Assignment{}
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			for _, assignment := range class.Assignments {
+				assignment.Vocabs = nil
+				assignment.Copies = nil
+				results = append(results, assignment)
+			}
+		}
+	})
+	slices.SortFunc(results, func(a, b model.Assignment) int { return a.DueAt.Compare(b.DueAt) })
+	return results, err
+}
+
+func (ls *LocalStore) GetAssignmentVocabs(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) ([]model.Vocab, error) {
+	var result []model.Vocab
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			result = slices.Clone(class.Assignments[assignmentId].Vocabs)
+		}
+	})
+	return result, err
+}
+
+func (ls *LocalStore) SetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string, copied model.AssignmentCopy,
+) error {
+	return ls.updateClasses(func(classes map[uuid.UUID]*localClass) {
+		class, ok := classes[classId]
+		if !ok {
+			return
+		}
+		assignment, ok := class.Assignments[assignmentId]
+		if !ok {
+			return
+		}
+		assignment.Copies = maps.Clone(assignment.Copies)
+		if assignment.Copies == nil {
+			assignment.Copies = map[string]model.AssignmentCopy{}
+		}
+		assignment.Copies[studentId] = copied
+		class.Assignments[assignmentId] = assignment
+	})
+}
+
+func (ls *LocalStore) GetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string,
+) (*model.AssignmentCopy, error) {
+	var result *model.AssignmentCopy
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			if copied, ok := class.Assignments[assignmentId].Copies[studentId]; ok {
+				result = &copied
+			}
+		}
+	})
+	if err == nil && result == nil {
+		return nil, ErrAssignmentCopyNotFound
+	}
+	return result, err
+}
+
+func (ls *LocalStore) ListAssignmentCopies(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) (map[string]model.AssignmentCopy, error) {
+	result := map[string]model.AssignmentCopy{}
+	err := ls.viewClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			maps.Copy(result, class.Assignments[assignmentId].Copies)
+		}
+	})
+	return result, err
+}
+
+func (ls *LocalStore) RemoveAssignment(ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID) error {
+	return ls.updateClasses(func(classes map[uuid.UUID]*localClass) {
+		if class, ok := classes[classId]; ok {
+			delete(class.Assignments, assignmentId)
+		}
+	})
+}
+
+func (ls *LocalStore) rolesPath() string {
+	return filepath.Join(ls.dir, "roles.json")
+}
+
+// userRoles returns the cached roles, loading them on first use. The
+// caller must hold ls.mu.
+func (ls *LocalStore) userRoles() (map[string]model.Role, error) {
+	if ls.roles != nil {
+		return ls.roles, nil
+	}
+
+	roles := map[string]model.Role{}
+	if err := readJSONFile(ls.rolesPath(), &roles); err != nil {
+		return nil, fmt.Errorf("failed to read roles: %w", err)
+	}
+
+	ls.roles = roles
+	return roles, nil
+}
+
+func (ls *LocalStore) GetUserRole(ctx context.Context, userId string) (model.Role, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	roles, err := ls.userRoles()
+	if err != nil {
+		return "", err
+	}
+	return roles[userId], nil
+}
+
+func (ls *LocalStore) SetUserRole(ctx context.Context, userId string, role model.Role) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	roles, err := ls.userRoles()
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		delete(roles, userId)
+	} else {
+		roles[userId] = role
+	}
+
+	if err := writeJSONFile(ls.rolesPath(), roles); err != nil {
+		return fmt.Errorf("failed to write roles: %w", err)
+	}
+	return nil
+}
+
+func (ls *LocalStore) apiKeysPath() string {
+	return filepath.Join(ls.dir, "api_keys.json")
+}
+
+// storedAPIKeys returns the cached API keys, loading them on first use.
+// The caller must hold ls.mu.
+func (ls *LocalStore) storedAPIKeys() (map[string]localAPIKey, error) {
+	if ls.apiKeys != nil {
+		return ls.apiKeys, nil
+	}
+
+	keys := map[string]localAPIKey{}
+	if err := readJSONFile(ls.apiKeysPath(), &keys); err != nil {
+		return nil, fmt.Errorf("failed to read api keys: %w", err)
+	}
+
+	ls.apiKeys = keys
+	return keys, nil
+}
+
+// updateAPIKeys runs fn on the API keys and saves them afterwards.
+func (ls *LocalStore) updateAPIKeys(fn func(keys map[string]localAPIKey)) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	keys, err := ls.storedAPIKeys()
+	if err != nil {
+		return err
+	}
+	fn(keys)
+
+	if err := writeJSONFile(ls.apiKeysPath(), keys); err != nil {
+		return fmt.Errorf("failed to write api keys: %w", err)
+	}
+	return nil
+}
+
+func (ls *LocalStore) SetAPIKey(ctx context.Context, key model.APIKey) error {
+	key.Scopes = slices.Clone(key.Scopes)
+	return ls.updateAPIKeys(func(keys map[string]localAPIKey) {
+		keys[key.Id] = localAPIKey{Key: key, Hash: key.Hash}
+	})
+}
+
+func (ls *LocalStore) GetAPIKey(ctx context.Context, keyId string) (*model.APIKey, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	keys, err := ls.storedAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+	stored, ok := keys[keyId]
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	key := stored.apiKey()
+	return &key, nil
+}
+
+func (ls *LocalStore) ListAPIKeys(ctx context.Context, userId string) ([]model.APIKey, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	keys, err := ls.storedAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []model.APIKey{}
+	for _, stored := range keys {
+		if stored.Key.UserId == userId {
+			results = append(results, stored.apiKey())
+		}
+	}
+	slices.SortFunc(results, func(a, b model.APIKey) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return results, nil
+}
+
+func (ls *LocalStore) TouchAPIKey(ctx context.Context, keyId string, usedAt time.Time) error {
+	return ls.updateAPIKeys(func(keys map[string]localAPIKey) {
+		if stored, ok := keys[keyId]; ok {
+			stored.Key.LastUsedAt = usedAt
+			keys[keyId] = stored
+		}
+	})
+}
+
+func (ls *LocalStore) RemoveAPIKey(ctx context.Context, keyId string) error {
+	return ls.updateAPIKeys(func(keys map[string]localAPIKey) {
+		delete(keys, keyId)
+	})
+}
+
+func (k localAPIKey) apiKey() model.APIKey {
+	key := k.Key
+	key.Hash = k.Hash
+	key.Scopes = slices.Clone(key.Scopes)
+	return key
+}
+
+func (ls *LocalStore) metaPath() string {
+	return filepath.Join(ls.dir, "meta.json")
+}
+
+// localMeta is the content of meta.json.
+type localMeta struct {
+	DataVersion int `json:"data_version"`
+}
+
+func (ls *LocalStore) GetDataVersion(ctx context.Context) (int, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	var meta localMeta
+	if err := readJSONFile(ls.metaPath(), &meta); err != nil {
+		return 0, fmt.Errorf("failed to read meta: %w", err)
+	}
+	return meta.DataVersion, nil
+}
+
+func (ls *LocalStore) SetDataVersion(ctx context.Context, version int) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if err := writeJSONFile(ls.metaPath(), localMeta{DataVersion: version}); err != nil {
+		return fmt.Errorf("failed to write meta: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	userId := "user@example.com"
+
+	store, err := NewLocalStore(dir)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	house := model.Vocab{Id: uuid.New(), Definition: "house", PartOfSpeech: model.PartOfSpeechNoun, CreatedAt: now}
+	tree := model.Vocab{Id: uuid.New(), Definition: "tree", PartOfSpeech: model.PartOfSpeechNoun, CreatedAt: now.Add(time.Hour)}
+	go_ := model.Vocab{Id: uuid.New(), Definition: "go", PartOfSpeech: model.PartOfSpeechVerb, CreatedAt: now.Add(2 * time.Hour)}
+	set := model.VocabSet{Id: uuid.New(), Name: "Nature", VocabIds: []uuid.UUID{tree.Id}}
+
+	require.NoError(t, store.WriteVocabBatch(ctx, userId, model.VocabBatch{
+		Set:   []model.Vocab{house, tree, go_},
+		Index: []model.SearchEntry{newSearchEntry(house), newSearchEntry(tree), newSearchEntry(go_)},
+	}))
+	require.NoError(t, store.SetVocabSet(ctx, userId, set))
+	require.NoError(t, store.UpdatePool(ctx, userId, &model.Pool{Vocabs: []model.Vocab{house}}))
+
+	// A new store reads the data back from disk.
+	store, err = NewLocalStore(dir)
+	require.NoError(t, err)
+
+	users, err := store.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{userId}, users)
+
+	vocab, err := store.GetVocab(ctx, userId, house.Id)
+	require.NoError(t, err)
+	require.Equal(t, house.Definition, vocab.Definition)
+
+	_, err = store.GetVocab(ctx, userId, uuid.New())
+	require.ErrorIs(t, err, ErrVocabNotFound)
+	_, err = store.GetVocabSet(ctx, userId, uuid.New())
+	require.ErrorIs(t, err, ErrSetNotFound)
+	_, err = store.FetchUserPool(ctx, "someone-else")
+	require.ErrorIs(t, err, ErrPoolNotFound)
+
+	page, err := store.QueryUserVocabulary(ctx, userId, model.VocabQuery{
+		PartOfSpeech: model.PartOfSpeechNoun,
+		SortBy:       model.VocabSortCreatedAt,
+		Descending:   true,
+		Limit:        1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []model.Vocab{tree}, page.Vocabs)
+	require.Equal(t, tree.Id.String(), page.NextCursor)
+
+	page, err = store.QueryUserVocabulary(ctx, userId, model.VocabQuery{
+		PartOfSpeech: model.PartOfSpeechNoun,
+		SortBy:       model.VocabSortCreatedAt,
+		Descending:   true,
+		Cursor:       page.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []model.Vocab{house}, page.Vocabs)
+	require.Empty(t, page.NextCursor)
+
+	page, err = store.QueryUserVocabulary(ctx, userId, model.VocabQuery{SetId: set.Id})
+	require.NoError(t, err)
+	require.Equal(t, []model.Vocab{tree}, page.Vocabs)
+
+	_, err = store.QueryUserVocabulary(ctx, userId, model.VocabQuery{Cursor: uuid.NewString()})
+	require.ErrorIs(t, err, ErrInvalidQuery)
+
+	ids, err := store.SearchVocabIds(ctx, userId, []string{"tre", "=go"}, 0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{tree.Id, go_.Id}, ids)
+
+	require.NoError(t, store.WriteVocabBatch(ctx, userId, model.VocabBatch{Delete: []uuid.UUID{tree.Id}}))
+	ids, err = store.SearchVocabIds(ctx, userId, []string{"tre"}, 0)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	require.NoError(t, store.DeleteUser(ctx, userId))
+	vocabs, err := store.FetchUserVocabulary(ctx, userId)
+	require.NoError(t, err)
+	require.Empty(t, vocabs)
+	users, err = store.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Empty(t, users)
+}
@@ -0,0 +1,272 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+)
+
+// meteredStore records the count, latency and errors of every call of the
+// wrapped store.
+type meteredStore struct {
+	next    Firestore
+	metrics *metrics.Metrics
+}
+
+func newMeteredStore(next Firestore, m *metrics.Metrics) Firestore {
+	return &meteredStore{next: next, metrics: m}
+}
+
+// observe records a call started at start; use as
+// defer s.observe("Method", time.Now(), &err). Items not found are an
+// answer of the store, not a failure.
+func (s *meteredStore) observe(method string, start time.Time, err *error) {
+	failure := *err
+	if errors.Is(failure, ErrNotFound) {
+		failure = nil
+	}
+	s.metrics.ObserveStoreCall(method, time.Since(start), failure)
+}
+
+func (s *meteredStore) FetchUserVocabulary(ctx context.Context, userId string) (result []model.Vocab, err error) {
+	defer s.observe("FetchUserVocabulary", time.Now(), &err)
+	return s.next.FetchUserVocabulary(ctx, userId)
+}
+
+func (s *meteredStore) QueryUserVocabulary(ctx context.Context, userId string, query model.VocabQuery) (result *model.VocabPage, err error) {
+	defer s.observe("QueryUserVocabulary", time.Now(), &err)
+	return s.next.QueryUserVocabulary(ctx, userId, query)
+}
+
+func (s *meteredStore) AddVocabulary(ctx context.Context, userId string, vocab model.Vocab) (err error) {
+	defer s.observe("AddVocabulary", time.Now(), &err)
+	return s.next.AddVocabulary(ctx, userId, vocab)
+}
+
+func (s *meteredStore) RemoveVocabulary(ctx context.Context, userId string, vocabId uuid.UUID) (err error) {
+	defer s.observe("RemoveVocabulary", time.Now(), &err)
+	return s.next.RemoveVocabulary(ctx, userId, vocabId)
+}
+
+func (s *meteredStore) UpdatePool(ctx context.Context, userId string, pool *model.Pool) (err error) {
+	defer s.observe("UpdatePool", time.Now(), &err)
+	return s.next.UpdatePool(ctx, userId, pool)
+}
+
+func (s *meteredStore) FetchUserPool(ctx context.Context, userId string) (result *model.Pool, err error) {
+	defer s.observe("FetchUserPool", time.Now(), &err)
+	return s.next.FetchUserPool(ctx, userId)
+}
+
+func (s *meteredStore) GetVocab(ctx context.Context, userId string, vocabId uuid.UUID) (result *model.Vocab, err error) {
+	defer s.observe("GetVocab", time.Now(), &err)
+	return s.next.GetVocab(ctx, userId, vocabId)
+}
+
+func (s *meteredStore) GetMultipleVocabs(ctx context.Context, userId string, vocabIds []uuid.UUID) (result []model.Vocab, err error) {
+	defer s.observe("GetMultipleVocabs", time.Now(), &err)
+	return s.next.GetMultipleVocabs(ctx, userId, vocabIds)
+}
+
+func (s *meteredStore) SetVocabSet(ctx context.Context, userId string, vocabSet model.VocabSet) (err error) {
+	defer s.observe("SetVocabSet", time.Now(), &err)
+	return s.next.SetVocabSet(ctx, userId, vocabSet)
+}
+
+func (s *meteredStore) GetVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) (result *model.VocabSet, err error) {
+	defer s.observe("GetVocabSet", time.Now(), &err)
+	return s.next.GetVocabSet(ctx, userId, vocabSetId)
+}
+
+func (s *meteredStore) FetchUserVocabSets(ctx context.Context, userId string) (result []model.VocabSet, err error) {
+	defer s.observe("FetchUserVocabSets", time.Now(), &err)
+	return s.next.FetchUserVocabSets(ctx, userId)
+}
+
+func (s *meteredStore) RemoveVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) (err error) {
+	defer s.observe("RemoveVocabSet", time.Now(), &err)
+	return s.next.RemoveVocabSet(ctx, userId, vocabSetId)
+}
+
+func (s *meteredStore) SetSearchEntry(ctx context.Context, userId string, entry model.SearchEntry) (err error) {
+	defer s.observe("SetSearchEntry", time.Now(), &err)
+	return s.next.SetSearchEntry(ctx, userId, entry)
+}
+
+func (s *meteredStore) RemoveSearchEntry(ctx context.Context, userId string, vocabId uuid.UUID) (err error) {
+	defer s.observe("RemoveSearchEntry", time.Now(), &err)
+	return s.next.RemoveSearchEntry(ctx, userId, vocabId)
+}
+
+func (s *meteredStore) SearchVocabIds(ctx context.Context, userId string, terms []string, limit int) (result []uuid.UUID, err error) {
+	defer s.observe("SearchVocabIds", time.Now(), &err)
+	return s.next.SearchVocabIds(ctx, userId, terms, limit)
+}
+
+func (s *meteredStore) WriteVocabBatch(ctx context.Context, userId string, batch model.VocabBatch) (err error) {
+	defer s.observe("WriteVocabBatch", time.Now(), &err)
+	return s.next.WriteVocabBatch(ctx, userId, batch)
+}
+
+func (s *meteredStore) DeleteUser(ctx context.Context, userId string) (err error) {
+	defer s.observe("DeleteUser", time.Now(), &err)
+	return s.next.DeleteUser(ctx, userId)
+}
+
+func (s *meteredStore) ListUsers(ctx context.Context) (result []string, err error) {
+	defer s.observe("ListUsers", time.Now(), &err)
+	return s.next.ListUsers(ctx)
+}
+
+func (s *meteredStore) SetPublishedSet(ctx context.Context, set model.PublishedSet) (err error) {
+	defer s.observe("SetPublishedSet", time.Now(), &err)
+	return s.next.SetPublishedSet(ctx, set)
+}
+
+func (s *meteredStore) GetPublishedSet(ctx context.Context, id string) (result *model.PublishedSet, err error) {
+	defer s.observe("GetPublishedSet", time.Now(), &err)
+	return s.next.GetPublishedSet(ctx, id)
+}
+
+func (s *meteredStore) ListPublishedSets(ctx context.Context, ownerId string, limit int) (result []model.PublishedSet, err error) {
+	defer s.observe("ListPublishedSets", time.Now(), &err)
+	return s.next.ListPublishedSets(ctx, ownerId, limit)
+}
+
+func (s *meteredStore) RemovePublishedSet(ctx context.Context, id string) (err error) {
+	defer s.observe("RemovePublishedSet", time.Now(), &err)
+	return s.next.RemovePublishedSet(ctx, id)
+}
+
+func (s *meteredStore) SetClass(ctx context.Context, class model.Class) (err error) {
+	defer s.observe("SetClass", time.Now(), &err)
+	return s.next.SetClass(ctx, class)
+}
+
+func (s *meteredStore) SetClassInviteCode(ctx context.Context, classId uuid.UUID, code string) (err error) {
+	defer s.observe("SetClassInviteCode", time.Now(), &err)
+	return s.next.SetClassInviteCode(ctx, classId, code)
+}
+
+func (s *meteredStore) AddClassStudent(ctx context.Context, classId uuid.UUID, studentId string) (err error) {
+	defer s.observe("AddClassStudent", time.Now(), &err)
+	return s.next.AddClassStudent(ctx, classId, studentId)
+}
+
+func (s *meteredStore) RemoveClassStudent(ctx context.Context, classId uuid.UUID, studentId string) (err error) {
+	defer s.observe("RemoveClassStudent", time.Now(), &err)
+	return s.next.RemoveClassStudent(ctx, classId, studentId)
+}
+
+func (s *meteredStore) GetClass(ctx context.Context, classId uuid.UUID) (result *model.Class, err error) {
+	defer s.observe("GetClass", time.Now(), &err)
+	return s.next.GetClass(ctx, classId)
+}
+
+func (s *meteredStore) FindClassByInviteCode(ctx context.Context, code string) (result *model.Class, err error) {
+	defer s.observe("FindClassByInviteCode", time.Now(), &err)
+	return s.next.FindClassByInviteCode(ctx, code)
+}
+
+func (s *meteredStore) ListUserClasses(ctx context.Context, userId string) (result []model.Class, err error) {
+	defer s.observe("ListUserClasses", time.Now(), &err)
+	return s.next.ListUserClasses(ctx, userId)
+}
+
+func (s *meteredStore) RemoveClass(ctx context.Context, classId uuid.UUID) (err error) {
+	defer s.observe("RemoveClass", time.Now(), &err)
+	return s.next.RemoveClass(ctx, classId)
+}
+
+func (s *meteredStore) SetAssignment(ctx context.Context, assignment model.Assignment) (err error) {
+	defer s.observe("SetAssignment", time.Now(), &err)
+	return s.next.SetAssignment(ctx, assignment)
+}
+
+func (s *meteredStore) ListAssignments(ctx context.Context, classId uuid.UUID) (result []model.Assignment, err error) {
+	defer s.observe("ListAssignments", time.Now(), &err)
+	return s.next.ListAssignments(ctx, classId)
+}
+
+func (s *meteredStore) GetAssignmentVocabs(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) (result []model.Vocab, err error) {
+	defer s.observe("GetAssignmentVocabs", time.Now(), &err)
+	return s.next.GetAssignmentVocabs(ctx, classId, assignmentId)
+}
+
+func (s *meteredStore) SetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string, copied model.AssignmentCopy,
+) (err error) {
+	defer s.observe("SetAssignmentCopy", time.Now(), &err)
+	return s.next.SetAssignmentCopy(ctx, classId, assignmentId, studentId, copied)
+}
+
+func (s *meteredStore) GetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string,
+) (result *model.AssignmentCopy, err error) {
+	defer s.observe("GetAssignmentCopy", time.Now(), &err)
+	return s.next.GetAssignmentCopy(ctx, classId, assignmentId, studentId)
+}
+
+func (s *meteredStore) ListAssignmentCopies(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) (result map[string]model.AssignmentCopy, err error) {
+	defer s.observe("ListAssignmentCopies", time.Now(), &err)
+	return s.next.ListAssignmentCopies(ctx, classId, assignmentId)
+}
+
+func (s *meteredStore) RemoveAssignment(ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID) (err error) {
+	defer s.observe("RemoveAssignment", time.Now(), &err)
+	return s.next.RemoveAssignment(ctx, classId, assignmentId)
+}
+
+func (s *meteredStore) GetUserRole(ctx context.Context, userId string) (result model.Role, err error) {
+	defer s.observe("GetUserRole", time.Now(), &err)
+	return s.next.GetUserRole(ctx, userId)
+}
+
+func (s *meteredStore) SetUserRole(ctx context.Context, userId string, role model.Role) (err error) {
+	defer s.observe("SetUserRole", time.Now(), &err)
+	return s.next.SetUserRole(ctx, userId, role)
+}
+
+func (s *meteredStore) SetAPIKey(ctx context.Context, key model.APIKey) (err error) {
+	defer s.observe("SetAPIKey", time.Now(), &err)
+	return s.next.SetAPIKey(ctx, key)
+}
+
+func (s *meteredStore) GetAPIKey(ctx context.Context, keyId string) (result *model.APIKey, err error) {
+	defer s.observe("GetAPIKey", time.Now(), &err)
+	return s.next.GetAPIKey(ctx, keyId)
+}
+
+func (s *meteredStore) ListAPIKeys(ctx context.Context, userId string) (result []model.APIKey, err error) {
+	defer s.observe("ListAPIKeys", time.Now(), &err)
+	return s.next.ListAPIKeys(ctx, userId)
+}
+
+func (s *meteredStore) TouchAPIKey(ctx context.Context, keyId string, usedAt time.Time) (err error) {
+	defer s.observe("TouchAPIKey", time.Now(), &err)
+	return s.next.TouchAPIKey(ctx, keyId, usedAt)
+}
+
+func (s *meteredStore) RemoveAPIKey(ctx context.Context, keyId string) (err error) {
+	defer s.observe("RemoveAPIKey", time.Now(), &err)
+	return s.next.RemoveAPIKey(ctx, keyId)
+}
+
+func (s *meteredStore) GetDataVersion(ctx context.Context) (result int, err error) {
+	defer s.observe("GetDataVersion", time.Now(), &err)
+	return s.next.GetDataVersion(ctx)
+}
+
+func (s *meteredStore) SetDataVersion(ctx context.Context, version int) (err error) {
+	defer s.observe("SetDataVersion", time.Now(), &err)
+	return s.next.SetDataVersion(ctx, version)
+}
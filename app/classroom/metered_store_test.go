@@ -0,0 +1,62 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+)
+
+// scrapeMetrics returns the metrics in the Prometheus text format.
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+func TestMeteredStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userId := "test-user"
+	found, missing, failing := uuid.New(), uuid.New(), uuid.New()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, found).Return(&model.Vocab{Id: found}, nil)
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, missing).Return(nil, ErrVocabNotFound)
+	mockStore.EXPECT().GetVocab(gomock.Any(), userId, failing).Return(nil, errors.New("test error"))
+	mockStore.EXPECT().SetVocabSet(gomock.Any(), userId, gomock.Any()).Return(nil)
+
+	m := metrics.New()
+	store := newMeteredStore(mockStore, m)
+
+	vocab, err := store.GetVocab(ctx, userId, found)
+	require.NoError(t, err)
+	require.Equal(t, found, vocab.Id)
+	_, err = store.GetVocab(ctx, userId, missing)
+	require.ErrorIs(t, err, ErrVocabNotFound)
+	_, err = store.GetVocab(ctx, userId, failing)
+	require.EqualError(t, err, "test error")
+	require.NoError(t, store.SetVocabSet(ctx, userId, model.VocabSet{Id: uuid.New()}))
+
+	scraped := scrapeMetrics(t, m)
+	require.Contains(t, scraped, `danish_store_calls_total{method="GetVocab"} 3`)
+	require.Contains(t, scraped, `danish_store_call_duration_seconds_count{method="GetVocab"} 3`)
+	// Vocab not found is an answer, only the failing call is an error.
+	require.Contains(t, scraped, `danish_store_errors_total{method="GetVocab"} 1`)
+	require.Contains(t, scraped, `danish_store_calls_total{method="SetVocabSet"} 1`)
+	require.NotContains(t, scraped, `danish_store_errors_total{method="SetVocabSet"}`)
+}
@@ -5,9 +5,15 @@ import "go.uber.org/fx"
 var Module = fx.Module(
 	"classroom",
 	fx.Provide(
-		NewFirebaseStore,
+		NewStore,
 		NewDictionary,
 		NewWordPool,
 		NewSetService,
+		NewAccountService,
+		NewAdminService,
+		NewPublishService,
+		NewClassService,
+		NewRoleService,
+		NewAPIKeyService,
 	),
 )
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	model "github.com/vladazn/danish/app/model"
@@ -42,6 +43,20 @@ func (m *MockFirestore) EXPECT() *MockFirestoreMockRecorder {
 	return m.recorder
 }
 
+// AddClassStudent mocks base method.
+func (m *MockFirestore) AddClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClassStudent", ctx, classId, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddClassStudent indicates an expected call of AddClassStudent.
+func (mr *MockFirestoreMockRecorder) AddClassStudent(ctx, classId, studentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClassStudent", reflect.TypeOf((*MockFirestore)(nil).AddClassStudent), ctx, classId, studentId)
+}
+
 // AddVocabulary mocks base method.
 func (m *MockFirestore) AddVocabulary(ctx context.Context, userId string, vocab model.Vocab) error {
 	m.ctrl.T.Helper()
@@ -56,6 +71,20 @@ func (mr *MockFirestoreMockRecorder) AddVocabulary(ctx, userId, vocab any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVocabulary", reflect.TypeOf((*MockFirestore)(nil).AddVocabulary), ctx, userId, vocab)
 }
 
+// DeleteUser mocks base method.
+func (m *MockFirestore) DeleteUser(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockFirestoreMockRecorder) DeleteUser(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockFirestore)(nil).DeleteUser), ctx, userId)
+}
+
 // FetchUserPool mocks base method.
 func (m *MockFirestore) FetchUserPool(ctx context.Context, userId string) (*model.Pool, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +130,96 @@ func (mr *MockFirestoreMockRecorder) FetchUserVocabulary(ctx, userId any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserVocabulary", reflect.TypeOf((*MockFirestore)(nil).FetchUserVocabulary), ctx, userId)
 }
 
+// FindClassByInviteCode mocks base method.
+func (m *MockFirestore) FindClassByInviteCode(ctx context.Context, code string) (*model.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClassByInviteCode", ctx, code)
+	ret0, _ := ret[0].(*model.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClassByInviteCode indicates an expected call of FindClassByInviteCode.
+func (mr *MockFirestoreMockRecorder) FindClassByInviteCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClassByInviteCode", reflect.TypeOf((*MockFirestore)(nil).FindClassByInviteCode), ctx, code)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockFirestore) GetAPIKey(ctx context.Context, keyId string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, keyId)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockFirestoreMockRecorder) GetAPIKey(ctx, keyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockFirestore)(nil).GetAPIKey), ctx, keyId)
+}
+
+// GetAssignmentCopy mocks base method.
+func (m *MockFirestore) GetAssignmentCopy(ctx context.Context, classId, assignmentId uuid.UUID, studentId string) (*model.AssignmentCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentCopy", ctx, classId, assignmentId, studentId)
+	ret0, _ := ret[0].(*model.AssignmentCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentCopy indicates an expected call of GetAssignmentCopy.
+func (mr *MockFirestoreMockRecorder) GetAssignmentCopy(ctx, classId, assignmentId, studentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentCopy", reflect.TypeOf((*MockFirestore)(nil).GetAssignmentCopy), ctx, classId, assignmentId, studentId)
+}
+
+// GetAssignmentVocabs mocks base method.
+func (m *MockFirestore) GetAssignmentVocabs(ctx context.Context, classId, assignmentId uuid.UUID) ([]model.Vocab, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentVocabs", ctx, classId, assignmentId)
+	ret0, _ := ret[0].([]model.Vocab)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentVocabs indicates an expected call of GetAssignmentVocabs.
+func (mr *MockFirestoreMockRecorder) GetAssignmentVocabs(ctx, classId, assignmentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentVocabs", reflect.TypeOf((*MockFirestore)(nil).GetAssignmentVocabs), ctx, classId, assignmentId)
+}
+
+// GetClass mocks base method.
+func (m *MockFirestore) GetClass(ctx context.Context, classId uuid.UUID) (*model.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClass", ctx, classId)
+	ret0, _ := ret[0].(*model.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClass indicates an expected call of GetClass.
+func (mr *MockFirestoreMockRecorder) GetClass(ctx, classId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClass", reflect.TypeOf((*MockFirestore)(nil).GetClass), ctx, classId)
+}
+
+// GetDataVersion mocks base method.
+func (m *MockFirestore) GetDataVersion(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataVersion", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataVersion indicates an expected call of GetDataVersion.
+func (mr *MockFirestoreMockRecorder) GetDataVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataVersion", reflect.TypeOf((*MockFirestore)(nil).GetDataVersion), ctx)
+}
+
 // GetMultipleVocabs mocks base method.
 func (m *MockFirestore) GetMultipleVocabs(ctx context.Context, userId string, vocabIds []uuid.UUID) ([]model.Vocab, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +235,36 @@ func (mr *MockFirestoreMockRecorder) GetMultipleVocabs(ctx, userId, vocabIds any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleVocabs", reflect.TypeOf((*MockFirestore)(nil).GetMultipleVocabs), ctx, userId, vocabIds)
 }
 
+// GetPublishedSet mocks base method.
+func (m *MockFirestore) GetPublishedSet(ctx context.Context, id string) (*model.PublishedSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedSet", ctx, id)
+	ret0, _ := ret[0].(*model.PublishedSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedSet indicates an expected call of GetPublishedSet.
+func (mr *MockFirestoreMockRecorder) GetPublishedSet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedSet", reflect.TypeOf((*MockFirestore)(nil).GetPublishedSet), ctx, id)
+}
+
+// GetUserRole mocks base method.
+func (m *MockFirestore) GetUserRole(ctx context.Context, userId string) (model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRole", ctx, userId)
+	ret0, _ := ret[0].(model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRole indicates an expected call of GetUserRole.
+func (mr *MockFirestoreMockRecorder) GetUserRole(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRole", reflect.TypeOf((*MockFirestore)(nil).GetUserRole), ctx, userId)
+}
+
 // GetVocab mocks base method.
 func (m *MockFirestore) GetVocab(ctx context.Context, userId string, vocabId uuid.UUID) (*model.Vocab, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +295,195 @@ func (mr *MockFirestoreMockRecorder) GetVocabSet(ctx, userId, vocabSetId any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVocabSet", reflect.TypeOf((*MockFirestore)(nil).GetVocabSet), ctx, userId, vocabSetId)
 }
 
+// ListAPIKeys mocks base method.
+func (m *MockFirestore) ListAPIKeys(ctx context.Context, userId string) ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, userId)
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockFirestoreMockRecorder) ListAPIKeys(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockFirestore)(nil).ListAPIKeys), ctx, userId)
+}
+
+// ListAssignmentCopies mocks base method.
+func (m *MockFirestore) ListAssignmentCopies(ctx context.Context, classId, assignmentId uuid.UUID) (map[string]model.AssignmentCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignmentCopies", ctx, classId, assignmentId)
+	ret0, _ := ret[0].(map[string]model.AssignmentCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignmentCopies indicates an expected call of ListAssignmentCopies.
+func (mr *MockFirestoreMockRecorder) ListAssignmentCopies(ctx, classId, assignmentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentCopies", reflect.TypeOf((*MockFirestore)(nil).ListAssignmentCopies), ctx, classId, assignmentId)
+}
+
+// ListAssignments mocks base method.
+func (m *MockFirestore) ListAssignments(ctx context.Context, classId uuid.UUID) ([]model.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", ctx, classId)
+	ret0, _ := ret[0].([]model.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *MockFirestoreMockRecorder) ListAssignments(ctx, classId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*MockFirestore)(nil).ListAssignments), ctx, classId)
+}
+
+// ListPublishedSets mocks base method.
+func (m *MockFirestore) ListPublishedSets(ctx context.Context, ownerId string, limit int) ([]model.PublishedSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublishedSets", ctx, ownerId, limit)
+	ret0, _ := ret[0].([]model.PublishedSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublishedSets indicates an expected call of ListPublishedSets.
+func (mr *MockFirestoreMockRecorder) ListPublishedSets(ctx, ownerId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublishedSets", reflect.TypeOf((*MockFirestore)(nil).ListPublishedSets), ctx, ownerId, limit)
+}
+
+// ListUserClasses mocks base method.
+func (m *MockFirestore) ListUserClasses(ctx context.Context, userId string) ([]model.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserClasses", ctx, userId)
+	ret0, _ := ret[0].([]model.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserClasses indicates an expected call of ListUserClasses.
+func (mr *MockFirestoreMockRecorder) ListUserClasses(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserClasses", reflect.TypeOf((*MockFirestore)(nil).ListUserClasses), ctx, userId)
+}
+
+// ListUsers mocks base method.
+func (m *MockFirestore) ListUsers(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockFirestoreMockRecorder) ListUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockFirestore)(nil).ListUsers), ctx)
+}
+
+// QueryUserVocabulary mocks base method.
+func (m *MockFirestore) QueryUserVocabulary(ctx context.Context, userId string, query model.VocabQuery) (*model.VocabPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryUserVocabulary", ctx, userId, query)
+	ret0, _ := ret[0].(*model.VocabPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryUserVocabulary indicates an expected call of QueryUserVocabulary.
+func (mr *MockFirestoreMockRecorder) QueryUserVocabulary(ctx, userId, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryUserVocabulary", reflect.TypeOf((*MockFirestore)(nil).QueryUserVocabulary), ctx, userId, query)
+}
+
+// RemoveAPIKey mocks base method.
+func (m *MockFirestore) RemoveAPIKey(ctx context.Context, keyId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAPIKey", ctx, keyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAPIKey indicates an expected call of RemoveAPIKey.
+func (mr *MockFirestoreMockRecorder) RemoveAPIKey(ctx, keyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAPIKey", reflect.TypeOf((*MockFirestore)(nil).RemoveAPIKey), ctx, keyId)
+}
+
+// RemoveAssignment mocks base method.
+func (m *MockFirestore) RemoveAssignment(ctx context.Context, classId, assignmentId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignment", ctx, classId, assignmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAssignment indicates an expected call of RemoveAssignment.
+func (mr *MockFirestoreMockRecorder) RemoveAssignment(ctx, classId, assignmentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignment", reflect.TypeOf((*MockFirestore)(nil).RemoveAssignment), ctx, classId, assignmentId)
+}
+
+// RemoveClass mocks base method.
+func (m *MockFirestore) RemoveClass(ctx context.Context, classId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveClass", ctx, classId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveClass indicates an expected call of RemoveClass.
+func (mr *MockFirestoreMockRecorder) RemoveClass(ctx, classId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveClass", reflect.TypeOf((*MockFirestore)(nil).RemoveClass), ctx, classId)
+}
+
+// RemoveClassStudent mocks base method.
+func (m *MockFirestore) RemoveClassStudent(ctx context.Context, classId uuid.UUID, studentId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveClassStudent", ctx, classId, studentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveClassStudent indicates an expected call of RemoveClassStudent.
+func (mr *MockFirestoreMockRecorder) RemoveClassStudent(ctx, classId, studentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveClassStudent", reflect.TypeOf((*MockFirestore)(nil).RemoveClassStudent), ctx, classId, studentId)
+}
+
+// RemovePublishedSet mocks base method.
+func (m *MockFirestore) RemovePublishedSet(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePublishedSet", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePublishedSet indicates an expected call of RemovePublishedSet.
+func (mr *MockFirestoreMockRecorder) RemovePublishedSet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePublishedSet", reflect.TypeOf((*MockFirestore)(nil).RemovePublishedSet), ctx, id)
+}
+
+// RemoveSearchEntry mocks base method.
+func (m *MockFirestore) RemoveSearchEntry(ctx context.Context, userId string, vocabId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSearchEntry", ctx, userId, vocabId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSearchEntry indicates an expected call of RemoveSearchEntry.
+func (mr *MockFirestoreMockRecorder) RemoveSearchEntry(ctx, userId, vocabId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSearchEntry", reflect.TypeOf((*MockFirestore)(nil).RemoveSearchEntry), ctx, userId, vocabId)
+}
+
 // RemoveVocabSet mocks base method.
 func (m *MockFirestore) RemoveVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -174,6 +512,147 @@ func (mr *MockFirestoreMockRecorder) RemoveVocabulary(ctx, userId, vocabId any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVocabulary", reflect.TypeOf((*MockFirestore)(nil).RemoveVocabulary), ctx, userId, vocabId)
 }
 
+// SearchVocabIds mocks base method.
+func (m *MockFirestore) SearchVocabIds(ctx context.Context, userId string, terms []string, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchVocabIds", ctx, userId, terms, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchVocabIds indicates an expected call of SearchVocabIds.
+func (mr *MockFirestoreMockRecorder) SearchVocabIds(ctx, userId, terms, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchVocabIds", reflect.TypeOf((*MockFirestore)(nil).SearchVocabIds), ctx, userId, terms, limit)
+}
+
+// SetAPIKey mocks base method.
+func (m *MockFirestore) SetAPIKey(ctx context.Context, key model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAPIKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAPIKey indicates an expected call of SetAPIKey.
+func (mr *MockFirestoreMockRecorder) SetAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIKey", reflect.TypeOf((*MockFirestore)(nil).SetAPIKey), ctx, key)
+}
+
+// SetAssignment mocks base method.
+func (m *MockFirestore) SetAssignment(ctx context.Context, assignment model.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAssignment", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAssignment indicates an expected call of SetAssignment.
+func (mr *MockFirestoreMockRecorder) SetAssignment(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssignment", reflect.TypeOf((*MockFirestore)(nil).SetAssignment), ctx, assignment)
+}
+
+// SetAssignmentCopy mocks base method.
+func (m *MockFirestore) SetAssignmentCopy(ctx context.Context, classId, assignmentId uuid.UUID, studentId string, copied model.AssignmentCopy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAssignmentCopy", ctx, classId, assignmentId, studentId, copied)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAssignmentCopy indicates an expected call of SetAssignmentCopy.
+func (mr *MockFirestoreMockRecorder) SetAssignmentCopy(ctx, classId, assignmentId, studentId, copied any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssignmentCopy", reflect.TypeOf((*MockFirestore)(nil).SetAssignmentCopy), ctx, classId, assignmentId, studentId, copied)
+}
+
+// SetClass mocks base method.
+func (m *MockFirestore) SetClass(ctx context.Context, class model.Class) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClass", ctx, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClass indicates an expected call of SetClass.
+func (mr *MockFirestoreMockRecorder) SetClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClass", reflect.TypeOf((*MockFirestore)(nil).SetClass), ctx, class)
+}
+
+// SetClassInviteCode mocks base method.
+func (m *MockFirestore) SetClassInviteCode(ctx context.Context, classId uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClassInviteCode", ctx, classId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClassInviteCode indicates an expected call of SetClassInviteCode.
+func (mr *MockFirestoreMockRecorder) SetClassInviteCode(ctx, classId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClassInviteCode", reflect.TypeOf((*MockFirestore)(nil).SetClassInviteCode), ctx, classId, code)
+}
+
+// SetDataVersion mocks base method.
+func (m *MockFirestore) SetDataVersion(ctx context.Context, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDataVersion", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDataVersion indicates an expected call of SetDataVersion.
+func (mr *MockFirestoreMockRecorder) SetDataVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDataVersion", reflect.TypeOf((*MockFirestore)(nil).SetDataVersion), ctx, version)
+}
+
+// SetPublishedSet mocks base method.
+func (m *MockFirestore) SetPublishedSet(ctx context.Context, set model.PublishedSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPublishedSet", ctx, set)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPublishedSet indicates an expected call of SetPublishedSet.
+func (mr *MockFirestoreMockRecorder) SetPublishedSet(ctx, set any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublishedSet", reflect.TypeOf((*MockFirestore)(nil).SetPublishedSet), ctx, set)
+}
+
+// SetSearchEntry mocks base method.
+func (m *MockFirestore) SetSearchEntry(ctx context.Context, userId string, entry model.SearchEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSearchEntry", ctx, userId, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSearchEntry indicates an expected call of SetSearchEntry.
+func (mr *MockFirestoreMockRecorder) SetSearchEntry(ctx, userId, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSearchEntry", reflect.TypeOf((*MockFirestore)(nil).SetSearchEntry), ctx, userId, entry)
+}
+
+// SetUserRole mocks base method.
+func (m *MockFirestore) SetUserRole(ctx context.Context, userId string, role model.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockFirestoreMockRecorder) SetUserRole(ctx, userId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockFirestore)(nil).SetUserRole), ctx, userId, role)
+}
+
 // SetVocabSet mocks base method.
 func (m *MockFirestore) SetVocabSet(ctx context.Context, userId string, vocabSet model.VocabSet) error {
 	m.ctrl.T.Helper()
@@ -188,6 +667,20 @@ func (mr *MockFirestoreMockRecorder) SetVocabSet(ctx, userId, vocabSet any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVocabSet", reflect.TypeOf((*MockFirestore)(nil).SetVocabSet), ctx, userId, vocabSet)
 }
 
+// TouchAPIKey mocks base method.
+func (m *MockFirestore) TouchAPIKey(ctx context.Context, keyId string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, keyId, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockFirestoreMockRecorder) TouchAPIKey(ctx, keyId, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockFirestore)(nil).TouchAPIKey), ctx, keyId, usedAt)
+}
+
 // UpdatePool mocks base method.
 func (m *MockFirestore) UpdatePool(ctx context.Context, userId string, pool *model.Pool) error {
 	m.ctrl.T.Helper()
@@ -201,3 +694,17 @@ func (mr *MockFirestoreMockRecorder) UpdatePool(ctx, userId, pool any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePool", reflect.TypeOf((*MockFirestore)(nil).UpdatePool), ctx, userId, pool)
 }
+
+// WriteVocabBatch mocks base method.
+func (m *MockFirestore) WriteVocabBatch(ctx context.Context, userId string, batch model.VocabBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteVocabBatch", ctx, userId, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteVocabBatch indicates an expected call of WriteVocabBatch.
+func (mr *MockFirestoreMockRecorder) WriteVocabBatch(ctx, userId, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteVocabBatch", reflect.TypeOf((*MockFirestore)(nil).WriteVocabBatch), ctx, userId, batch)
+}
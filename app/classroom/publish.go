@@ -0,0 +1,249 @@
+package classroom
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+var (
+	ErrPublishedSetNotFound = newError(ErrNotFound, "published_set_not_found", "published set not found")
+	ErrNotSetOwner          = newError(ErrForbidden, "not_set_owner", "published set belongs to another user")
+)
+
+// MaxBrowseLimit is the most published sets a listing returns.
+const MaxBrowseLimit = 100
+
+// PublishService shares vocab sets between users. Publishing stores a
+// snapshot of the set's vocab, so later edits of the owner are only
+// visible after publishing again, and cloning goes through the import so
+// words the user already has are merged rather than duplicated.
+type PublishService struct {
+	storage Firestore
+	dict    *Dictionary
+}
+
+type NewPublishServiceParams struct {
+	fx.In
+	Store Firestore
+	Dict  *Dictionary
+}
+
+func NewPublishService(p NewPublishServiceParams) *PublishService {
+	return &PublishService{
+		storage: p.Store,
+		dict:    p.Dict,
+	}
+}
+
+// newShareId returns a random id that is short enough for a link.
+func newShareId() (string, error) {
+	b := make([]byte, 9)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// snapshot copies the content of a vocab without any progress.
+func snapshot(vocab model.Vocab) model.Vocab {
+	forms := make([]model.VocabForm, len(vocab.Forms))
+	for i, form := range vocab.Forms {
+		forms[i] = model.VocabForm{Id: form.Id, Value: form.Value, Form: form.Form}
+	}
+
+	return model.Vocab{
+		Id:           vocab.Id,
+		Definition:   vocab.Definition,
+		PartOfSpeech: vocab.PartOfSpeech,
+		Forms:        forms,
+	}
+}
+
+// Publish stores a snapshot of the user's set. Publishing a set again
+// refreshes its snapshot and keeps the share id.
+func (ps *PublishService) Publish(ctx context.Context, userId string, setId uuid.UUID) (*model.PublishedSet, error) {
+	ctx, span := tracer.Start(ctx, "PublishService.Publish")
+	defer span.End()
+
+	vocabSet, err := ps.storage.GetVocabSet(ctx, userId, setId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocab set: %w", err)
+	}
+
+	vocabs, err := ps.storage.GetMultipleVocabs(ctx, userId, vocabSet.VocabIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocab items for set: %w", err)
+	}
+	if len(vocabs) == 0 {
+		return nil, fmt.Errorf("%w: the set has no vocab", ErrInvalidVocab)
+	}
+	if len(vocabs) > MaxImportSize {
+		return nil, fmt.Errorf("%w: at most %d vocab per published set", ErrInvalidVocab, MaxImportSize)
+	}
+
+	own, err := ps.storage.ListPublishedSets(ctx, userId, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published sets: %w", err)
+	}
+
+	published := model.PublishedSet{
+		OwnerId:     userId,
+		SourceSetId: setId,
+		Name:        vocabSet.Name,
+		VocabCount:  len(vocabs),
+		Vocabs:      make([]model.Vocab, len(vocabs)),
+		PublishedAt: time.Now(),
+	}
+	for i, vocab := range vocabs {
+		published.Vocabs[i] = snapshot(vocab)
+	}
+
+	if i := slices.IndexFunc(own, func(s model.PublishedSet) bool { return s.SourceSetId == setId }); i >= 0 {
+		published.Id = own[i].Id
+	} else if published.Id, err = newShareId(); err != nil {
+		return nil, fmt.Errorf("failed to create share id: %w", err)
+	}
+
+	if err := ps.storage.SetPublishedSet(ctx, published); err != nil {
+		return nil, fmt.Errorf("failed to publish set: %w", err)
+	}
+
+	return &published, nil
+}
+
+// Unpublish removes a published set of the user. Clones already made are
+// not affected.
+func (ps *PublishService) Unpublish(ctx context.Context, userId string, shareId string) error {
+	ctx, span := tracer.Start(ctx, "PublishService.Unpublish")
+	defer span.End()
+
+	published, err := ps.storage.GetPublishedSet(ctx, shareId)
+	if err != nil {
+		return fmt.Errorf("failed to get published set: %w", err)
+	}
+	if published.OwnerId != userId {
+		return ErrNotSetOwner
+	}
+
+	if err := ps.storage.RemovePublishedSet(ctx, shareId); err != nil {
+		return fmt.Errorf("failed to unpublish set: %w", err)
+	}
+
+	return nil
+}
+
+// Browse lists the newest published sets without their vocab. Owners are
+// not revealed to other users.
+func (ps *PublishService) Browse(ctx context.Context, userId string, limit int) ([]model.PublishedSet, error) {
+	ctx, span := tracer.Start(ctx, "PublishService.Browse")
+	defer span.End()
+
+	if limit <= 0 || limit > MaxBrowseLimit {
+		limit = MaxBrowseLimit
+	}
+
+	sets, err := ps.storage.ListPublishedSets(ctx, "", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published sets: %w", err)
+	}
+	for i := range sets {
+		hideOwner(&sets[i], userId)
+	}
+
+	return sets, nil
+}
+
+// ListOwn lists the sets the user has published.
+func (ps *PublishService) ListOwn(ctx context.Context, userId string) ([]model.PublishedSet, error) {
+	ctx, span := tracer.Start(ctx, "PublishService.ListOwn")
+	defer span.End()
+
+	sets, err := ps.storage.ListPublishedSets(ctx, userId, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published sets: %w", err)
+	}
+	return sets, nil
+}
+
+// Preview returns a published set with its vocab.
+func (ps *PublishService) Preview(ctx context.Context, userId string, shareId string) (*model.PublishedSet, error) {
+	ctx, span := tracer.Start(ctx, "PublishService.Preview")
+	defer span.End()
+
+	published, err := ps.storage.GetPublishedSet(ctx, shareId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get published set: %w", err)
+	}
+	hideOwner(published, userId)
+
+	return published, nil
+}
+
+func hideOwner(published *model.PublishedSet, userId string) {
+	if published.OwnerId != userId {
+		published.OwnerId = ""
+		published.SourceSetId = uuid.Nil
+	}
+}
+
+// Clone copies a published set into the user's library as a new set named
+// name, or after the published set if name is empty. Words the user
+// already has are merged, adding the forms they are missing and keeping
+// their progress.
+func (ps *PublishService) Clone(
+	ctx context.Context, userId string, shareId string, name string,
+) (*model.CloneResult, error) {
+	ctx, span := tracer.Start(ctx, "PublishService.Clone")
+	defer span.End()
+
+	published, err := ps.storage.GetPublishedSet(ctx, shareId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get published set: %w", err)
+	}
+
+	results, err := ps.dict.ImportWords(userid.ToCtx(ctx, userId), published.Vocabs, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy vocab: %w", err)
+	}
+
+	result := &model.CloneResult{
+		Set: model.VocabSet{
+			Id:       uuid.New(),
+			Name:     published.Name,
+			VocabIds: []uuid.UUID{},
+		},
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		result.Set.Name = name
+	}
+	for _, r := range results {
+		switch r.Status {
+		case model.ImportStatusCreated:
+			result.Created++
+		case model.ImportStatusMerged:
+			result.Merged++
+		default:
+			continue
+		}
+		if !slices.Contains(result.Set.VocabIds, r.Id) {
+			result.Set.VocabIds = append(result.Set.VocabIds, r.Id)
+		}
+	}
+
+	if err := ps.storage.SetVocabSet(ctx, userId, result.Set); err != nil {
+		return nil, fmt.Errorf("failed to add vocab set: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+func TestPublishService(t *testing.T) {
+	ctx := context.Background()
+	owner, reader := "owner", "reader"
+
+	store, dict := newLocalDictionary(t)
+	service := &PublishService{storage: store, dict: dict}
+
+	lastSuccess := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	house := model.Vocab{
+		Definition:   "house",
+		PartOfSpeech: model.PartOfSpeechNoun,
+		Forms: []model.VocabForm{
+			{Value: "hus", Form: "indefinite_singular", Level: 4, LastSuccess: lastSuccess, SuccessInRow: 3},
+			{Value: "huset", Form: "definite_singular", Level: 2, LastSuccess: lastSuccess},
+		},
+	}
+	tree := model.Vocab{Definition: "tree", Forms: []model.VocabForm{{Value: "træ", Form: "indefinite_singular"}}}
+
+	set := addSet(t, dict, owner, "Home", house, tree)
+
+	_, err := service.Publish(ctx, owner, uuid.New())
+	require.ErrorIs(t, err, ErrSetNotFound)
+
+	published, err := service.Publish(ctx, owner, set.Id)
+	require.NoError(t, err)
+	require.NotEmpty(t, published.Id)
+	require.Equal(t, 2, published.VocabCount)
+	for _, vocab := range published.Vocabs {
+		for _, form := range vocab.Forms {
+			require.Zero(t, form.Level)
+			require.Zero(t, form.SuccessInRow)
+			require.True(t, form.LastSuccess.IsZero())
+		}
+	}
+
+	// Publishing again keeps the share id.
+	again, err := service.Publish(ctx, owner, set.Id)
+	require.NoError(t, err)
+	require.Equal(t, published.Id, again.Id)
+
+	browsed, err := service.Browse(ctx, reader, 0)
+	require.NoError(t, err)
+	require.Len(t, browsed, 1)
+	require.Empty(t, browsed[0].OwnerId)
+	require.Empty(t, browsed[0].Vocabs)
+
+	preview, err := service.Preview(ctx, reader, published.Id)
+	require.NoError(t, err)
+	require.Len(t, preview.Vocabs, 2)
+
+	// The reader already knows "house" with some progress.
+	known := model.Vocab{
+		Definition: "house",
+		Forms:      []model.VocabForm{{Value: "hus", Form: "indefinite_singular", Level: 1, LastSuccess: lastSuccess}},
+	}
+	_, err = dict.ImportWords(userid.ToCtx(ctx, reader), []model.Vocab{known}, false)
+	require.NoError(t, err)
+
+	cloned, err := service.Clone(ctx, reader, published.Id, "")
+	require.NoError(t, err)
+	require.Equal(t, "Home", cloned.Set.Name)
+	require.Equal(t, 1, cloned.Created)
+	require.Equal(t, 1, cloned.Merged)
+	require.Len(t, cloned.Set.VocabIds, 2)
+
+	vocabs, err := store.FetchUserVocabulary(ctx, reader)
+	require.NoError(t, err)
+	require.Len(t, vocabs, 2)
+	for _, vocab := range vocabs {
+		if vocab.Definition == "house" {
+			// The missing form is added, the known one keeps its progress.
+			require.Len(t, vocab.Forms, 2)
+			require.Equal(t, 1, vocab.Forms[0].Level)
+		}
+	}
+
+	_, err = service.Clone(ctx, reader, "missing", "")
+	require.ErrorIs(t, err, ErrPublishedSetNotFound)
+
+	require.ErrorIs(t, service.Unpublish(ctx, reader, published.Id), ErrNotSetOwner)
+	require.NoError(t, service.Unpublish(ctx, owner, published.Id))
+	_, err = service.Preview(ctx, reader, published.Id)
+	require.ErrorIs(t, err, ErrPublishedSetNotFound)
+}
@@ -0,0 +1,56 @@
+package classroom
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+// pageVocabs answers q from vocabs already held in memory: it sorts them,
+// skips to the cursor and fills one page with the vocabs matching the
+// filters. vocabs must be in id order, which is the order without a sort.
+func pageVocabs(vocabs []model.Vocab, q model.VocabQuery, inSet map[uuid.UUID]bool) (*model.VocabPage, error) {
+	if q.SortBy != model.VocabSortNone {
+		slices.SortStableFunc(vocabs, func(a, b model.Vocab) int {
+			var c int
+			switch q.SortBy {
+			case model.VocabSortDefinition:
+				c = cmp.Compare(a.Definition, b.Definition)
+			case model.VocabSortCreatedAt:
+				c = a.CreatedAt.Compare(b.CreatedAt)
+			case model.VocabSortDueAt:
+				c = a.DueAt.Compare(b.DueAt)
+			}
+			if q.Descending {
+				return -c
+			}
+			return c
+		})
+	}
+
+	if q.Cursor != "" {
+		i := slices.IndexFunc(vocabs, func(v model.Vocab) bool { return v.Id.String() == q.Cursor })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: cursor vocab no longer exists", ErrInvalidQuery)
+		}
+		vocabs = vocabs[i+1:]
+	}
+
+	page := &model.VocabPage{Vocabs: []model.Vocab{}}
+	for _, v := range vocabs {
+		if !q.Matches(v, inSet) {
+			continue
+		}
+		if q.Limit > 0 && len(page.Vocabs) == q.Limit {
+			page.NextCursor = page.Vocabs[len(page.Vocabs)-1].Id.String()
+			break
+		}
+		page.Vocabs = append(page.Vocabs, v)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,173 @@
+package classroom
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/config"
+)
+
+var ErrInvalidRole = newError(ErrValidation, "invalid_role", "invalid role")
+
+// roleCacheTTL is how long a role read from storage is reused. Role
+// changes made through the RoleService apply at once, changes made
+// directly in storage after at most this long. Expired roles are dropped
+// once per TTL, so the cache only holds the users seen recently.
+const roleCacheTTL = time.Minute
+
+// RoleService decides the role of a user. Roles come from the auth token's
+// custom claims, then from the roles collection; users without either are
+// learners. Users listed in the config are always admins.
+type RoleService struct {
+	storage Firestore
+	admins  map[string]bool
+
+	mu        sync.Mutex
+	cache     map[string]cachedRole
+	lastSweep time.Time
+}
+
+type cachedRole struct {
+	role    model.Role
+	expires time.Time
+}
+
+type NewRoleServiceParams struct {
+	fx.In
+	Store Firestore
+	Cfg   *config.AuthConfig
+}
+
+func NewRoleService(p NewRoleServiceParams) *RoleService {
+	admins := make(map[string]bool, len(p.Cfg.AdminIds))
+	for _, userId := range p.Cfg.AdminIds {
+		admins[userId] = true
+	}
+
+	return &RoleService{
+		storage:   p.Store,
+		admins:    admins,
+		cache:     map[string]cachedRole{},
+		lastSweep: time.Now(),
+	}
+}
+
+// RoleFromClaims reads the role from custom claims, either a "role"
+// string, a "roles" list or an "admin" flag. It returns "" if the claims
+// hold no known role.
+func RoleFromClaims(claims map[string]any) model.Role {
+	var roles []model.Role
+	if role, ok := claims["role"].(string); ok {
+		roles = append(roles, model.Role(role))
+	}
+	if list, ok := claims["roles"].([]any); ok {
+		for _, role := range list {
+			if role, ok := role.(string); ok {
+				roles = append(roles, model.Role(role))
+			}
+		}
+	}
+	if admin, ok := claims["admin"].(bool); ok && admin {
+		roles = append(roles, model.RoleAdmin)
+	}
+
+	return model.HighestRole(roles...)
+}
+
+// Resolve returns the role of an authenticated user with the given token
+// claims.
+func (rs *RoleService) Resolve(ctx context.Context, userId string, claims map[string]any) (model.Role, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.Resolve")
+	defer span.End()
+
+	if rs.admins[userId] {
+		return model.RoleAdmin, nil
+	}
+	if role := RoleFromClaims(claims); role != "" {
+		return role, nil
+	}
+	return rs.storedRole(ctx, userId)
+}
+
+// storedRole returns the role from the roles collection, defaulting to
+// learner.
+func (rs *RoleService) storedRole(ctx context.Context, userId string) (model.Role, error) {
+	now := time.Now()
+
+	rs.mu.Lock()
+	cached, ok := rs.cache[userId]
+	rs.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.role, nil
+	}
+
+	role, err := rs.storage.GetUserRole(ctx, userId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+	if !role.Valid() {
+		role = model.RoleLearner
+	}
+
+	rs.mu.Lock()
+	if now.Sub(rs.lastSweep) > roleCacheTTL {
+		rs.sweep(now)
+	}
+	rs.cache[userId] = cachedRole{role: role, expires: now.Add(roleCacheTTL)}
+	rs.mu.Unlock()
+
+	return role, nil
+}
+
+// sweep drops the expired roles. rs.mu must be held.
+func (rs *RoleService) sweep(now time.Time) {
+	for userId, cached := range rs.cache {
+		if !now.Before(cached.expires) {
+			delete(rs.cache, userId)
+		}
+	}
+	rs.lastSweep = now
+}
+
+// GetRole returns the role of a user as far as it is known without a
+// token: from the config or the roles collection.
+func (rs *RoleService) GetRole(ctx context.Context, userId string) (model.Role, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.GetRole")
+	defer span.End()
+
+	if rs.admins[userId] {
+		return model.RoleAdmin, nil
+	}
+	return rs.storedRole(ctx, userId)
+}
+
+// SetRole stores the role of a user in the roles collection. Roles in
+// custom claims take precedence and have to be changed in Firebase.
+func (rs *RoleService) SetRole(ctx context.Context, userId string, role model.Role) error {
+	ctx, span := tracer.Start(ctx, "RoleService.SetRole")
+	defer span.End()
+
+	if !role.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
+	stored := role
+	if role == model.RoleLearner {
+		// Learner is the default, there is nothing to store.
+		stored = ""
+	}
+	if err := rs.storage.SetUserRole(ctx, userId, stored); err != nil {
+		return fmt.Errorf("failed to set user role: %w", err)
+	}
+
+	rs.mu.Lock()
+	delete(rs.cache, userId)
+	rs.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package classroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/config"
+)
+
+func TestRoleFromClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   model.Role
+	}{
+		{"no claims", nil, ""},
+		{"role", map[string]any{"role": "teacher"}, model.RoleTeacher},
+		{"unknown role", map[string]any{"role": "owner"}, ""},
+		{"roles", map[string]any{"roles": []any{"learner", "teacher"}}, model.RoleTeacher},
+		{"admin flag", map[string]any{"role": "teacher", "admin": true}, model.RoleAdmin},
+		{"admin flag off", map[string]any{"admin": false}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, RoleFromClaims(tt.claims))
+		})
+	}
+}
+
+func TestRoleService(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	service := NewRoleService(NewRoleServiceParams{
+		Store: store,
+		Cfg:   &config.AuthConfig{AdminIds: []string{"root"}},
+	})
+
+	role, err := service.Resolve(ctx, "user", nil)
+	require.NoError(t, err)
+	require.Equal(t, model.RoleLearner, role)
+
+	require.NoError(t, service.SetRole(ctx, "user", model.RoleTeacher))
+	role, err = service.Resolve(ctx, "user", nil)
+	require.NoError(t, err)
+	require.Equal(t, model.RoleTeacher, role)
+
+	// Claims take precedence over the stored role.
+	role, err = service.Resolve(ctx, "user", map[string]any{"role": "admin"})
+	require.NoError(t, err)
+	require.Equal(t, model.RoleAdmin, role)
+
+	// Configured admins stay admins whatever else is known about them.
+	role, err = service.Resolve(ctx, "root", map[string]any{"role": "learner"})
+	require.NoError(t, err)
+	require.Equal(t, model.RoleAdmin, role)
+
+	require.NoError(t, service.SetRole(ctx, "user", model.RoleLearner))
+	stored, err := store.GetUserRole(ctx, "user")
+	require.NoError(t, err)
+	require.Empty(t, stored)
+	role, err = service.GetRole(ctx, "user")
+	require.NoError(t, err)
+	require.Equal(t, model.RoleLearner, role)
+
+	require.ErrorIs(t, service.SetRole(ctx, "user", "owner"), ErrInvalidRole)
+}
+
+func TestRoleService_SweepsExpiredRoles(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	service := NewRoleService(NewRoleServiceParams{Store: store, Cfg: &config.AuthConfig{}})
+
+	expired := time.Now().Add(-time.Second)
+	service.cache["gone"] = cachedRole{role: model.RoleTeacher, expires: expired}
+	service.lastSweep = expired.Add(-roleCacheTTL)
+
+	role, err := service.GetRole(ctx, "user")
+	require.NoError(t, err)
+	require.Equal(t, model.RoleLearner, role)
+	require.NotContains(t, service.cache, "gone")
+	require.Contains(t, service.cache, "user")
+}
@@ -0,0 +1,279 @@
+package classroom
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/textnorm"
+	"github.com/vladazn/danish/common/userid"
+)
+
+const (
+	// maxIndexedPrefix caps the prefixes stored per word, longer queries
+	// are matched on their first maxIndexedPrefix letters and re-checked
+	// while ranking.
+	maxIndexedPrefix = 15
+	// maxSearchTerms is the most terms a single index lookup accepts.
+	maxSearchTerms = 30
+	// searchCandidates is how many index hits are ranked per search.
+	searchCandidates  = 200
+	defaultSearchSize = 20
+	maxSearchSize     = 100
+)
+
+// Search finds vocab whose definition or form values start with every word
+// of q, ignoring case, accents and the spelling of æ, ø and å.
+func (d *Dictionary) Search(ctx context.Context, q string, limit int) ([]model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary.Search")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	if limit <= 0 {
+		limit = defaultSearchSize
+	}
+	limit = min(limit, maxSearchSize)
+
+	queryTokens := textnorm.Tokens(q)
+	if len(queryTokens) == 0 {
+		return []model.Vocab{}, nil
+	}
+
+	// Until the background migration has indexed existing vocab, the index
+	// misses words, so the whole vocabulary is ranked instead.
+	version, err := d.storage.GetDataVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get data version: %w", err)
+	}
+	var candidates []model.Vocab
+	if version < DataVersion {
+		candidates, err = d.storage.FetchUserVocabulary(ctx, userId)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch vocab: %w", err)
+		}
+	} else {
+		candidates, err = d.indexCandidates(ctx, userId, q, queryTokens)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	type scored struct {
+		vocab model.Vocab
+		score int
+	}
+	var results []scored
+	for _, vocab := range candidates {
+		if score := searchScore(vocab, q, queryTokens); score > 0 {
+			results = append(results, scored{vocab, score})
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b scored) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		return strings.Compare(a.vocab.Definition, b.vocab.Definition)
+	})
+
+	vocabs := []model.Vocab{}
+	for _, r := range results[:min(limit, len(results))] {
+		vocabs = append(vocabs, r.vocab)
+	}
+
+	return vocabs, nil
+}
+
+// indexCandidates looks up the vocab worth ranking for the query in the
+// search index.
+func (d *Dictionary) indexCandidates(
+	ctx context.Context, userId string, q string, queryTokens []string,
+) ([]model.Vocab, error) {
+	// Look up candidates by the longest word, it is the most selective one.
+	// The lookups go from the best kind of match down, so the hits of a
+	// common prefix never crowd out whole-word or exact hits.
+	longest := slices.MaxFunc(queryTokens, func(a, b string) int {
+		return len([]rune(a)) - len([]rune(b))
+	})
+	var wordTerms, prefixTerms []string
+	for _, variant := range textnorm.Variants(longest) {
+		wordTerms = append(wordTerms, wordTerm(variant))
+		prefixTerms = append(prefixTerms, truncate(variant, maxIndexedPrefix))
+	}
+
+	seen := map[uuid.UUID]bool{}
+	ids := []uuid.UUID{}
+	for _, terms := range [][]string{{exactTerm(q)}, wordTerms, prefixTerms} {
+		found, err := d.storage.SearchVocabIds(ctx, userId, terms, searchCandidates)
+		if err != nil {
+			return nil, fmt.Errorf("could not search vocab: %w", err)
+		}
+		for _, id := range found {
+			if !seen[id] && len(ids) < searchCandidates {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == searchCandidates {
+			break
+		}
+	}
+
+	candidates, err := d.storage.GetMultipleVocabs(ctx, userId, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not get search results: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// RebuildSearchIndex re-indexes the whole vocabulary of the user.
+func (d *Dictionary) RebuildSearchIndex(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "Dictionary.RebuildSearchIndex")
+	defer span.End()
+
+	userId := userid.MustFromCtx(ctx)
+
+	vocabs, err := d.storage.FetchUserVocabulary(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("could not fetch vocab: %w", err)
+	}
+
+	for _, vocab := range vocabs {
+		if err := d.storage.SetSearchEntry(ctx, userId, newSearchEntry(vocab)); err != nil {
+			return fmt.Errorf("could not index vocab %s: %w", vocab.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func newSearchEntry(vocab model.Vocab) model.SearchEntry {
+	seen := map[string]bool{}
+	terms := []string{}
+
+	for _, term := range exactTerms(vocab) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, text := range searchableTexts(vocab) {
+		for _, token := range textnorm.Tokens(text) {
+			for _, variant := range textnorm.Variants(token) {
+				if term := wordTerm(variant); !seen[term] {
+					seen[term] = true
+					terms = append(terms, term)
+				}
+
+				runes := []rune(variant)
+				for i := 1; i <= min(len(runes), maxIndexedPrefix); i++ {
+					prefix := string(runes[:i])
+					if !seen[prefix] {
+						seen[prefix] = true
+						terms = append(terms, prefix)
+					}
+				}
+			}
+		}
+	}
+
+	return model.SearchEntry{VocabId: vocab.Id, Terms: terms}
+}
+
+// exactTerms holds the whole normalized definition and form values, marked
+// with a leading "=" so they never collide with word prefixes.
+func exactTerms(vocab model.Vocab) []string {
+	var terms []string
+	for _, text := range searchableTexts(vocab) {
+		if term := exactTerm(text); term != "=" {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+func exactTerm(text string) string {
+	return "=" + textnorm.Normalize(text)
+}
+
+// wordTerm marks a whole word with a leading ":", so a lookup can tell a
+// word from the prefix of a longer one.
+func wordTerm(variant string) string {
+	return ":" + variant
+}
+
+func searchableTexts(vocab model.Vocab) []string {
+	texts := []string{vocab.Definition}
+	for _, form := range vocab.Forms {
+		texts = append(texts, form.Value)
+	}
+
+	return texts
+}
+
+// searchScore ranks how well the vocab matches the query, 0 meaning it does
+// not match. Whole-word hits beat prefix hits and form values beat the
+// definition; a form equal to the full query wins outright.
+func searchScore(vocab model.Vocab, q string, queryTokens []string) int {
+	normalizedQuery := textnorm.Normalize(q)
+
+	score := 0
+	for _, form := range vocab.Forms {
+		if textnorm.Normalize(form.Value) == normalizedQuery {
+			score += 100
+			break
+		}
+	}
+
+	for _, queryToken := range queryTokens {
+		best := 0
+		for i, text := range searchableTexts(vocab) {
+			weight := 2
+			if i == 0 {
+				weight = 1
+			}
+			best = max(best, weight*tokenScore(queryToken, textnorm.Tokens(text)))
+		}
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	return score
+}
+
+func tokenScore(queryToken string, textTokens []string) int {
+	best := 0
+	for _, qv := range textnorm.Variants(queryToken) {
+		for _, textToken := range textTokens {
+			for _, tv := range textnorm.Variants(textToken) {
+				switch {
+				case tv == qv:
+					return 3
+				case strings.HasPrefix(tv, qv):
+					best = 2
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n])
+}
@@ -0,0 +1,227 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vladazn/danish/app/classroom/private/mocks"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+func TestNewSearchEntry(t *testing.T) {
+	vocab := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "Bread",
+		Forms: []model.VocabForm{
+			{Value: "brød"},
+			{Value: "Café"},
+		},
+	}
+
+	entry := newSearchEntry(vocab)
+
+	require.Equal(t, vocab.Id, entry.VocabId)
+	for _, term := range []string{"b", "bread", "broed", "brod", "cafe", "caf", "=bread", "=broed", ":bread", ":brod", ":cafe"} {
+		require.Contains(t, entry.Terms, term)
+	}
+	require.NotContains(t, entry.Terms, ":caf")
+	require.NotContains(t, entry.Terms, "brød")
+	require.NotContains(t, entry.Terms, "Bread")
+}
+
+func TestDictionary_Search(t *testing.T) {
+	userId := "test-user"
+
+	hus := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "house",
+		Forms:      []model.VocabForm{{Value: "hus"}, {Value: "huset"}},
+	}
+	huse := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "houses",
+		Forms:      []model.VocabForm{{Value: "husene"}},
+	}
+	husband := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "husband",
+		Forms:      []model.VocabForm{{Value: "mand"}},
+	}
+	foer := model.Vocab{
+		Id:         uuid.New(),
+		Definition: "before",
+		Forms:      []model.VocabForm{{Value: "før"}},
+	}
+	candidates := []model.Vocab{husband, huse, hus, foer}
+
+	tests := []struct {
+		name        string
+		query       string
+		tiers       [][]string
+		expected    []model.Vocab
+		expectError bool
+	}{
+		{
+			name:     "exact form ranks first",
+			query:    "hus",
+			tiers:    [][]string{{"=hus"}, {":hus"}, {"hus"}},
+			expected: []model.Vocab{hus, huse, husband},
+		},
+		{
+			name:     "accents and danish letters are ignored",
+			query:    "FOR",
+			tiers:    [][]string{{"=for"}, {":for"}, {"for"}},
+			expected: []model.Vocab{foer},
+		},
+		{
+			name:     "danish letters in the query",
+			query:    "før",
+			tiers:    [][]string{{"=foer"}, {":foer", ":for"}, {"foer", "for"}},
+			expected: []model.Vocab{foer},
+		},
+		{
+			name:     "every word must match",
+			query:    "hus houses",
+			tiers:    [][]string{{"=hus houses"}, {":houses"}, {"houses"}},
+			expected: []model.Vocab{huse},
+		},
+		{
+			name:        "storage error",
+			query:       "hus",
+			tiers:       [][]string{{"=hus"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockFirestore(ctrl)
+			mockStore.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+			if tt.expectError {
+				mockStore.EXPECT().
+					SearchVocabIds(gomock.Any(), userId, tt.tiers[0], searchCandidates).
+					Return(nil, errors.New("test error"))
+			} else {
+				// Every tier finds all candidates, which are kept once.
+				ids := []uuid.UUID{}
+				for _, vocab := range candidates {
+					ids = append(ids, vocab.Id)
+				}
+				var calls []any
+				for _, terms := range tt.tiers {
+					calls = append(calls, mockStore.EXPECT().
+						SearchVocabIds(gomock.Any(), userId, terms, searchCandidates).
+						Return(ids, nil))
+				}
+				gomock.InOrder(calls...)
+				mockStore.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, ids).
+					Return(candidates, nil)
+			}
+
+			dict := &Dictionary{storage: mockStore}
+			ctx := userid.ToCtx(context.Background(), userId)
+
+			result, err := dict.Search(ctx, tt.query, 0)
+
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDictionary_Search_StopsAtCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := "test-user"
+	ids := make([]uuid.UUID, searchCandidates)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	// Whole-word hits fill the candidates, so the prefix is never looked up.
+	mockStore := mocks.NewMockFirestore(ctrl)
+	mockStore.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion, nil)
+	gomock.InOrder(
+		mockStore.EXPECT().SearchVocabIds(gomock.Any(), userId, []string{"=hus"}, searchCandidates).
+			Return(ids[:1], nil),
+		mockStore.EXPECT().SearchVocabIds(gomock.Any(), userId, []string{":hus"}, searchCandidates).
+			Return(ids, nil),
+	)
+	mockStore.EXPECT().GetMultipleVocabs(gomock.Any(), userId, ids).Return([]model.Vocab{}, nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	_, err := dict.Search(ctx, "hus", 0)
+	require.NoError(t, err)
+}
+
+func TestDictionary_Search_BeforeMigration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userId := "test-user"
+	hus := model.Vocab{Id: uuid.New(), Definition: "house", Forms: []model.VocabForm{{Value: "hus"}}}
+	brod := model.Vocab{Id: uuid.New(), Definition: "bread", Forms: []model.VocabForm{{Value: "brød"}}}
+
+	// The index is not read before the migration, every vocab is ranked.
+	mockStore := mocks.NewMockFirestore(ctrl)
+	mockStore.EXPECT().GetDataVersion(gomock.Any()).Return(DataVersion-1, nil)
+	mockStore.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return([]model.Vocab{brod, hus}, nil)
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	result, err := dict.Search(ctx, "hus", 0)
+	require.NoError(t, err)
+	require.Equal(t, []model.Vocab{hus}, result)
+}
+
+func TestDictionary_Search_EmptyQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dict := &Dictionary{storage: mocks.NewMockFirestore(ctrl)}
+	ctx := userid.ToCtx(context.Background(), "test-user")
+
+	result, err := dict.Search(ctx, " ,. ", 10)
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestDictionary_RebuildSearchIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabs := []model.Vocab{
+		{Id: uuid.New(), Definition: "house"},
+		{Id: uuid.New(), Definition: "bread"},
+	}
+
+	mockStore.EXPECT().FetchUserVocabulary(gomock.Any(), userId).Return(vocabs, nil)
+	for _, vocab := range vocabs {
+		mockStore.EXPECT().SetSearchEntry(gomock.Any(), userId, newSearchEntry(vocab)).Return(nil)
+	}
+
+	dict := &Dictionary{storage: mockStore}
+	ctx := userid.ToCtx(context.Background(), userId)
+
+	require.NoError(t, dict.RebuildSearchIndex(ctx))
+}
@@ -8,6 +8,18 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/validate"
+)
+
+var (
+	ErrSetNotFound = newError(ErrNotFound, "set_not_found", "vocab set not found")
+	ErrInvalidSet  = newError(ErrValidation, "invalid_set", "invalid vocab set")
+)
+
+// Limits of a vocab set.
+const (
+	MaxSetNameLength = 100
+	MaxSetSize       = MaxImportSize
 )
 
 type SetService struct {
@@ -16,7 +28,7 @@ type SetService struct {
 
 type NewSetServiceParams struct {
 	fx.In
-	Store *FirebaseStore
+	Store Firestore
 }
 
 func NewSetService(p NewSetServiceParams) *SetService {
@@ -27,6 +39,9 @@ func NewSetService(p NewSetServiceParams) *SetService {
 
 // GetSetList retrieves all vocab sets for a user
 func (ss *SetService) GetSetList(ctx context.Context, userId string) ([]model.VocabSet, error) {
+	ctx, span := tracer.Start(ctx, "SetService.GetSetList")
+	defer span.End()
+
 	sets, err := ss.storage.FetchUserVocabSets(ctx, userId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user vocab sets: %w", err)
@@ -36,14 +51,14 @@ func (ss *SetService) GetSetList(ctx context.Context, userId string) ([]model.Vo
 
 // GetSetVocabBatch retrieves a batch of vocabulary items from a specific set
 func (ss *SetService) GetSetVocabBatch(ctx context.Context, userId string, setId uuid.UUID, limit int) (*model.Batch, error) {
+	ctx, span := tracer.Start(ctx, "SetService.GetSetVocabBatch")
+	defer span.End()
+
 	// Get the vocab set
 	vocabSet, err := ss.storage.GetVocabSet(ctx, userId, setId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vocab set: %w", err)
 	}
-	if vocabSet == nil {
-		return nil, fmt.Errorf("vocab set not found")
-	}
 
 	// Get the vocabulary items for the set
 	vocabs, err := ss.storage.GetMultipleVocabs(ctx, userId, vocabSet.VocabIds)
@@ -61,8 +76,34 @@ func (ss *SetService) GetSetVocabBatch(ctx context.Context, userId string, setId
 	}, nil
 }
 
+// ExportSet returns a vocab set together with its vocab, for the file
+// exports of the API and the command line.
+func (ss *SetService) ExportSet(ctx context.Context, userId string, setId uuid.UUID) (*model.VocabSet, []model.Vocab, error) {
+	ctx, span := tracer.Start(ctx, "SetService.ExportSet")
+	defer span.End()
+
+	vocabSet, err := ss.storage.GetVocabSet(ctx, userId, setId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get vocab set: %w", err)
+	}
+
+	vocabs, err := ss.storage.GetMultipleVocabs(ctx, userId, vocabSet.VocabIds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get vocab items for set: %w", err)
+	}
+
+	return vocabSet, vocabs, nil
+}
+
 // AddSet creates a new vocab set for a user
 func (ss *SetService) AddSet(ctx context.Context, userId string, name string, vocabIds []uuid.UUID) (*model.VocabSet, error) {
+	ctx, span := tracer.Start(ctx, "SetService.AddSet")
+	defer span.End()
+
+	if err := ss.validateSet(ctx, userId, name, vocabIds); err != nil {
+		return nil, err
+	}
+
 	vocabSet := model.VocabSet{
 		Id:       uuid.New(),
 		Name:     name,
@@ -79,14 +120,18 @@ func (ss *SetService) AddSet(ctx context.Context, userId string, name string, vo
 
 // UpdateSet updates an existing vocab set
 func (ss *SetService) UpdateSet(ctx context.Context, userId string, setId uuid.UUID, name string, vocabIds []uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "SetService.UpdateSet")
+	defer span.End()
+
+	if err := ss.validateSet(ctx, userId, name, vocabIds); err != nil {
+		return err
+	}
+
 	// Check if the set exists
-	existingSet, err := ss.storage.GetVocabSet(ctx, userId, setId)
+	_, err := ss.storage.GetVocabSet(ctx, userId, setId)
 	if err != nil {
 		return fmt.Errorf("failed to get existing vocab set: %w", err)
 	}
-	if existingSet == nil {
-		return fmt.Errorf("vocab set not found")
-	}
 
 	// Update the set
 	updatedSet := model.VocabSet{
@@ -105,14 +150,14 @@ func (ss *SetService) UpdateSet(ctx context.Context, userId string, setId uuid.U
 
 // RemoveSet removes a vocab set for a user
 func (ss *SetService) RemoveSet(ctx context.Context, userId string, setId uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "SetService.RemoveSet")
+	defer span.End()
+
 	// Check if the set exists
-	existingSet, err := ss.storage.GetVocabSet(ctx, userId, setId)
+	_, err := ss.storage.GetVocabSet(ctx, userId, setId)
 	if err != nil {
 		return fmt.Errorf("failed to get existing vocab set: %w", err)
 	}
-	if existingSet == nil {
-		return fmt.Errorf("vocab set not found")
-	}
 
 	// Remove the set
 	err = ss.storage.RemoveVocabSet(ctx, userId, setId)
@@ -122,3 +167,40 @@ func (ss *SetService) RemoveSet(ctx context.Context, userId string, setId uuid.U
 
 	return nil
 }
+
+// validateSet checks the name of a set and that every vocab id of it is
+// listed once and refers to vocab of the user.
+func (ss *SetService) validateSet(ctx context.Context, userId string, name string, vocabIds []uuid.UUID) error {
+	var val validate.Validator
+	val.Required("name", name)
+	val.MaxLength("name", name, MaxSetNameLength)
+	val.Items("vocab_ids", len(vocabIds), 0, MaxSetSize)
+
+	if len(vocabIds) > 0 && len(vocabIds) <= MaxSetSize {
+		vocabs, err := ss.storage.GetMultipleVocabs(ctx, userId, vocabIds)
+		if err != nil {
+			return fmt.Errorf("failed to get set vocab: %w", err)
+		}
+		exists := make(map[uuid.UUID]bool, len(vocabs))
+		for _, vocab := range vocabs {
+			exists[vocab.Id] = true
+		}
+
+		seen := make(map[uuid.UUID]bool, len(vocabIds))
+		for i, vocabId := range vocabIds {
+			field := validate.Index("vocab_ids", i)
+			switch {
+			case seen[vocabId]:
+				val.Add(field, "is listed more than once")
+			case !exists[vocabId]:
+				val.Add(field, "does not exist")
+			}
+			seen[vocabId] = true
+		}
+	}
+
+	if err := val.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSet, err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/vladazn/danish/app/classroom/private/mocks"
 	"github.com/vladazn/danish/app/model"
 	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
 )
 
 func TestSetService_GetSetList(t *testing.T) {
@@ -180,7 +181,7 @@ func TestSetService_GetSetVocabBatch(t *testing.T) {
 			setupMock: func(mock *mocks.MockFirestore) {
 				mock.EXPECT().
 					GetVocabSet(gomock.Any(), userId, setId).
-					Return(nil, nil)
+					Return(nil, ErrSetNotFound)
 			},
 			expected:    nil,
 			expectError: true,
@@ -245,6 +246,32 @@ func TestSetService_GetSetVocabBatch(t *testing.T) {
 	}
 }
 
+func TestSetService_ExportSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockFirestore(ctrl)
+	userId := "test-user"
+	vocabs := []model.Vocab{{Id: uuid.New(), Definition: "hus"}}
+	vocabSet := &model.VocabSet{Id: uuid.New(), Name: "Home", VocabIds: []uuid.UUID{vocabs[0].Id}}
+	missingId := uuid.New()
+
+	mockStore.EXPECT().GetVocabSet(gomock.Any(), userId, vocabSet.Id).Return(vocabSet, nil)
+	mockStore.EXPECT().GetMultipleVocabs(gomock.Any(), userId, vocabSet.VocabIds).Return(vocabs, nil)
+	mockStore.EXPECT().GetVocabSet(gomock.Any(), userId, missingId).Return(nil, ErrSetNotFound)
+
+	service := &SetService{storage: mockStore}
+	ctx := context.Background()
+
+	set, setVocabs, err := service.ExportSet(ctx, userId, vocabSet.Id)
+	require.NoError(t, err)
+	require.Equal(t, vocabSet, set)
+	require.Equal(t, vocabs, setVocabs)
+
+	_, _, err = service.ExportSet(ctx, userId, missingId)
+	require.ErrorIs(t, err, ErrSetNotFound)
+}
+
 func TestSetService_AddSet(t *testing.T) {
 	userId := "test-user"
 	vocabId1 := uuid.New()
@@ -263,6 +290,9 @@ func TestSetService_AddSet(t *testing.T) {
 			setName:  setName,
 			vocabIds: []uuid.UUID{vocabId1, vocabId2},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1, vocabId2}).
+					Return([]model.Vocab{{Id: vocabId1}, {Id: vocabId2}}, nil)
 				mock.EXPECT().
 					SetVocabSet(gomock.Any(), userId, gomock.Any()).
 					DoAndReturn(func(ctx context.Context, userId string, vocabSet model.VocabSet) error {
@@ -295,12 +325,48 @@ func TestSetService_AddSet(t *testing.T) {
 			setName:  setName,
 			vocabIds: []uuid.UUID{vocabId1},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
 				mock.EXPECT().
 					SetVocabSet(gomock.Any(), userId, gomock.Any()).
 					Return(errors.New("storage error"))
 			},
 			expectError: true,
 		},
+		{
+			name:     "unknown vocab",
+			setName:  setName,
+			vocabIds: []uuid.UUID{vocabId1, vocabId2},
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1, vocabId2}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:     "vocab listed twice",
+			setName:  setName,
+			vocabIds: []uuid.UUID{vocabId1, vocabId1},
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1, vocabId1}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:     "empty name",
+			setName:  "",
+			vocabIds: []uuid.UUID{vocabId1},
+			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -332,6 +398,29 @@ func TestSetService_AddSet(t *testing.T) {
 	}
 }
 
+func TestSetService_AddSet_Violations(t *testing.T) {
+	ctx := context.Background()
+	userId := "test-user"
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	service := NewSetService(NewSetServiceParams{Store: store})
+
+	known := model.Vocab{Id: uuid.New(), Definition: "house"}
+	require.NoError(t, store.AddVocabulary(ctx, userId, known))
+
+	_, err = service.AddSet(ctx, userId, " ", []uuid.UUID{known.Id, uuid.New(), known.Id})
+	require.ErrorIs(t, err, ErrInvalidSet)
+
+	var validationErr *validate.Error
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, []validate.Violation{
+		{Field: "name", Message: "is required"},
+		{Field: "vocab_ids[1]", Message: "does not exist"},
+		{Field: "vocab_ids[2]", Message: "is listed more than once"},
+	}, validationErr.Violations)
+}
+
 func TestSetService_UpdateSet(t *testing.T) {
 	userId := "test-user"
 	setId := uuid.New()
@@ -353,6 +442,9 @@ func TestSetService_UpdateSet(t *testing.T) {
 			setName:  updatedName,
 			vocabIds: []uuid.UUID{vocabId1, vocabId2},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1, vocabId2}).
+					Return([]model.Vocab{{Id: vocabId1}, {Id: vocabId2}}, nil)
 				mock.EXPECT().
 					GetVocabSet(gomock.Any(), userId, setId).
 					Return(&model.VocabSet{
@@ -377,9 +469,12 @@ func TestSetService_UpdateSet(t *testing.T) {
 			setName:  updatedName,
 			vocabIds: []uuid.UUID{vocabId1},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
 				mock.EXPECT().
 					GetVocabSet(gomock.Any(), userId, setId).
-					Return(nil, nil)
+					Return(nil, ErrSetNotFound)
 			},
 			expectError: true,
 		},
@@ -389,6 +484,9 @@ func TestSetService_UpdateSet(t *testing.T) {
 			setName:  updatedName,
 			vocabIds: []uuid.UUID{vocabId1},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
 				mock.EXPECT().
 					GetVocabSet(gomock.Any(), userId, setId).
 					Return(nil, errors.New("get set error"))
@@ -401,6 +499,9 @@ func TestSetService_UpdateSet(t *testing.T) {
 			setName:  updatedName,
 			vocabIds: []uuid.UUID{vocabId1},
 			setupMock: func(mock *mocks.MockFirestore) {
+				mock.EXPECT().
+					GetMultipleVocabs(gomock.Any(), userId, []uuid.UUID{vocabId1}).
+					Return([]model.Vocab{{Id: vocabId1}}, nil)
 				mock.EXPECT().
 					GetVocabSet(gomock.Any(), userId, setId).
 					Return(&model.VocabSet{
@@ -535,7 +636,7 @@ func TestSetService_RemoveSet(t *testing.T) {
 			setupMock: func(mock *mocks.MockFirestore) {
 				mock.EXPECT().
 					GetVocabSet(gomock.Any(), userId, setId).
-					Return(nil, nil)
+					Return(nil, ErrSetNotFound)
 			},
 			expectError: true,
 		},
@@ -0,0 +1,38 @@
+package classroom
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/storage"
+	"github.com/vladazn/danish/config"
+)
+
+type NewStoreParams struct {
+	fx.In
+	Cfg     *config.StorageConfig
+	Client  *storage.FirestoreClient
+	Metrics *metrics.Metrics
+}
+
+// NewStore returns the storage backend selected in the config, with its
+// calls recorded in the metrics and traced.
+func NewStore(p NewStoreParams) (Firestore, error) {
+	var store Firestore
+	switch p.Cfg.Backend {
+	case config.StorageBackendFirestore:
+		store = NewFirebaseStore(NewFirebaseStoreParams{Client: p.Client})
+	case config.StorageBackendLocal:
+		local, err := NewLocalStore(p.Cfg.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+		store = local
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", p.Cfg.Backend)
+	}
+
+	return newTracedStore(newMeteredStore(store, p.Metrics), p.Cfg.Backend), nil
+}
@@ -0,0 +1,331 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+// tracer starts the spans of the service methods and storage calls.
+var tracer = otel.Tracer("github.com/vladazn/danish/app/classroom")
+
+// tracedStore wraps every call of the wrapped store in a client span.
+type tracedStore struct {
+	next   Firestore
+	system attribute.KeyValue
+}
+
+func newTracedStore(next Firestore, backend string) Firestore {
+	return &tracedStore{next: next, system: semconv.DBSystemKey.String(backend)}
+}
+
+func (s *tracedStore) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "Firestore."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(s.system, semconv.DBOperationName(method)),
+	)
+}
+
+// endSpan ends a span, marking it failed if *err is set and not a not
+// found error; use as
+// defer endSpan(span, &err).
+func endSpan(span trace.Span, err *error) {
+	if *err != nil && !errors.Is(*err, ErrNotFound) {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+func (s *tracedStore) FetchUserVocabulary(ctx context.Context, userId string) (result []model.Vocab, err error) {
+	ctx, span := s.start(ctx, "FetchUserVocabulary")
+	defer endSpan(span, &err)
+	return s.next.FetchUserVocabulary(ctx, userId)
+}
+
+func (s *tracedStore) QueryUserVocabulary(ctx context.Context, userId string, query model.VocabQuery) (result *model.VocabPage, err error) {
+	ctx, span := s.start(ctx, "QueryUserVocabulary")
+	defer endSpan(span, &err)
+	return s.next.QueryUserVocabulary(ctx, userId, query)
+}
+
+func (s *tracedStore) AddVocabulary(ctx context.Context, userId string, vocab model.Vocab) (err error) {
+	ctx, span := s.start(ctx, "AddVocabulary")
+	defer endSpan(span, &err)
+	return s.next.AddVocabulary(ctx, userId, vocab)
+}
+
+func (s *tracedStore) RemoveVocabulary(ctx context.Context, userId string, vocabId uuid.UUID) (err error) {
+	ctx, span := s.start(ctx, "RemoveVocabulary")
+	defer endSpan(span, &err)
+	return s.next.RemoveVocabulary(ctx, userId, vocabId)
+}
+
+func (s *tracedStore) UpdatePool(ctx context.Context, userId string, pool *model.Pool) (err error) {
+	ctx, span := s.start(ctx, "UpdatePool")
+	defer endSpan(span, &err)
+	return s.next.UpdatePool(ctx, userId, pool)
+}
+
+func (s *tracedStore) FetchUserPool(ctx context.Context, userId string) (result *model.Pool, err error) {
+	ctx, span := s.start(ctx, "FetchUserPool")
+	defer endSpan(span, &err)
+	return s.next.FetchUserPool(ctx, userId)
+}
+
+func (s *tracedStore) GetVocab(ctx context.Context, userId string, vocabId uuid.UUID) (result *model.Vocab, err error) {
+	ctx, span := s.start(ctx, "GetVocab")
+	defer endSpan(span, &err)
+	return s.next.GetVocab(ctx, userId, vocabId)
+}
+
+func (s *tracedStore) GetMultipleVocabs(ctx context.Context, userId string, vocabIds []uuid.UUID) (result []model.Vocab, err error) {
+	ctx, span := s.start(ctx, "GetMultipleVocabs")
+	defer endSpan(span, &err)
+	return s.next.GetMultipleVocabs(ctx, userId, vocabIds)
+}
+
+func (s *tracedStore) SetVocabSet(ctx context.Context, userId string, vocabSet model.VocabSet) (err error) {
+	ctx, span := s.start(ctx, "SetVocabSet")
+	defer endSpan(span, &err)
+	return s.next.SetVocabSet(ctx, userId, vocabSet)
+}
+
+func (s *tracedStore) GetVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) (result *model.VocabSet, err error) {
+	ctx, span := s.start(ctx, "GetVocabSet")
+	defer endSpan(span, &err)
+	return s.next.GetVocabSet(ctx, userId, vocabSetId)
+}
+
+func (s *tracedStore) FetchUserVocabSets(ctx context.Context, userId string) (result []model.VocabSet, err error) {
+	ctx, span := s.start(ctx, "FetchUserVocabSets")
+	defer endSpan(span, &err)
+	return s.next.FetchUserVocabSets(ctx, userId)
+}
+
+func (s *tracedStore) RemoveVocabSet(ctx context.Context, userId string, vocabSetId uuid.UUID) (err error) {
+	ctx, span := s.start(ctx, "RemoveVocabSet")
+	defer endSpan(span, &err)
+	return s.next.RemoveVocabSet(ctx, userId, vocabSetId)
+}
+
+func (s *tracedStore) SetSearchEntry(ctx context.Context, userId string, entry model.SearchEntry) (err error) {
+	ctx, span := s.start(ctx, "SetSearchEntry")
+	defer endSpan(span, &err)
+	return s.next.SetSearchEntry(ctx, userId, entry)
+}
+
+func (s *tracedStore) RemoveSearchEntry(ctx context.Context, userId string, vocabId uuid.UUID) (err error) {
+	ctx, span := s.start(ctx, "RemoveSearchEntry")
+	defer endSpan(span, &err)
+	return s.next.RemoveSearchEntry(ctx, userId, vocabId)
+}
+
+func (s *tracedStore) SearchVocabIds(ctx context.Context, userId string, terms []string, limit int) (result []uuid.UUID, err error) {
+	ctx, span := s.start(ctx, "SearchVocabIds")
+	defer endSpan(span, &err)
+	return s.next.SearchVocabIds(ctx, userId, terms, limit)
+}
+
+func (s *tracedStore) WriteVocabBatch(ctx context.Context, userId string, batch model.VocabBatch) (err error) {
+	ctx, span := s.start(ctx, "WriteVocabBatch")
+	defer endSpan(span, &err)
+	return s.next.WriteVocabBatch(ctx, userId, batch)
+}
+
+func (s *tracedStore) DeleteUser(ctx context.Context, userId string) (err error) {
+	ctx, span := s.start(ctx, "DeleteUser")
+	defer endSpan(span, &err)
+	return s.next.DeleteUser(ctx, userId)
+}
+
+func (s *tracedStore) ListUsers(ctx context.Context) (result []string, err error) {
+	ctx, span := s.start(ctx, "ListUsers")
+	defer endSpan(span, &err)
+	return s.next.ListUsers(ctx)
+}
+
+func (s *tracedStore) SetPublishedSet(ctx context.Context, set model.PublishedSet) (err error) {
+	ctx, span := s.start(ctx, "SetPublishedSet")
+	defer endSpan(span, &err)
+	return s.next.SetPublishedSet(ctx, set)
+}
+
+func (s *tracedStore) GetPublishedSet(ctx context.Context, id string) (result *model.PublishedSet, err error) {
+	ctx, span := s.start(ctx, "GetPublishedSet")
+	defer endSpan(span, &err)
+	return s.next.GetPublishedSet(ctx, id)
+}
+
+func (s *tracedStore) ListPublishedSets(ctx context.Context, ownerId string, limit int) (result []model.PublishedSet, err error) {
+	ctx, span := s.start(ctx, "ListPublishedSets")
+	defer endSpan(span, &err)
+	return s.next.ListPublishedSets(ctx, ownerId, limit)
+}
+
+func (s *tracedStore) RemovePublishedSet(ctx context.Context, id string) (err error) {
+	ctx, span := s.start(ctx, "RemovePublishedSet")
+	defer endSpan(span, &err)
+	return s.next.RemovePublishedSet(ctx, id)
+}
+
+func (s *tracedStore) SetClass(ctx context.Context, class model.Class) (err error) {
+	ctx, span := s.start(ctx, "SetClass")
+	defer endSpan(span, &err)
+	return s.next.SetClass(ctx, class)
+}
+
+func (s *tracedStore) SetClassInviteCode(ctx context.Context, classId uuid.UUID, code string) (err error) {
+	ctx, span := s.start(ctx, "SetClassInviteCode")
+	defer endSpan(span, &err)
+	return s.next.SetClassInviteCode(ctx, classId, code)
+}
+
+func (s *tracedStore) AddClassStudent(ctx context.Context, classId uuid.UUID, studentId string) (err error) {
+	ctx, span := s.start(ctx, "AddClassStudent")
+	defer endSpan(span, &err)
+	return s.next.AddClassStudent(ctx, classId, studentId)
+}
+
+func (s *tracedStore) RemoveClassStudent(ctx context.Context, classId uuid.UUID, studentId string) (err error) {
+	ctx, span := s.start(ctx, "RemoveClassStudent")
+	defer endSpan(span, &err)
+	return s.next.RemoveClassStudent(ctx, classId, studentId)
+}
+
+func (s *tracedStore) GetClass(ctx context.Context, classId uuid.UUID) (result *model.Class, err error) {
+	ctx, span := s.start(ctx, "GetClass")
+	defer endSpan(span, &err)
+	return s.next.GetClass(ctx, classId)
+}
+
+func (s *tracedStore) FindClassByInviteCode(ctx context.Context, code string) (result *model.Class, err error) {
+	ctx, span := s.start(ctx, "FindClassByInviteCode")
+	defer endSpan(span, &err)
+	return s.next.FindClassByInviteCode(ctx, code)
+}
+
+func (s *tracedStore) ListUserClasses(ctx context.Context, userId string) (result []model.Class, err error) {
+	ctx, span := s.start(ctx, "ListUserClasses")
+	defer endSpan(span, &err)
+	return s.next.ListUserClasses(ctx, userId)
+}
+
+func (s *tracedStore) RemoveClass(ctx context.Context, classId uuid.UUID) (err error) {
+	ctx, span := s.start(ctx, "RemoveClass")
+	defer endSpan(span, &err)
+	return s.next.RemoveClass(ctx, classId)
+}
+
+func (s *tracedStore) SetAssignment(ctx context.Context, assignment model.Assignment) (err error) {
+	ctx, span := s.start(ctx, "SetAssignment")
+	defer endSpan(span, &err)
+	return s.next.SetAssignment(ctx, assignment)
+}
+
+func (s *tracedStore) ListAssignments(ctx context.Context, classId uuid.UUID) (result []model.Assignment, err error) {
+	ctx, span := s.start(ctx, "ListAssignments")
+	defer endSpan(span, &err)
+	return s.next.ListAssignments(ctx, classId)
+}
+
+func (s *tracedStore) GetAssignmentVocabs(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) (result []model.Vocab, err error) {
+	ctx, span := s.start(ctx, "GetAssignmentVocabs")
+	defer endSpan(span, &err)
+	return s.next.GetAssignmentVocabs(ctx, classId, assignmentId)
+}
+
+func (s *tracedStore) SetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string, copied model.AssignmentCopy,
+) (err error) {
+	ctx, span := s.start(ctx, "SetAssignmentCopy")
+	defer endSpan(span, &err)
+	return s.next.SetAssignmentCopy(ctx, classId, assignmentId, studentId, copied)
+}
+
+func (s *tracedStore) GetAssignmentCopy(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID, studentId string,
+) (result *model.AssignmentCopy, err error) {
+	ctx, span := s.start(ctx, "GetAssignmentCopy")
+	defer endSpan(span, &err)
+	return s.next.GetAssignmentCopy(ctx, classId, assignmentId, studentId)
+}
+
+func (s *tracedStore) ListAssignmentCopies(
+	ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID,
+) (result map[string]model.AssignmentCopy, err error) {
+	ctx, span := s.start(ctx, "ListAssignmentCopies")
+	defer endSpan(span, &err)
+	return s.next.ListAssignmentCopies(ctx, classId, assignmentId)
+}
+
+func (s *tracedStore) RemoveAssignment(ctx context.Context, classId uuid.UUID, assignmentId uuid.UUID) (err error) {
+	ctx, span := s.start(ctx, "RemoveAssignment")
+	defer endSpan(span, &err)
+	return s.next.RemoveAssignment(ctx, classId, assignmentId)
+}
+
+func (s *tracedStore) GetUserRole(ctx context.Context, userId string) (result model.Role, err error) {
+	ctx, span := s.start(ctx, "GetUserRole")
+	defer endSpan(span, &err)
+	return s.next.GetUserRole(ctx, userId)
+}
+
+func (s *tracedStore) SetUserRole(ctx context.Context, userId string, role model.Role) (err error) {
+	ctx, span := s.start(ctx, "SetUserRole")
+	defer endSpan(span, &err)
+	return s.next.SetUserRole(ctx, userId, role)
+}
+
+func (s *tracedStore) SetAPIKey(ctx context.Context, key model.APIKey) (err error) {
+	ctx, span := s.start(ctx, "SetAPIKey")
+	defer endSpan(span, &err)
+	return s.next.SetAPIKey(ctx, key)
+}
+
+func (s *tracedStore) GetAPIKey(ctx context.Context, keyId string) (result *model.APIKey, err error) {
+	ctx, span := s.start(ctx, "GetAPIKey")
+	defer endSpan(span, &err)
+	return s.next.GetAPIKey(ctx, keyId)
+}
+
+func (s *tracedStore) ListAPIKeys(ctx context.Context, userId string) (result []model.APIKey, err error) {
+	ctx, span := s.start(ctx, "ListAPIKeys")
+	defer endSpan(span, &err)
+	return s.next.ListAPIKeys(ctx, userId)
+}
+
+func (s *tracedStore) TouchAPIKey(ctx context.Context, keyId string, usedAt time.Time) (err error) {
+	ctx, span := s.start(ctx, "TouchAPIKey")
+	defer endSpan(span, &err)
+	return s.next.TouchAPIKey(ctx, keyId, usedAt)
+}
+
+func (s *tracedStore) RemoveAPIKey(ctx context.Context, keyId string) (err error) {
+	ctx, span := s.start(ctx, "RemoveAPIKey")
+	defer endSpan(span, &err)
+	return s.next.RemoveAPIKey(ctx, keyId)
+}
+
+func (s *tracedStore) GetDataVersion(ctx context.Context) (result int, err error) {
+	ctx, span := s.start(ctx, "GetDataVersion")
+	defer endSpan(span, &err)
+	return s.next.GetDataVersion(ctx)
+}
+
+func (s *tracedStore) SetDataVersion(ctx context.Context, version int) (err error) {
+	ctx, span := s.start(ctx, "SetDataVersion")
+	defer endSpan(span, &err)
+	return s.next.SetDataVersion(ctx, version)
+}
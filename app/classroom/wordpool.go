@@ -2,16 +2,23 @@ package classroom
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/fx"
 
+	"github.com/vladazn/danish/app/metrics"
 	"github.com/vladazn/danish/app/model"
 	"github.com/vladazn/danish/common/rand"
 )
 
+// ErrPoolNotFound is returned for users whose learning pool was not built
+// yet.
+var ErrPoolNotFound = newError(ErrNotFound, "pool_not_found", "learning pool not found")
+
 //go:generate go run go.uber.org/mock/mockgen@latest -source=wordpool.go -destination=private/mocks/rand_mock.go -package=mocks
 type Rand interface {
 	IntN(n int) int
@@ -21,27 +28,34 @@ type Rand interface {
 type WordPool struct {
 	storage Firestore
 	rand    Rand
+	metrics *metrics.Metrics
 }
 
 type NewWordPoolParams struct {
 	fx.In
-	Rand  *rand.Random
-	Store *FirebaseStore
+	Rand    *rand.Random
+	Store   Firestore
+	Metrics *metrics.Metrics
 }
 
 func NewWordPool(p NewWordPoolParams) *WordPool {
 	return &WordPool{
 		rand:    p.Rand,
 		storage: p.Store,
+		metrics: p.Metrics,
 	}
 }
 
 func (wp *WordPool) buildPool(ctx context.Context, userId string) (*model.Pool, error) {
+	ctx, span := tracer.Start(ctx, "WordPool.buildPool")
+	defer span.End()
+
 	pool := &model.Pool{}
 	vocabs, err := wp.storage.FetchUserVocabulary(ctx, userId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch vocab to build pool: %w", err)
 	}
+	span.SetAttributes(attribute.Int("pool.scanned_vocab", len(vocabs)))
 	now := time.Now()
 
 	filtered := []model.Vocab{}
@@ -70,13 +84,18 @@ func (wp *WordPool) buildPool(ctx context.Context, userId string) (*model.Pool,
 	if err != nil {
 		return nil, fmt.Errorf("failed to update pool: %w", err)
 	}
+	wp.metrics.ObservePoolBuild(len(pool.Vocabs))
+	span.SetAttributes(attribute.Int("pool.size", len(pool.Vocabs)))
 
 	return pool, nil
 }
 
 func (wp *WordPool) GetBatch(ctx context.Context, userId string) (*model.Batch, error) {
+	ctx, span := tracer.Start(ctx, "WordPool.GetBatch")
+	defer span.End()
+
 	pool, err := wp.storage.FetchUserPool(ctx, userId)
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrPoolNotFound) {
 		return nil, fmt.Errorf("failed to fetch pool: %w", err)
 	}
 
@@ -92,7 +111,12 @@ func (wp *WordPool) GetBatch(ctx context.Context, userId string) (*model.Batch,
 	return &batch, nil
 }
 
+// RemoveFromPool drops the reviewed forms from the user's pool. It fails
+// with ErrPoolNotFound if the user has no pool.
 func (wp *WordPool) RemoveFromPool(ctx context.Context, userId string, vocab []model.Vocab) error {
+	ctx, span := tracer.Start(ctx, "WordPool.RemoveFromPool")
+	defer span.End()
+
 	pool, err := wp.storage.FetchUserPool(ctx, userId)
 	if err != nil {
 		return fmt.Errorf("failed get user pool: %w", err)
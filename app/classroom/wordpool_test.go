@@ -117,11 +117,11 @@ func TestWordPool_GetBatch(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "build new pool when existing pool is nil",
+			name: "build new pool when there is no pool",
 			setupMock: func(mockStore *mocks.MockFirestore, mockRand *mocks.MockRand) {
 				mockStore.EXPECT().
 					FetchUserPool(gomock.Any(), userId).
-					Return(nil, nil)
+					Return(nil, ErrPoolNotFound)
 				mockStore.EXPECT().
 					FetchUserVocabulary(gomock.Any(), userId).
 					Return([]model.Vocab{
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/backup"
+	"github.com/vladazn/danish/app/server"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+// commands is filled in init because the help command lists it.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"serve", "start the HTTP server (default)", runServe},
+		{"import", "import vocab from a CSV, TSV or Anki file into a user", runImport},
+		{"export", "export a user's vocab as CSV, TSV, Anki or an account archive", runExport},
+		{"stats", "show vocab, set and pool counts per user", runStats},
+		{"migrate", "bring stored data up to date with the current model", runMigrate},
+		{"seed", "add the starter vocabulary to a user", runSeed},
+		{"review", "practice a user's vocab in the terminal", runReview},
+		{"user", "list or delete users and manage roles (user list, user delete, user role)", runUser},
+		{"backup", "dump all users to a backup file", runBackup},
+		{"restore", "restore all users from a backup file", runRestore},
+		{"token", "mint a development token for the local JWT verifier", runToken},
+		{"help", "show this help", runHelp},
+	}
+}
+
+func runCommand(name string, args []string) error {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(args)
+		}
+	}
+
+	printUsage(os.Stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: danish [command] [flags]")
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.usage)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "danish <command> -h" for the flags of a command.`)
+}
+
+func runHelp(args []string) error {
+	printUsage(os.Stdout)
+	return nil
+}
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	app := fx.New(
+		core,
+		server.Module,
+	)
+	app.Run()
+	return app.Err()
+}
+
+// runWith starts the core modules plus opts without the HTTP server and
+// calls fn once everything is connected.
+func runWith(fn func(ctx context.Context) error, opts ...fx.Option) (err error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := fx.New(core, fx.Options(opts...), fx.NopLogger)
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, app.Stop(context.Background()))
+	}()
+
+	return fn(ctx)
+}
+
+// required returns an error naming the first flag, by name, with an empty
+// value.
+func required(command string, flags map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(flags)) {
+		if strings.TrimSpace(flags[name]) == "" {
+			return fmt.Errorf("%s: -%s is required", command, name)
+		}
+	}
+	return nil
+}
+
+func runBackup(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := flags.String("out", "", "backup file to write, e.g. backup.jsonl.gz")
+	resume := flags.Bool("resume", false, "keep the users of an unfinished backup and continue with the rest")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("backup", map[string]string{"out": *out}); err != nil {
+		return err
+	}
+
+	var svc *backup.Service
+	return runWith(func(ctx context.Context) error {
+		stats, err := svc.Backup(ctx, *out, *resume)
+		if err != nil {
+			return fmt.Errorf("backup: %w", err)
+		}
+		fmt.Printf("backed up %d users (%d vocab), kept %d from an earlier run\n",
+			stats.Users, stats.Vocab, stats.Skipped)
+		return nil
+	}, backup.Module, fx.Populate(&svc))
+}
+
+func runRestore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	in := flags.String("in", "", "backup file to restore")
+	resume := flags.Bool("resume", false, "skip users restored by an unfinished restore and redo the ones it started")
+	verifyOnly := flags.Bool("verify", false, "only check the backup's checksums")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("restore", map[string]string{"in": *in}); err != nil {
+		return err
+	}
+
+	if *verifyOnly {
+		users, err := backup.Verify(*in)
+		if err != nil {
+			return fmt.Errorf("restore: %w", err)
+		}
+		fmt.Printf("backup is valid, %d users\n", users)
+		return nil
+	}
+
+	var svc *backup.Service
+	return runWith(func(ctx context.Context) error {
+		stats, err := svc.Restore(ctx, *in, *resume)
+		if err != nil {
+			return fmt.Errorf("restore: %w", err)
+		}
+		fmt.Printf("restored %d users (%d vocab), skipped %d restored earlier\n",
+			stats.Users, stats.Vocab, stats.Skipped)
+		return nil
+	}, backup.Module, fx.Populate(&svc))
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/seed"
+	"github.com/vladazn/danish/app/transfer"
+	"github.com/vladazn/danish/common/userid"
+)
+
+const (
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+	formatAnki = "anki"
+	formatJSON = "json"
+)
+
+// formatOf returns the explicit format, or else guesses it from the file
+// extension.
+func formatOf(format, path string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		return formatTSV
+	case ".apkg":
+		return formatAnki
+	case ".json":
+		return formatJSON
+	default:
+		return formatCSV
+	}
+}
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	user := flags.String("user", "", "id of the user to import into")
+	file := flags.String("file", "", "file to import")
+	format := flags.String("format", "", "csv, tsv or anki (default: from the file extension)")
+	mapping := flags.String("mapping", "", "column or field mapping as JSON")
+	dryRun := flags.Bool("dry-run", false, "only report what would be imported")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("import", map[string]string{"user": *user, "file": *file}); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	var rows []transfer.Row
+	switch f := formatOf(*format, *file); f {
+	case formatCSV, formatTSV:
+		delimiter, _ := transfer.Delimiter(f)
+		var m *transfer.CSVMapping
+		if *mapping != "" {
+			m = &transfer.CSVMapping{}
+			if err := json.Unmarshal([]byte(*mapping), m); err != nil {
+				return fmt.Errorf("import: invalid mapping: %w", err)
+			}
+		}
+		rows, err = transfer.ReadCSV(bytes.NewReader(data), delimiter, m)
+	case formatAnki:
+		var m *transfer.AnkiMapping
+		if *mapping != "" {
+			m = &transfer.AnkiMapping{}
+			if err := json.Unmarshal([]byte(*mapping), m); err != nil {
+				return fmt.Errorf("import: invalid mapping: %w", err)
+			}
+		}
+		rows, err = transfer.ReadAnki(bytes.NewReader(data), int64(len(data)), m)
+	default:
+		return fmt.Errorf("import: unsupported format %q", f)
+	}
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	var dict *classroom.Dictionary
+	return runWith(func(ctx context.Context) error {
+		ctx = userid.ToCtx(ctx, *user)
+		results, err := dict.ImportWords(ctx, transfer.Valid(rows), *dryRun)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+
+		printImportReport(os.Stdout, transfer.NewImportReport(rows, results, *dryRun))
+		return nil
+	}, fx.Populate(&dict))
+}
+
+func printImportReport(w io.Writer, report transfer.ImportReport) {
+	for _, result := range report.Results {
+		if result.Status == model.ImportStatusRejected {
+			fmt.Fprintf(w, "line %d rejected: %s\n", result.Line, result.Reason)
+		}
+	}
+
+	prefix := "imported"
+	if report.DryRun {
+		prefix = "dry run, would import"
+	}
+	fmt.Fprintf(w, "%s: %d created, %d merged, %d rejected\n",
+		prefix, report.Created, report.Merged, report.Rejected)
+}
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	user := flags.String("user", "", "id of the user to export")
+	out := flags.String("out", "", "file to write (default: standard output)")
+	format := flags.String("format", "", "csv, tsv, anki or json (default: from the file extension)")
+	setIdStr := flags.String("set", "", "only export the vocab of this set (csv, tsv and anki)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("export", map[string]string{"user": *user}); err != nil {
+		return err
+	}
+
+	var setId uuid.UUID
+	if *setIdStr != "" {
+		var err error
+		if setId, err = uuid.Parse(*setIdStr); err != nil {
+			return fmt.Errorf("export: invalid set id: %w", err)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var (
+		dict    *classroom.Dictionary
+		sets    *classroom.SetService
+		account *classroom.AccountService
+	)
+	return runWith(func(ctx context.Context) error {
+		ctx = userid.ToCtx(ctx, *user)
+
+		f := formatOf(*format, *out)
+		if f == formatJSON {
+			archive, err := account.Export(ctx, *user)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(archive)
+		}
+
+		deckName := "Danish"
+		var vocabs []model.Vocab
+		if setId != uuid.Nil {
+			vocabSet, setVocabs, err := sets.ExportSet(ctx, *user, setId)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+			deckName = vocabSet.Name
+			vocabs = setVocabs
+		} else {
+			var err error
+			if vocabs, err = dict.GetAllWords(ctx); err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+		}
+
+		switch f {
+		case formatCSV, formatTSV:
+			delimiter, _ := transfer.Delimiter(f)
+			return transfer.WriteCSV(w, delimiter, vocabs)
+		case formatAnki:
+			return transfer.WriteAnki(w, deckName, vocabs)
+		default:
+			return fmt.Errorf("export: unsupported format %q", f)
+		}
+	}, fx.Populate(&dict, &sets, &account))
+}
+
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	user := flags.String("user", "", "id of the user to seed")
+	dryRun := flags.Bool("dry-run", false, "only report what would be added")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("seed", map[string]string{"user": *user}); err != nil {
+		return err
+	}
+
+	vocabs, err := seed.Vocab()
+	if err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+
+	var dict *classroom.Dictionary
+	return runWith(func(ctx context.Context) error {
+		ctx = userid.ToCtx(ctx, *user)
+		results, err := dict.ImportWords(ctx, vocabs, *dryRun)
+		if err != nil {
+			return fmt.Errorf("seed: %w", err)
+		}
+
+		rows := make([]transfer.Row, len(vocabs))
+		for i, vocab := range vocabs {
+			rows[i] = transfer.Row{Line: i + 2, Vocab: vocab}
+		}
+		printImportReport(os.Stdout, transfer.NewImportReport(rows, results, *dryRun))
+		return nil
+	}, fx.Populate(&dict))
+}
@@ -0,0 +1,164 @@
+// Package health reports whether the service is alive and whether its
+// dependencies are usable, for liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+// Check tests a single dependency.
+type Check struct {
+	Name string
+	Func func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of a Check.
+type CheckResult struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+}
+
+// Report is the readiness of the service. Checks is left out while
+// draining, since the answer is not-ready regardless.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Ready reports whether the service should get traffic.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// Public returns the report with only the status of every check. Errors
+// and latencies of dependencies are for the logs, not for anyone who can
+// reach the probe.
+func (r Report) Public() Report {
+	if r.Checks == nil {
+		return r
+	}
+	checks := make(map[string]CheckResult, len(r.Checks))
+	for name, result := range r.Checks {
+		checks[name] = CheckResult{Status: result.Status}
+	}
+	return Report{Status: r.Status, Checks: checks}
+}
+
+// Checker runs the readiness checks. A report is reused for the cache TTL,
+// so probes coming in quickly do not each load the dependencies.
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	cacheTTL time.Duration
+	log      *zap.Logger
+	draining atomic.Bool
+
+	mu        sync.Mutex
+	last      Report
+	checkedAt time.Time
+}
+
+func NewChecker(timeout time.Duration, cacheTTL time.Duration, log *zap.Logger, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout, cacheTTL: cacheTTL, log: log}
+}
+
+// Drain makes every following Ready fail, for the time a shutdown takes.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready returns the report of the last run of the checks if it is within
+// the cache TTL, and otherwise runs them again. Callers coming in during a
+// run wait for its report.
+func (c *Checker) Ready(ctx context.Context) Report {
+	if c.draining.Load() {
+		return Report{Status: StatusDraining}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < c.cacheTTL {
+		return c.last
+	}
+
+	// The report is shared, so a caller going away must not fail it.
+	c.last = c.runAll(context.WithoutCancel(ctx))
+	c.checkedAt = time.Now()
+	return c.last
+}
+
+// runAll runs all checks concurrently, each with the check timeout.
+func (c *Checker) runAll(ctx context.Context) Report {
+	results := make([]CheckResult, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(c.checks))}
+	for i, check := range c.checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailing
+			c.log.Warn("Readiness check failed",
+				zap.String("check", check.Name), zap.String("error", results[i].Error))
+		}
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Func(ctx)
+	result := CheckResult{Status: StatusOK, LatencyMs: time.Since(start).Milliseconds()}
+	if err == nil && ctx.Err() != nil {
+		// The check ignored its context and finished late.
+		err = ctx.Err()
+	}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Error = fmt.Sprintf("timed out after %s", c.timeout)
+		}
+	}
+	return result
+}
+
+// DirCheck checks that dir exists and is a directory, for the local
+// storage backend.
+func DirCheck(dir string) func(ctx context.Context) error {
+	return func(context.Context) error {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	ctx := context.Background()
+	ok := Check{Name: "storage", Func: func(context.Context) error { return nil }}
+
+	t.Run("all checks pass", func(t *testing.T) {
+		report := NewChecker(time.Second, 0, zap.NewNop(), ok).Ready(ctx)
+		require.True(t, report.Ready())
+		require.Equal(t, StatusOK, report.Checks["storage"].Status)
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+		failing := Check{Name: "auth", Func: func(context.Context) error { return errors.New("unreachable") }}
+
+		report := NewChecker(time.Second, 0, zap.NewNop(), ok, failing).Ready(ctx)
+		require.False(t, report.Ready())
+		require.Equal(t, StatusFailing, report.Status)
+		require.Equal(t, StatusOK, report.Checks["storage"].Status)
+		require.Equal(t, StatusFailing, report.Checks["auth"].Status)
+		require.Equal(t, "unreachable", report.Checks["auth"].Error)
+	})
+
+	t.Run("slow check times out", func(t *testing.T) {
+		slow := Check{Name: "auth", Func: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+
+		report := NewChecker(10*time.Millisecond, 0, zap.NewNop(), slow).Ready(ctx)
+		require.False(t, report.Ready())
+		require.Equal(t, "timed out after 10ms", report.Checks["auth"].Error)
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		checker := NewChecker(time.Second, 0, zap.NewNop(), ok)
+		checker.Drain()
+
+		report := checker.Ready(ctx)
+		require.False(t, report.Ready())
+		require.Equal(t, Report{Status: StatusDraining}, report)
+	})
+}
+
+func TestChecker_ReadyCache(t *testing.T) {
+	ctx := context.Background()
+	var runs atomic.Int32
+	counted := Check{Name: "storage", Func: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}}
+
+	t.Run("report is reused within the TTL", func(t *testing.T) {
+		runs.Store(0)
+		checker := NewChecker(time.Second, time.Hour, zap.NewNop(), counted)
+
+		for range 3 {
+			require.True(t, checker.Ready(ctx).Ready())
+		}
+		require.EqualValues(t, 1, runs.Load())
+	})
+
+	t.Run("checks run again after the TTL", func(t *testing.T) {
+		runs.Store(0)
+		checker := NewChecker(time.Second, time.Millisecond, zap.NewNop(), counted)
+
+		checker.Ready(ctx)
+		time.Sleep(2 * time.Millisecond)
+		checker.Ready(ctx)
+		require.EqualValues(t, 2, runs.Load())
+	})
+
+	t.Run("a canceled caller does not fail the report", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		waiting := Check{Name: "storage", Func: func(ctx context.Context) error { return ctx.Err() }}
+
+		require.True(t, NewChecker(time.Second, time.Hour, zap.NewNop(), waiting).Ready(canceled).Ready())
+	})
+
+	t.Run("draining is not cached", func(t *testing.T) {
+		checker := NewChecker(time.Second, time.Hour, zap.NewNop(), counted)
+		require.True(t, checker.Ready(ctx).Ready())
+
+		checker.Drain()
+		require.Equal(t, StatusDraining, checker.Ready(ctx).Status)
+	})
+}
+
+func TestReport_Public(t *testing.T) {
+	report := Report{Status: StatusFailing, Checks: map[string]CheckResult{
+		"storage": {Status: StatusOK, LatencyMs: 3},
+		"auth":    {Status: StatusFailing, Error: "dial tcp 10.0.0.1:443: connection refused", LatencyMs: 2000},
+	}}
+
+	require.Equal(t, Report{Status: StatusFailing, Checks: map[string]CheckResult{
+		"storage": {Status: StatusOK},
+		"auth":    {Status: StatusFailing},
+	}}, report.Public())
+	require.Equal(t, "dial tcp 10.0.0.1:443: connection refused", report.Checks["auth"].Error)
+	require.Equal(t, Report{Status: StatusDraining}, Report{Status: StatusDraining}.Public())
+}
+
+func TestDirCheck(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, DirCheck(dir)(context.Background()))
+	require.Error(t, DirCheck(dir+"/missing")(context.Background()))
+}
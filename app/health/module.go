@@ -0,0 +1,48 @@
+package health
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/storage"
+	"github.com/vladazn/danish/config"
+)
+
+var Module = fx.Module(
+	"health",
+	fx.Provide(
+		NewDependencyChecker,
+	),
+)
+
+type NewDependencyCheckerParams struct {
+	fx.In
+	Cfg        *config.HealthConfig
+	StorageCfg *config.StorageConfig
+	Client     *storage.FirestoreClient
+	Verifier   auth.TokenVerifier
+	Logger     *zap.Logger
+}
+
+// NewDependencyChecker checks the storage backend and, if it depends on a
+// remote provider, the token verifier.
+func NewDependencyChecker(p NewDependencyCheckerParams) (*Checker, error) {
+	var checks []Check
+	switch p.StorageCfg.Backend {
+	case config.StorageBackendFirestore:
+		checks = append(checks, Check{Name: "storage", Func: p.Client.Ping})
+	case config.StorageBackendLocal:
+		checks = append(checks, Check{Name: "storage", Func: DirCheck(p.StorageCfg.LocalPath)})
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", p.StorageCfg.Backend)
+	}
+
+	if pinger, ok := p.Verifier.(auth.Pinger); ok {
+		checks = append(checks, Check{Name: "auth", Func: pinger.Ping})
+	}
+
+	return NewChecker(p.Cfg.CheckTimeout, p.Cfg.CacheTTL, p.Logger.With(zap.String("type", "health")), checks...), nil
+}
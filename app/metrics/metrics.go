@@ -0,0 +1,179 @@
+// Package metrics exposes the Prometheus metrics of the service.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "danish"
+
+// Ratings of the forms in a batch result.
+const (
+	RatingCorrect = "correct"
+	RatingMistake = "mistake"
+)
+
+// Metrics holds the Prometheus collectors of the service. Its methods do
+// nothing on a nil *Metrics, so services built without it in tests need no
+// special casing.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	grpcCalls     *prometheus.CounterVec
+	grpcDuration  *prometheus.HistogramVec
+	storeCalls    *prometheus.CounterVec
+	storeErrors   *prometheus.CounterVec
+	storeDuration *prometheus.HistogramVec
+	poolRebuilds  prometheus.Counter
+	poolSize      prometheus.Histogram
+	batchResults  *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by method and route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		grpcCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "calls_total",
+			Help:      "gRPC calls by full method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "call_duration_seconds",
+			Help:      "Latency of gRPC calls by full method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		storeCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "calls_total",
+			Help:      "Storage calls by method of the Firestore interface.",
+		}, []string{"method"}),
+		storeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "errors_total",
+			Help:      "Failed storage calls by method of the Firestore interface.",
+		}, []string{"method"}),
+		storeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "call_duration_seconds",
+			Help:      "Latency of storage calls by method of the Firestore interface.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		poolRebuilds: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "rebuilds_total",
+			Help:      "Learning pools built from a user's vocabulary.",
+		}),
+		poolSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "size_vocab",
+			Help:      "Vocab in a freshly built learning pool.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		batchResults: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "batch",
+			Name:      "results_total",
+			Help:      "Reviewed forms of batch results by rating.",
+		}, []string{"rating"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.grpcCalls,
+		m.grpcDuration,
+		m.storeCalls,
+		m.storeErrors,
+		m.storeDuration,
+		m.poolRebuilds,
+		m.poolSize,
+		m.batchResults,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTP records a served request. route is the pattern the request
+// matched, so ids in paths do not blow up the number of series.
+func (m *Metrics) ObserveHTTP(method string, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveGRPC records a served call. method is the full method name, as
+// in "/danish.v1.DictionaryService/GetWord", and code its status code.
+func (m *Metrics) ObserveGRPC(method string, code string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.grpcCalls.WithLabelValues(method, code).Inc()
+	m.grpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// ObserveStoreCall records a call of a storage method.
+func (m *Metrics) ObserveStoreCall(method string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.storeCalls.WithLabelValues(method).Inc()
+	m.storeDuration.WithLabelValues(method).Observe(duration.Seconds())
+	if err != nil {
+		m.storeErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// ObservePoolBuild records a rebuilt learning pool of size vocab.
+func (m *Metrics) ObservePoolBuild(size int) {
+	if m == nil {
+		return
+	}
+	m.poolRebuilds.Inc()
+	m.poolSize.Observe(float64(size))
+}
+
+// ObserveBatchResult records forms reviewed with the given rating.
+func (m *Metrics) ObserveBatchResult(rating string, forms int) {
+	if m == nil || forms == 0 {
+		return
+	}
+	m.batchResults.WithLabelValues(rating).Add(float64(forms))
+}
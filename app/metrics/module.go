@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/config"
+)
+
+var Module = fx.Module(
+	"metrics",
+	fx.Provide(
+		New,
+	),
+)
+
+type HooksParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Cfg       *config.MetricsServerConfig
+	Metrics   *Metrics
+	Logger    *zap.Logger
+}
+
+// RegisterHooks serves /metrics on the internal port of the config. Only
+// the server invokes it, so command-line modes leave the port free.
+func RegisterHooks(p HooksParams) {
+	if !p.Cfg.Enabled {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", p.Metrics.Handler())
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", p.Cfg.Port),
+		Handler: mux,
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen for metrics: %w", err)
+			}
+
+			go func() {
+				p.Logger.Info("Starting metrics server on " + lis.Addr().String())
+				if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+					p.Logger.Error("Error serving metrics", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			p.Logger.Info("Stopping metrics server...")
+			return srv.Shutdown(ctx)
+		},
+	})
+}
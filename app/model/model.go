@@ -1,9 +1,12 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/common/validate"
 )
 
 var levelToInterval = map[int]time.Duration{
@@ -87,6 +90,133 @@ type Vocab struct {
 	PartOfSpeech PartOfSpeech `json:"part_of_speech"`
 	Forms        []VocabForm  `json:"forms"`
 	PausedUntil  *time.Time   `json:"pause_until,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	// DueAt is the earliest time one of the forms is due for review. It is
+	// kept up to date on every write so storage can sort on it.
+	DueAt time.Time `json:"due_at"`
+}
+
+var knownPartsOfSpeech = map[PartOfSpeech]bool{
+	PartOfSpeechUnknown:     true,
+	PartOfSpeechNoun:        true,
+	PartOfSpeechVerb:        true,
+	PortOfSpeechNumeral:     true,
+	PartOfSpeechAdjective:   true,
+	PartOfSpeechAdverb:      true,
+	PartOfSpeechPronoun:     true,
+	PartOfSpeechPreposition: true,
+	PartOfSpeechConjunction: true,
+	PartOfSpeechQuestion:    true,
+}
+
+// Validate checks that the vocab can be stored. It returns a
+// *validate.Error naming the fields by their JSON paths.
+func (v *Vocab) Validate() error {
+	var val validate.Validator
+	val.Required("definition", v.Definition)
+	val.Check(v.PartOfSpeech == "" || knownPartsOfSpeech[v.PartOfSpeech],
+		"part_of_speech", "is not a known part of speech")
+
+	seen := make(map[uuid.UUID]bool, len(v.Forms))
+	for i, form := range v.Forms {
+		field := validate.Index("forms", i)
+		val.Nested(field, form.Validate())
+		val.Check(!seen[form.Id], validate.Join(field, "id"), "is used by another form")
+		seen[form.Id] = true
+	}
+
+	return val.Err()
+}
+
+// Form returns the form with the given id.
+func (v *Vocab) Form(formId uuid.UUID) (VocabForm, bool) {
+	for _, form := range v.Forms {
+		if form.Id == formId {
+			return form, true
+		}
+	}
+
+	return VocabForm{}, false
+}
+
+// ReplaceForm replaces the form with the same id, reporting whether it was found.
+func (v *Vocab) ReplaceForm(form VocabForm) bool {
+	for i, vocabForm := range v.Forms {
+		if vocabForm.Id == form.Id {
+			v.Forms[i] = form
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveForm removes the form with the given id, reporting whether it was found.
+func (v *Vocab) RemoveForm(formId uuid.UUID) bool {
+	for i, form := range v.Forms {
+		if form.Id == formId {
+			v.Forms = append(v.Forms[:i], v.Forms[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Level returns the lowest level among the vocab forms.
+func (v *Vocab) Level() int {
+	if len(v.Forms) == 0 {
+		return 0
+	}
+
+	level := v.Forms[0].Level
+	for _, form := range v.Forms[1:] {
+		level = min(level, form.Level)
+	}
+
+	return level
+}
+
+// NextDue returns the earliest time one of the reviewable forms becomes due,
+// or the zero time if the vocab has no reviewable forms.
+func (v *Vocab) NextDue() time.Time {
+	var due time.Time
+	for _, form := range v.Forms {
+		if !form.Reviewable() {
+			continue
+		}
+		if formDue := form.DueAt(); due.IsZero() || formDue.Before(due) {
+			due = formDue
+		}
+	}
+
+	return due
+}
+
+// MergeForms adds the forms of other to v. A form with the same id, or of
+// the same kind with a value that normalizes the same way, is a duplicate;
+// the copy with more progress is kept under the id and value of v's form.
+func (v *Vocab) MergeForms(other Vocab, normalize func(string) string) {
+	for _, otherForm := range other.Forms {
+		merged := false
+		for i, form := range v.Forms {
+			sameForm := form.Id == otherForm.Id ||
+				form.Form == otherForm.Form && normalize(form.Value) == normalize(otherForm.Value)
+			if !sameForm {
+				continue
+			}
+			if otherForm.MoreProgressThan(form) {
+				otherForm.Id = form.Id
+				otherForm.Value = form.Value
+				v.Forms[i] = otherForm
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			v.Forms = append(v.Forms, otherForm)
+		}
+	}
 }
 
 func (v *Vocab) CanBeAddedToQueue(now time.Time) bool {
@@ -129,17 +259,65 @@ type VocabForm struct {
 	SuccessInRow int       `json:"success_in_row"`
 }
 
-func (v *VocabForm) CanBeAddedToQueue(t time.Time) bool {
-	if v.Form == "definite_singular" || v.Form == "definite_plural" {
-		return false
+// Validate checks that the form can be stored.
+func (v *VocabForm) Validate() error {
+	var val validate.Validator
+	val.Required("value", v.Value)
+	val.Range("level", v.Level, 0, 5)
+	return val.Err()
+}
+
+// MoreProgressThan reports whether the form is further along than other,
+// comparing level, then the success streak, then the last success.
+func (v *VocabForm) MoreProgressThan(other VocabForm) bool {
+	if v.Level != other.Level {
+		return v.Level > other.Level
+	}
+	if v.SuccessInRow != other.SuccessInRow {
+		return v.SuccessInRow > other.SuccessInRow
 	}
 
-	interval := time.Hour
+	return v.LastSuccess.After(other.LastSuccess)
+}
+
+// Reviewable reports whether the form is ever asked in a review.
+func (v *VocabForm) Reviewable() bool {
+	return v.Form != "definite_singular" && v.Form != "definite_plural"
+}
+
+// Interval returns the time between reviews at the form's level.
+func (v *VocabForm) Interval() time.Duration {
 	if i, ok := levelToInterval[v.Level]; ok {
-		interval = i
+		return i
+	}
+
+	return time.Hour
+}
+
+// DueAt returns the time the form becomes due for review.
+func (v *VocabForm) DueAt() time.Time {
+	return v.LastSuccess.Add(v.Interval())
+}
+
+// LevelForInterval returns the highest level whose review interval is at
+// most d, for progress coming from other spaced repetition tools.
+func LevelForInterval(d time.Duration) int {
+	level := 0
+	for l, interval := range levelToInterval {
+		if interval <= d && l > level {
+			level = l
+		}
+	}
+
+	return level
+}
+
+func (v *VocabForm) CanBeAddedToQueue(t time.Time) bool {
+	if !v.Reviewable() {
+		return false
 	}
 
-	return v.LastSuccess.Add(interval).Before(t)
+	return v.DueAt().Before(t)
 }
 
 type Pool struct {
@@ -147,12 +325,323 @@ type Pool struct {
 	Vocabs    []Vocab   `json:"vocabs"`
 }
 
+// RemoveVocab drops the vocab with the given id from the pool, reporting
+// whether it was present.
+func (p *Pool) RemoveVocab(vocabId uuid.UUID) bool {
+	for i, vocab := range p.Vocabs {
+		if vocab.Id == vocabId {
+			p.Vocabs = append(p.Vocabs[:i], p.Vocabs[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
 type Batch struct {
 	Vocabs []Vocab `json:"vocabs"`
 }
 
 type VocabSet struct {
-	Id        uuid.UUID   `json:"id"`
-	Name      string      `json:"name"`
-	VocabIds  []uuid.UUID `json:"vocab_ids"`
+	Id       uuid.UUID   `json:"id"`
+	Name     string      `json:"name"`
+	VocabIds []uuid.UUID `json:"vocab_ids"`
+}
+
+// PublishedSet is a read-only snapshot of a user's vocab set that others
+// can find by its share id, preview and clone. The snapshot carries the
+// vocab content only, never the owner's progress.
+type PublishedSet struct {
+	// Id is the share link id.
+	Id          string    `json:"id"`
+	OwnerId     string    `json:"owner_id,omitempty"`
+	SourceSetId uuid.UUID `json:"source_set_id,omitempty"`
+	Name        string    `json:"name"`
+	VocabCount  int       `json:"vocab_count"`
+	// Vocabs is left out of listings.
+	Vocabs      []Vocab   `json:"vocabs,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// CloneResult describes a published set copied into a user's library.
+type CloneResult struct {
+	Set     VocabSet `json:"set"`
+	Created int      `json:"created"`
+	Merged  int      `json:"merged"`
+}
+
+// Class is a group of students taught by one teacher. Students join with
+// the invite code.
+type Class struct {
+	Id         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	TeacherId  string    `json:"teacher_id"`
+	InviteCode string    `json:"invite_code,omitempty"`
+	StudentIds []string  `json:"student_ids,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Assignment is a vocab set a teacher assigned to a class. The set's vocab
+// is copied into the library of every student as a set of their own.
+type Assignment struct {
+	Id         uuid.UUID `json:"id"`
+	ClassId    uuid.UUID `json:"class_id"`
+	SetId      uuid.UUID `json:"set_id"`
+	Name       string    `json:"name"`
+	DueAt      time.Time `json:"due_at"`
+	VocabCount int       `json:"vocab_count"`
+	// Vocabs is the snapshot given to students joining later. It is
+	// stored apart from the assignment and left out of listings.
+	Vocabs    []Vocab   `json:"vocabs,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// Copies maps student ids to their copy of the assignment. Each copy
+	// is stored on its own. Only the teacher sees all of them.
+	Copies map[string]AssignmentCopy `json:"copies,omitempty"`
+}
+
+// AssignmentCopy is the set an assignment was copied into in a student's
+// library.
+type AssignmentCopy struct {
+	SetId    uuid.UUID   `json:"set_id"`
+	VocabIds []uuid.UUID `json:"vocab_ids"`
+}
+
+// AssignmentProgress is a student's progress on an assignment, taken from
+// the levels of the assigned forms in the student's library.
+type AssignmentProgress struct {
+	AssignmentId uuid.UUID `json:"assignment_id"`
+	Forms        int       `json:"forms"`
+	// Learned counts forms that reached at least level 1.
+	Learned      int     `json:"learned"`
+	AverageLevel float64 `json:"average_level"`
+	Percent      int     `json:"percent"`
+	Overdue      bool    `json:"overdue"`
+}
+
+// StudentProgress holds a student's progress on every assignment of a
+// class.
+type StudentProgress struct {
+	StudentId   string               `json:"student_id"`
+	Assignments []AssignmentProgress `json:"assignments"`
+}
+
+// ClassDashboard shows a teacher how every student of a class is doing.
+type ClassDashboard struct {
+	Class       Class             `json:"class"`
+	Assignments []Assignment      `json:"assignments"`
+	Students    []StudentProgress `json:"students"`
+}
+
+// Role decides what a user may do. Every role includes the ones before
+// it: teachers are learners as well, admins are teachers.
+type Role string
+
+const (
+	RoleLearner Role = "learner"
+	RoleTeacher Role = "teacher"
+	RoleAdmin   Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleLearner: 1,
+	RoleTeacher: 2,
+	RoleAdmin:   3,
+}
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	return roleRanks[r] > 0
+}
+
+// Includes reports whether a user with role r may act as other.
+func (r Role) Includes(other Role) bool {
+	return other.Valid() && roleRanks[r] >= roleRanks[other]
+}
+
+// HighestRole returns the role including all others, or "" if none of the
+// roles is valid.
+func HighestRole(roles ...Role) Role {
+	var highest Role
+	for _, role := range roles {
+		if roleRanks[role] > roleRanks[highest] {
+			highest = role
+		}
+	}
+	return highest
+}
+
+// APIKeyScope limits what requests an API key may make.
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead allows requests that do not change anything.
+	APIKeyScopeRead APIKeyScope = "read"
+	// APIKeyScopeWrite allows every request and includes read.
+	APIKeyScopeWrite APIKeyScope = "write"
+)
+
+// Valid reports whether s is a known scope.
+func (s APIKeyScope) Valid() bool {
+	return s == APIKeyScopeRead || s == APIKeyScopeWrite
+}
+
+// APIKey is a long-lived key a user creates for scripts and integrations.
+// Only a hash of the key is stored, the key itself is shown once.
+type APIKey struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+	Hash   string `json:"-"`
+	// Scopes holds read, write or both.
+	Scopes []APIKeyScope `json:"scopes"`
+	// RateLimit is the number of requests allowed per minute.
+	RateLimit  int       `json:"rate_limit"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// Allows reports whether the key has the scope. Write includes read.
+func (k APIKey) Allows(scope APIKeyScope) bool {
+	return slices.Contains(k.Scopes, scope) ||
+		scope == APIKeyScopeRead && slices.Contains(k.Scopes, APIKeyScopeWrite)
+}
+
+// CreatedAPIKey is an API key right after creation, the only time the key
+// itself is known.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// SearchEntry is the search index record of a vocab. Terms holds every
+// normalized prefix of the vocab's definition and form values.
+type SearchEntry struct {
+	VocabId uuid.UUID `json:"vocab_id"`
+	Terms   []string  `json:"terms"`
+}
+
+// VocabBatch groups vocab writes so storage can apply them in bulk.
+type VocabBatch struct {
+	Set   []Vocab
+	Index []SearchEntry
+	// Delete removes the vocab together with its search entry.
+	Delete []uuid.UUID
+}
+
+type BulkStatus string
+
+const (
+	BulkStatusCreated BulkStatus = "created"
+	BulkStatusUpdated BulkStatus = "updated"
+	BulkStatusDeleted BulkStatus = "deleted"
+	BulkStatusFailed  BulkStatus = "failed"
+)
+
+// BulkResult is the outcome of one item of a bulk request.
+type BulkResult struct {
+	Index  int        `json:"index"`
+	Id     uuid.UUID  `json:"id"`
+	Status BulkStatus `json:"status"`
+	Reason string     `json:"reason,omitempty"`
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusCreated  ImportStatus = "created"
+	ImportStatusMerged   ImportStatus = "merged"
+	ImportStatusRejected ImportStatus = "rejected"
+)
+
+// ImportResult is the outcome of one imported item. Merged items point to
+// the vocab they were merged into.
+type ImportResult struct {
+	Index int `json:"index"`
+	// Line is the line of the item in an imported file, if any.
+	Line   int          `json:"line,omitempty"`
+	Id     uuid.UUID    `json:"id"`
+	Status ImportStatus `json:"status"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// AccountArchiveVersion is the version of the account archive format
+// written by exports. Imports accept archives up to this version.
+const AccountArchiveVersion = 2
+
+// AccountArchive holds everything stored for a user. The search index is
+// left out as it is rebuilt from the vocab on import. Published sets, API
+// keys, the role and class memberships are exported for the user's records
+// since version 2; imports do not restore them.
+type AccountArchive struct {
+	Version       int               `json:"version"`
+	ExportedAt    time.Time         `json:"exported_at"`
+	Vocab         []Vocab           `json:"vocab"`
+	Sets          []VocabSet        `json:"sets"`
+	Pool          *Pool             `json:"pool,omitempty"`
+	PublishedSets []PublishedSet    `json:"published_sets,omitempty"`
+	APIKeys       []APIKey          `json:"api_keys,omitempty"`
+	Role          Role              `json:"role,omitempty"`
+	Classes       []ClassMembership `json:"classes,omitempty"`
+}
+
+// ClassMembership is a class the user teaches or attends, without the
+// other students of the class.
+type ClassMembership struct {
+	ClassId uuid.UUID `json:"class_id"`
+	Name    string    `json:"name"`
+	Teacher bool      `json:"teacher"`
+}
+
+// UserStats summarizes the stored data of a user.
+type UserStats struct {
+	UserId         string               `json:"user_id"`
+	Vocab          int                  `json:"vocab"`
+	Forms          int                  `json:"forms"`
+	Sets           int                  `json:"sets"`
+	PoolSize       int                  `json:"pool_size"`
+	Due            int                  `json:"due"`
+	Paused         int                  `json:"paused"`
+	ByLevel        map[int]int          `json:"by_level"`
+	ByPartOfSpeech map[PartOfSpeech]int `json:"by_part_of_speech"`
+}
+
+// Add adds the counts of other, for totals over several users.
+func (s *UserStats) Add(other UserStats) {
+	s.Vocab += other.Vocab
+	s.Forms += other.Forms
+	s.Sets += other.Sets
+	s.PoolSize += other.PoolSize
+	s.Due += other.Due
+	s.Paused += other.Paused
+	if s.ByLevel == nil {
+		s.ByLevel = map[int]int{}
+	}
+	for level, n := range other.ByLevel {
+		s.ByLevel[level] += n
+	}
+	if s.ByPartOfSpeech == nil {
+		s.ByPartOfSpeech = map[PartOfSpeech]int{}
+	}
+	for pos, n := range other.ByPartOfSpeech {
+		s.ByPartOfSpeech[pos] += n
+	}
+}
+
+// RepairReport counts what a repair of a user's data changed, or would
+// change on a dry run.
+type RepairReport struct {
+	UserId string `json:"user_id"`
+	DryRun bool   `json:"dry_run"`
+	Vocab  int    `json:"vocab"`
+	// MissingIds counts vocab with forms that had no id.
+	MissingIds int `json:"missing_ids"`
+	// StaleDates counts vocab without a creation time or with an outdated
+	// due time.
+	StaleDates int `json:"stale_dates"`
+	// Reindexed counts vocab whose search entry was rewritten.
+	Reindexed int `json:"reindexed"`
+	// DanglingSetIds counts set entries pointing at removed vocab.
+	DanglingSetIds int `json:"dangling_set_ids"`
+	// DanglingPool counts pool entries pointing at removed vocab.
+	DanglingPool int `json:"dangling_pool"`
 }
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/common/validate"
+)
+
+type VocabSort string
+
+const (
+	VocabSortNone       VocabSort = ""
+	VocabSortDefinition VocabSort = "definition"
+	VocabSortCreatedAt  VocabSort = "created_at"
+	VocabSortDueAt      VocabSort = "due_at"
+)
+
+// VocabQuery describes which page of a user's vocabulary to fetch. Zero
+// values mean "no restriction".
+type VocabQuery struct {
+	PartOfSpeech PartOfSpeech
+	MinLevel     *int
+	MaxLevel     *int
+	Paused       *bool
+	SetId        uuid.UUID
+
+	SortBy     VocabSort
+	Descending bool
+
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+	// Limit is the page size, 0 returns everything after the cursor.
+	Limit int
+}
+
+type VocabPage struct {
+	Vocabs     []Vocab `json:"vocabs"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+const MaxVocabPageSize = 500
+
+// Validate checks that the query options are consistent. Fields are
+// named after the query parameters of the list endpoint.
+func (q VocabQuery) Validate() error {
+	var val validate.Validator
+	switch q.SortBy {
+	case VocabSortNone, VocabSortDefinition, VocabSortCreatedAt, VocabSortDueAt:
+	default:
+		val.Add("sort", "is not a known sort")
+	}
+
+	if q.MinLevel != nil && q.MaxLevel != nil {
+		val.Check(*q.MinLevel <= *q.MaxLevel, "min_level", "is greater than max_level")
+	}
+
+	val.Range("limit", q.Limit, 0, MaxVocabPageSize)
+
+	if q.Cursor != "" {
+		_, err := uuid.Parse(q.Cursor)
+		val.Check(err == nil, "cursor", "is not a valid cursor")
+	}
+
+	return val.Err()
+}
+
+// Matches applies the filters a backend could not express natively. inSet
+// holds the vocab ids of q.SetId and is ignored when no set is requested.
+func (q VocabQuery) Matches(v Vocab, inSet map[uuid.UUID]bool) bool {
+	if q.PartOfSpeech != "" && v.PartOfSpeech != q.PartOfSpeech {
+		return false
+	}
+
+	if q.MinLevel != nil && v.Level() < *q.MinLevel {
+		return false
+	}
+
+	if q.MaxLevel != nil && v.Level() > *q.MaxLevel {
+		return false
+	}
+
+	if q.Paused != nil && (v.PausedUntil != nil) != *q.Paused {
+		return false
+	}
+
+	if q.SetId != uuid.Nil && !inSet[v.Id] {
+		return false
+	}
+
+	return true
+}
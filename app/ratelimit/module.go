@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/config"
+)
+
+var Module = fx.Module(
+	"ratelimit",
+	fx.Provide(
+		NewLimiter,
+	),
+)
+
+type NewLimiterParams struct {
+	fx.In
+	Cfg *config.RateLimitConfig
+}
+
+// NewLimiter returns the limiter backend selected in the config.
+func NewLimiter(p NewLimiterParams) (Limiter, error) {
+	switch p.Cfg.Backend {
+	case config.RateLimitBackendMemory:
+		return NewMemoryLimiter(), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", p.Cfg.Backend)
+	}
+}
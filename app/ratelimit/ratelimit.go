@@ -0,0 +1,160 @@
+// Package ratelimit implements token-bucket request budgets behind an
+// interface, so a backend shared by all instances can replace the
+// in-process one.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/vladazn/danish/config"
+)
+
+// Budget allows Requests per Period. Budgets are token buckets holding
+// Requests tokens, so a full budget may be spent at once.
+type Budget struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseBudget parses a budget written as <requests>/<period>, e.g. 30/1m.
+func ParseBudget(s string) (Budget, error) {
+	requestsStr, periodStr, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Budget{}, fmt.Errorf("budget %q is not of the form <requests>/<period>", s)
+	}
+	requests, err := strconv.Atoi(requestsStr)
+	if err != nil || requests <= 0 {
+		return Budget{}, fmt.Errorf("budget %q has an invalid number of requests", s)
+	}
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return Budget{}, fmt.Errorf("budget %q has an invalid period", s)
+	}
+	return Budget{Requests: requests, Period: period}, nil
+}
+
+func (b Budget) String() string {
+	return fmt.Sprintf("%d/%s", b.Requests, b.Period)
+}
+
+// Budgets are the budgets of the config. Routes are keyed by method and
+// route pattern without the API version, as in "POST /vocab".
+type Budgets struct {
+	IP     Budget
+	User   Budget
+	Routes map[string]Budget
+}
+
+// ParseBudgets parses the budgets of cfg.
+func ParseBudgets(cfg *config.RateLimitConfig) (Budgets, error) {
+	ip, err := ParseBudget(cfg.IP)
+	if err != nil {
+		return Budgets{}, fmt.Errorf("invalid ip rate limit: %w", err)
+	}
+	user, err := ParseBudget(cfg.User)
+	if err != nil {
+		return Budgets{}, fmt.Errorf("invalid user rate limit: %w", err)
+	}
+
+	routes := make(map[string]Budget, len(cfg.Routes))
+	for route, budgetStr := range cfg.Routes {
+		budget, err := ParseBudget(budgetStr)
+		if err != nil {
+			return Budgets{}, fmt.Errorf("invalid rate limit of %s: %w", route, err)
+		}
+		routes[strings.Join(strings.Fields(route), " ")] = budget
+	}
+
+	return Budgets{IP: ip, User: user, Routes: routes}, nil
+}
+
+// ForwardedClient returns the client address of an X-Forwarded-For value
+// written by the given number of trusted proxies. Every proxy appends the
+// address it was reached from, so the client is the entry that many hops
+// from the right; entries further left come from the client and can be
+// forged. It returns "" if the value has no such entry.
+func ForwardedClient(forwarded string, proxies int) string {
+	entries := strings.Split(forwarded, ",")
+	if proxies < 1 || proxies > len(entries) {
+		return ""
+	}
+	return strings.TrimSpace(entries[len(entries)-proxies])
+}
+
+// Result is the outcome of taking a request from a budget.
+type Result struct {
+	Allowed bool
+	// Remaining is the number of requests left right now.
+	Remaining int
+	// RetryAfter is how long to wait before the request would be allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter takes requests from the budgets of keys.
+type Limiter interface {
+	Take(ctx context.Context, key string, budget Budget) (Result, error)
+}
+
+// sweepInterval is how often the memory limiter drops idle buckets.
+const sweepInterval = time.Minute
+
+// MemoryLimiter keeps the buckets in process. Buckets that refilled
+// completely are dropped, since a fresh bucket behaves the same.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	budget   Budget
+	lastUsed time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: map[string]*bucket{}, lastSweep: time.Now()}
+}
+
+func (l *MemoryLimiter) Take(ctx context.Context, key string, budget Budget) (Result, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok || b.budget != budget {
+		b = &bucket{
+			limiter: rate.NewLimiter(rate.Every(budget.Period/time.Duration(budget.Requests)), budget.Requests),
+			budget:  budget,
+		}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	l.mu.Unlock()
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return Result{RetryAfter: delay}, nil
+	}
+	return Result{Allowed: true, Remaining: int(b.limiter.TokensAt(now))}, nil
+}
+
+// sweep drops the buckets that had time to refill. l.mu must be held.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.lastUsed) > b.budget.Period {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
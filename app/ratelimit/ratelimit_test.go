@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/config"
+)
+
+func TestParseBudget(t *testing.T) {
+	budget, err := ParseBudget("30/1m")
+	require.NoError(t, err)
+	require.Equal(t, Budget{Requests: 30, Period: time.Minute}, budget)
+
+	for _, s := range []string{"", "30", "0/1m", "-1/1m", "x/1m", "30/", "30/0s", "30/minute"} {
+		_, err := ParseBudget(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseBudgets(t *testing.T) {
+	budgets, err := ParseBudgets(&config.RateLimitConfig{
+		IP:     "300/1m",
+		User:   "120/1m",
+		Routes: map[string]string{"POST  /vocab": "30/1m"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Budgets{
+		IP:     Budget{Requests: 300, Period: time.Minute},
+		User:   Budget{Requests: 120, Period: time.Minute},
+		Routes: map[string]Budget{"POST /vocab": {Requests: 30, Period: time.Minute}},
+	}, budgets)
+
+	_, err = ParseBudgets(&config.RateLimitConfig{IP: "300/1m", User: "120/1m", Routes: map[string]string{"POST /vocab": "30"}})
+	require.ErrorContains(t, err, "invalid rate limit of POST /vocab")
+}
+
+func TestForwardedClient(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwarded string
+		proxies   int
+		want      string
+	}{
+		{"one proxy", "10.0.0.1", 1, "10.0.0.1"},
+		{"forged entries are skipped", "1.2.3.4, 10.0.0.1", 1, "10.0.0.1"},
+		{"two proxies", "1.2.3.4, 10.0.0.1, 192.168.0.1", 2, "10.0.0.1"},
+		{"fewer entries than proxies", "10.0.0.1", 2, ""},
+		{"no proxies", "10.0.0.1", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ForwardedClient(tt.forwarded, tt.proxies))
+		})
+	}
+}
+
+func TestMemoryLimiter_Take(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewMemoryLimiter()
+	budget := Budget{Requests: 3, Period: time.Minute}
+
+	for i := 2; i >= 0; i-- {
+		result, err := limiter.Take(ctx, "user:1", budget)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, i, result.Remaining)
+	}
+
+	result, err := limiter.Take(ctx, "user:1", budget)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Greater(t, result.RetryAfter, time.Duration(0))
+	require.LessOrEqual(t, result.RetryAfter, budget.Period/3)
+
+	t.Run("keys have their own buckets", func(t *testing.T) {
+		result, err := limiter.Take(ctx, "user:2", budget)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+	})
+
+	t.Run("changed budgets start full", func(t *testing.T) {
+		result, err := limiter.Take(ctx, "user:1", Budget{Requests: 5, Period: time.Minute})
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 4, result.Remaining)
+	})
+}
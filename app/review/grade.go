@@ -0,0 +1,66 @@
+package review
+
+import (
+	"strings"
+
+	"github.com/vladazn/danish/common/textnorm"
+)
+
+// Grade is the result of comparing a typed answer with a form's value.
+type Grade int
+
+const (
+	// GradeWrong is an answer that does not match.
+	GradeWrong Grade = iota
+	// GradeAccepted is an answer that only differs in accents, Danish
+	// letters typed as ae/oe/aa, case or spacing. It counts as correct.
+	// Dropping the Danish letter to its base letter (ø as o) does not,
+	// since that often spells another word, such as "far" for "får".
+	GradeAccepted
+	// GradeCorrect is an exact answer.
+	GradeCorrect
+)
+
+// Correct reports whether the grade counts as a success.
+func (g Grade) Correct() bool {
+	return g != GradeWrong
+}
+
+// GradeAnswer compares answer with the expected value. A value may list
+// alternatives separated by "/" or ",", any of which is accepted.
+func GradeAnswer(answer, expected string) Grade {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return GradeWrong
+	}
+
+	best := GradeWrong
+	for _, alternative := range alternatives(expected) {
+		switch {
+		case strings.EqualFold(answer, alternative):
+			return GradeCorrect
+		case sameSpelling(answer, alternative):
+			best = GradeAccepted
+		}
+	}
+
+	return best
+}
+
+// sameSpelling reports whether a and b are equal once accents are removed,
+// allowing each Danish letter to be typed transliterated (ø as oe).
+func sameSpelling(a, b string) bool {
+	return textnorm.Normalize(a) == textnorm.Normalize(b)
+}
+
+func alternatives(value string) []string {
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == '/' || r == ',' })
+	result := []string{strings.TrimSpace(value)}
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" && part != result[0] {
+			result = append(result, part)
+		}
+	}
+
+	return result
+}
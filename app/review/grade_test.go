@@ -0,0 +1,33 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGradeAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		expected string
+		grade    Grade
+	}{
+		{name: "exact", answer: "bøger", expected: "bøger", grade: GradeCorrect},
+		{name: "case and spaces", answer: "  Huset ", expected: "huset", grade: GradeCorrect},
+		{name: "transliterated", answer: "boeger", expected: "bøger", grade: GradeAccepted},
+		{name: "accents", answer: "cafe", expected: "café", grade: GradeAccepted},
+		{name: "base letter", answer: "boger", expected: "bøger", grade: GradeWrong},
+		{name: "dropped letter makes another word", answer: "far", expected: "får", grade: GradeWrong},
+		{name: "alternative", answer: "år", expected: "år/året", grade: GradeCorrect},
+		{name: "alternative spelled loosely", answer: "aaret", expected: "år, året", grade: GradeAccepted},
+		{name: "wrong", answer: "bog", expected: "bøger", grade: GradeWrong},
+		{name: "empty", answer: "", expected: "bøger", grade: GradeWrong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.grade, GradeAnswer(tt.answer, tt.expected))
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package review
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"review",
+	fx.Provide(
+		NewSession,
+	),
+)
@@ -0,0 +1,101 @@
+package review
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+type color string
+
+const (
+	reset  color = "\033[0m"
+	bold   color = "\033[1m"
+	dim    color = "\033[2m"
+	red    color = "\033[31m"
+	green  color = "\033[32m"
+	yellow color = "\033[33m"
+)
+
+type printer struct {
+	out   io.Writer
+	color bool
+}
+
+func (p *printer) paint(c color, s string) string {
+	if !p.color {
+		return s
+	}
+	return string(c) + s + string(reset)
+}
+
+func (p *printer) line(c color, format string, args ...any) {
+	fmt.Fprintln(p.out, p.paint(c, fmt.Sprintf(format, args...)))
+}
+
+// FormName turns a form kind such as "definite_singular" into
+// "definite singular".
+func FormName(form string) string {
+	return strings.ReplaceAll(form, "_", " ")
+}
+
+func (p *printer) prompt(vocab model.Vocab, form model.VocabForm) {
+	fmt.Fprintf(p.out, "%s %s %s: ",
+		p.paint(bold, vocab.Definition),
+		p.paint(dim, "("+string(vocab.PartOfSpeech)+")"),
+		FormName(form.Form))
+}
+
+func (p *printer) feedback(grade Grade, expected string) {
+	switch grade {
+	case GradeCorrect:
+		p.line(green, "  ✓ correct")
+	case GradeAccepted:
+		p.line(yellow, "  ✓ accepted, spelled %s", expected)
+	default:
+		p.line(red, "  ✗ wrong, the answer is %s", expected)
+	}
+}
+
+func (p *printer) summary(s Summary) {
+	fmt.Fprintln(p.out)
+	if s.Asked() == 0 {
+		p.line(dim, "No answers recorded.")
+		return
+	}
+
+	score := 100 * (s.Correct + s.Accepted) / s.Asked()
+	c := green
+	if score < 80 {
+		c = yellow
+	}
+	if score < 50 {
+		c = red
+	}
+
+	p.line(bold, "Session summary")
+	fmt.Fprintf(p.out, "  %d batches, %d forms in %s\n", s.Batches, s.Asked(), s.Duration.Round(time.Second))
+	fmt.Fprintf(p.out, "  %s correct, %s accepted, %s wrong\n",
+		p.paint(green, fmt.Sprint(s.Correct)),
+		p.paint(yellow, fmt.Sprint(s.Accepted)),
+		p.paint(red, fmt.Sprint(s.Wrong)))
+	fmt.Fprintf(p.out, "  score %s\n", p.paint(c, fmt.Sprintf("%d%%", score)))
+
+	if len(s.Misses) == 0 {
+		return
+	}
+	fmt.Fprintln(p.out)
+	p.line(bold, "To practice again")
+	for _, miss := range s.Misses {
+		answer := miss.Answer
+		if answer == "" {
+			answer = "(empty)"
+		}
+		fmt.Fprintf(p.out, "  %s, %s: %s %s\n",
+			miss.Definition, FormName(miss.Form),
+			p.paint(green, miss.Expected), p.paint(dim, "you typed "+answer))
+	}
+}
@@ -0,0 +1,200 @@
+package review
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+// quit ends a session early when typed as an answer.
+const quit = ":q"
+
+// Miss is a form answered wrongly.
+type Miss struct {
+	Definition string
+	Form       string
+	Answer     string
+	Expected   string
+}
+
+// Summary counts the answers of a session.
+type Summary struct {
+	Batches  int
+	Correct  int
+	Accepted int
+	Wrong    int
+	Misses   []Miss
+	Duration time.Duration
+}
+
+// Asked returns the number of answered forms.
+func (s Summary) Asked() int {
+	return s.Correct + s.Accepted + s.Wrong
+}
+
+// Session runs review rounds in a terminal: it asks for every form of a
+// batch, grades the typed answers and records the progress just like the
+// batch result endpoint does.
+type Session struct {
+	dict *classroom.Dictionary
+	pool *classroom.WordPool
+}
+
+type NewSessionParams struct {
+	fx.In
+	Dict *classroom.Dictionary
+	Pool *classroom.WordPool
+}
+
+func NewSession(p NewSessionParams) *Session {
+	return &Session{
+		dict: p.Dict,
+		pool: p.Pool,
+	}
+}
+
+// Options configures a session run.
+type Options struct {
+	// Rounds is the number of batches to review.
+	Rounds int
+	// Color enables ANSI colors in the output.
+	Color bool
+}
+
+// Run reviews up to opts.Rounds batches of userId, reading answers from in
+// and writing prompts and feedback to out. It stops early when the pool is
+// empty, on end of input or when the user types ":q"; progress of answered
+// forms is always recorded.
+func (s *Session) Run(
+	ctx context.Context, userId string, in io.Reader, out io.Writer, opts Options,
+) (Summary, error) {
+	ctx = userid.ToCtx(ctx, userId)
+	started := time.Now()
+	p := printer{out: out, color: opts.Color}
+	lines := readLines(in)
+
+	var summary Summary
+	for round := 0; round < max(opts.Rounds, 1); round++ {
+		batch, err := s.pool.GetBatch(ctx, userId)
+		if err != nil {
+			return summary, fmt.Errorf("failed to get batch: %w", err)
+		}
+		if len(batch.Vocabs) == 0 {
+			if round == 0 {
+				p.line(dim, "Nothing to review right now.")
+			}
+			break
+		}
+
+		summary.Batches++
+		withoutMistakes, withMistakes, stopped, err := s.askBatch(ctx, batch, lines, &p, &summary)
+		if err != nil {
+			return summary, err
+		}
+
+		if err := s.pool.RemoveFromPool(ctx, userId, withoutMistakes); err != nil {
+			return summary, fmt.Errorf("failed to update pool: %w", err)
+		}
+		if err := s.dict.RegisterProgress(ctx, withoutMistakes, withMistakes); err != nil {
+			return summary, fmt.Errorf("failed to register progress: %w", err)
+		}
+
+		if stopped {
+			break
+		}
+	}
+
+	summary.Duration = time.Since(started)
+	p.summary(summary)
+
+	return summary, nil
+}
+
+// askBatch asks every form of the batch and splits the answered forms into
+// vocab answered without and with mistakes. stopped is set when the user
+// quit or the input ended.
+func (s *Session) askBatch(
+	ctx context.Context, batch *model.Batch, lines <-chan string, p *printer, summary *Summary,
+) (withoutMistakes, withMistakes []model.Vocab, stopped bool, err error) {
+	for _, vocab := range batch.Vocabs {
+		right := model.Vocab{Id: vocab.Id, Definition: vocab.Definition, PartOfSpeech: vocab.PartOfSpeech}
+		wrong := right
+
+		for _, form := range vocab.Forms {
+			p.prompt(vocab, form)
+
+			var answer string
+			select {
+			case <-ctx.Done():
+				return nil, nil, true, ctx.Err()
+			case line, ok := <-lines:
+				if !ok || strings.TrimSpace(line) == quit {
+					fmt.Fprintln(p.out)
+					stopped = true
+				}
+				answer = line
+			}
+			if stopped {
+				break
+			}
+
+			grade := GradeAnswer(answer, form.Value)
+			p.feedback(grade, form.Value)
+			switch grade {
+			case GradeCorrect:
+				summary.Correct++
+			case GradeAccepted:
+				summary.Accepted++
+			default:
+				summary.Wrong++
+				summary.Misses = append(summary.Misses, Miss{
+					Definition: vocab.Definition,
+					Form:       form.Form,
+					Answer:     strings.TrimSpace(answer),
+					Expected:   form.Value,
+				})
+			}
+
+			if grade.Correct() {
+				right.Forms = append(right.Forms, form)
+			} else {
+				wrong.Forms = append(wrong.Forms, form)
+			}
+		}
+
+		if len(right.Forms) > 0 {
+			withoutMistakes = append(withoutMistakes, right)
+		}
+		if len(wrong.Forms) > 0 {
+			withMistakes = append(withMistakes, wrong)
+		}
+		if stopped {
+			break
+		}
+	}
+
+	return withoutMistakes, withMistakes, stopped, nil
+}
+
+// readLines reads in line by line in the background so that a session can
+// be interrupted while it waits for an answer.
+func readLines(in io.Reader) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
@@ -0,0 +1,117 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/rand"
+)
+
+// scriptedUser answers the prompts of a session by form name, since the
+// pool shuffles the order they are asked in. The input ends when a form
+// has no answers left.
+type scriptedUser struct {
+	out     strings.Builder
+	answers map[string][]string
+	in      *io.PipeWriter
+}
+
+func (u *scriptedUser) Write(p []byte) (int, error) {
+	u.out.Write(p)
+
+	prompt := string(p)
+	if !strings.HasSuffix(prompt, ": ") {
+		return len(p), nil
+	}
+	form := strings.TrimSuffix(prompt[strings.LastIndex(prompt, ") ")+2:], ": ")
+	answers := u.answers[form]
+	if len(answers) == 0 {
+		u.in.Close()
+		return len(p), nil
+	}
+	u.answers[form] = answers[1:]
+	if _, err := fmt.Fprintln(u.in, answers[0]); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func TestSession_Run(t *testing.T) {
+	ctx := context.Background()
+	userId := "user"
+
+	store, err := classroom.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	book := model.Vocab{
+		Id:           uuid.New(),
+		Definition:   "book",
+		PartOfSpeech: model.PartOfSpeechNoun,
+		Forms: []model.VocabForm{
+			{Id: uuid.New(), Form: "indefinite_plural", Value: "bøger"},
+			{Id: uuid.New(), Form: "definite_singular", Value: "bogen"},
+			{Id: uuid.New(), Form: "definite_plural", Value: "bøgerne"},
+		},
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, store.AddVocabulary(ctx, userId, book))
+	require.NoError(t, store.UpdatePool(ctx, userId, &model.Pool{CreatedAt: time.Now(), Vocabs: []model.Vocab{book}}))
+
+	session := NewSession(NewSessionParams{
+		Dict: classroom.NewDictionary(classroom.NewDictionaryParams{Store: store}),
+		Pool: classroom.NewWordPool(classroom.NewWordPoolParams{Rand: rand.New(), Store: store, Metrics: metrics.New()}),
+	})
+
+	in, pipe := io.Pipe()
+	t.Cleanup(func() { pipe.Close() })
+	user := &scriptedUser{
+		answers: map[string][]string{
+			"indefinite plural": {"boeger"},
+			"definite singular": {"bogen"},
+			// The missed form is asked again in the second round.
+			"definite plural": {"bogerne", quit},
+		},
+		in: pipe,
+	}
+
+	summary, err := session.Run(ctx, userId, in, user, Options{Rounds: 3})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, summary.Batches)
+	require.Equal(t, 1, summary.Correct)
+	require.Equal(t, 1, summary.Accepted)
+	require.Equal(t, 1, summary.Wrong)
+	require.Equal(t, []Miss{
+		{Definition: "book", Form: "definite_plural", Answer: "bogerne", Expected: "bøgerne"},
+	}, summary.Misses)
+
+	out := user.out.String()
+	require.Contains(t, out, "book (noun) indefinite plural: ")
+	require.Contains(t, out, "✓ accepted, spelled bøger")
+	require.Contains(t, out, "✓ correct")
+	require.Contains(t, out, "✗ wrong, the answer is bøgerne")
+	require.Contains(t, out, "1 correct, 1 accepted, 1 wrong")
+
+	// The forms answered right are progressed and leave the pool.
+	vocab, err := store.GetVocab(ctx, userId, book.Id)
+	require.NoError(t, err)
+	for _, form := range vocab.Forms[:2] {
+		require.Equal(t, 1, form.SuccessInRow, form.Form)
+		require.False(t, form.LastSuccess.IsZero(), form.Form)
+	}
+
+	pool, err := store.FetchUserPool(ctx, userId)
+	require.NoError(t, err)
+	require.Len(t, pool.Vocabs, 1)
+	require.Equal(t, []model.VocabForm{book.Forms[2]}, pool.Vocabs[0].Forms)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/app/review"
+	"github.com/vladazn/danish/config"
+)
+
+func runReview(args []string) error {
+	flags := flag.NewFlagSet("review", flag.ContinueOnError)
+	user := flags.String("user", "", "id of the user to review")
+	local := flags.String("local", "", "review from the local store in this directory instead of the configured backend")
+	rounds := flags.Int("rounds", 1, "number of batches to review")
+	noColor := flags.Bool("no-color", false, "disable colored output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("review", map[string]string{"user": *user}); err != nil {
+		return err
+	}
+
+	// The storage config is read from the environment, so the flag simply
+	// overrides it for this process.
+	if *local != "" {
+		os.Setenv("STORAGE_BACKEND", config.StorageBackendLocal)
+		os.Setenv("STORAGE_LOCAL_PATH", *local)
+	}
+
+	opts := review.Options{
+		Rounds: *rounds,
+		Color:  !*noColor && isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == "",
+	}
+
+	var session *review.Session
+	return runWith(func(ctx context.Context) error {
+		if _, err := session.Run(ctx, *user, os.Stdin, os.Stdout, opts); err != nil {
+			return fmt.Errorf("review: %w", err)
+		}
+		return nil
+	}, review.Module, fx.Populate(&session))
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
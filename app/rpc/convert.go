@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/common/validate"
+)
+
+// Unset timestamps stand for the zero time, which the model uses for
+// "never".
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// parseId parses an optional id, recording a violation if it is malformed.
+func parseId(val *validate.Validator, field string, s string) uuid.UUID {
+	if s == "" {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(s)
+	val.Check(err == nil, field, "is not a valid id")
+	return id
+}
+
+// parseRequiredId parses an id that must be set.
+func parseRequiredId(val *validate.Validator, field string, s string) uuid.UUID {
+	val.Required(field, s)
+	return parseId(val, field, s)
+}
+
+func parseIds(val *validate.Validator, field string, ss []string) []uuid.UUID {
+	ids := make([]uuid.UUID, len(ss))
+	for i, s := range ss {
+		ids[i] = parseRequiredId(val, validate.Index(field, i), s)
+	}
+	return ids
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	ss := make([]string, len(ids))
+	for i, id := range ids {
+		ss[i] = id.String()
+	}
+	return ss
+}
+
+func vocabToPB(v model.Vocab) *pb.Vocab {
+	vocab := &pb.Vocab{
+		Id:           v.Id.String(),
+		Definition:   v.Definition,
+		PartOfSpeech: string(v.PartOfSpeech),
+		Forms:        make([]*pb.VocabForm, len(v.Forms)),
+		CreatedAt:    toTimestamp(v.CreatedAt),
+		DueAt:        toTimestamp(v.DueAt),
+	}
+	for i, form := range v.Forms {
+		vocab.Forms[i] = formToPB(form)
+	}
+	if v.PausedUntil != nil {
+		vocab.PausedUntil = timestamppb.New(*v.PausedUntil)
+	}
+	return vocab
+}
+
+func vocabsToPB(vs []model.Vocab) []*pb.Vocab {
+	vocabs := make([]*pb.Vocab, len(vs))
+	for i, v := range vs {
+		vocabs[i] = vocabToPB(v)
+	}
+	return vocabs
+}
+
+func vocabFromPB(val *validate.Validator, field string, v *pb.Vocab) model.Vocab {
+	vocab := model.Vocab{
+		Id:           parseId(val, validate.Join(field, "id"), v.GetId()),
+		Definition:   v.GetDefinition(),
+		PartOfSpeech: model.PartOfSpeech(v.GetPartOfSpeech()),
+		CreatedAt:    fromTimestamp(v.GetCreatedAt()),
+		DueAt:        fromTimestamp(v.GetDueAt()),
+	}
+	for i, form := range v.GetForms() {
+		vocab.Forms = append(vocab.Forms, formFromPB(val, validate.Index(validate.Join(field, "forms"), i), form))
+	}
+	if v.GetPausedUntil() != nil {
+		pausedUntil := v.GetPausedUntil().AsTime()
+		vocab.PausedUntil = &pausedUntil
+	}
+	return vocab
+}
+
+func vocabsFromPB(val *validate.Validator, field string, vs []*pb.Vocab) []model.Vocab {
+	vocabs := make([]model.Vocab, len(vs))
+	for i, v := range vs {
+		vocabs[i] = vocabFromPB(val, validate.Index(field, i), v)
+	}
+	return vocabs
+}
+
+func formToPB(f model.VocabForm) *pb.VocabForm {
+	return &pb.VocabForm{
+		Id:           f.Id.String(),
+		Value:        f.Value,
+		Form:         f.Form,
+		Level:        int32(f.Level),
+		LastSuccess:  toTimestamp(f.LastSuccess),
+		SuccessInRow: int32(f.SuccessInRow),
+	}
+}
+
+func formFromPB(val *validate.Validator, field string, f *pb.VocabForm) model.VocabForm {
+	return model.VocabForm{
+		Id:           parseId(val, validate.Join(field, "id"), f.GetId()),
+		Value:        f.GetValue(),
+		Form:         f.GetForm(),
+		Level:        int(f.GetLevel()),
+		LastSuccess:  fromTimestamp(f.GetLastSuccess()),
+		SuccessInRow: int(f.GetSuccessInRow()),
+	}
+}
+
+func setToPB(s model.VocabSet) *pb.VocabSet {
+	return &pb.VocabSet{Id: s.Id.String(), Name: s.Name, VocabIds: idStrings(s.VocabIds)}
+}
+
+func batchToPB(b *model.Batch) *pb.Batch {
+	return &pb.Batch{Vocabs: vocabsToPB(b.Vocabs)}
+}
+
+func bulkResultsToPB(rs []model.BulkResult) *pb.BulkResponse {
+	resp := &pb.BulkResponse{Results: make([]*pb.BulkResult, len(rs))}
+	for i, r := range rs {
+		resp.Results[i] = &pb.BulkResult{
+			Index:  int32(r.Index),
+			Id:     r.Id.String(),
+			Status: string(r.Status),
+			Reason: r.Reason,
+		}
+	}
+	return resp
+}
+
+func importResultsToPB(rs []model.ImportResult) *pb.ImportWordsResponse {
+	resp := &pb.ImportWordsResponse{Results: make([]*pb.ImportResult, len(rs))}
+	for i, r := range rs {
+		resp.Results[i] = &pb.ImportResult{
+			Index:  int32(r.Index),
+			Id:     r.Id.String(),
+			Status: string(r.Status),
+			Reason: r.Reason,
+		}
+	}
+	return resp
+}
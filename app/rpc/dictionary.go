@@ -0,0 +1,237 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/common/validate"
+)
+
+// dictionaryServer exposes classroom.Dictionary.
+type dictionaryServer struct {
+	pb.UnimplementedDictionaryServiceServer
+	dict *classroom.Dictionary
+}
+
+func (s *dictionaryServer) AddWord(ctx context.Context, req *pb.AddWordRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocab := vocabFromPB(&val, "vocab", req.GetVocab())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	added, err := s.dict.AddWord(ctx, vocab)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(added), nil
+}
+
+func (s *dictionaryServer) UpdateWord(ctx context.Context, req *pb.UpdateWordRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocab := vocabFromPB(&val, "vocab", req.GetVocab())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	updated, err := s.dict.UpdateWord(ctx, vocab)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(updated), nil
+}
+
+func (s *dictionaryServer) GetWord(ctx context.Context, req *pb.GetWordRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "id", req.GetId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	vocab, err := s.dict.GetWord(ctx, vocabId)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(vocab), nil
+}
+
+func (s *dictionaryServer) ListWords(ctx context.Context, req *pb.ListWordsRequest) (*pb.ListWordsResponse, error) {
+	var val validate.Validator
+	query := model.VocabQuery{
+		PartOfSpeech: model.PartOfSpeech(req.GetPartOfSpeech()),
+		SetId:        parseId(&val, "set_id", req.GetSetId()),
+		SortBy:       model.VocabSort(req.GetSort()),
+		Descending:   req.GetDescending(),
+		Cursor:       req.GetCursor(),
+		Limit:        int(req.GetLimit()),
+		Paused:       req.Paused,
+	}
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if req.MinLevel != nil {
+		minLevel := int(req.GetMinLevel())
+		query.MinLevel = &minLevel
+	}
+	if req.MaxLevel != nil {
+		maxLevel := int(req.GetMaxLevel())
+		query.MaxLevel = &maxLevel
+	}
+
+	page, err := s.dict.ListWords(ctx, query)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.ListWordsResponse{Vocabs: vocabsToPB(page.Vocabs), NextCursor: page.NextCursor}, nil
+}
+
+func (s *dictionaryServer) SearchWords(ctx context.Context, req *pb.SearchWordsRequest) (*pb.SearchWordsResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	vocabs, err := s.dict.Search(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.SearchWordsResponse{Vocabs: vocabsToPB(vocabs)}, nil
+}
+
+func (s *dictionaryServer) RemoveWord(ctx context.Context, req *pb.RemoveWordRequest) (*emptypb.Empty, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "id", req.GetId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.dict.RemoveWord(ctx, vocabId); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *dictionaryServer) PatchWord(ctx context.Context, req *pb.PatchWordRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "id", req.GetId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	vocab, err := s.dict.PatchWord(ctx, vocabId, req.GetPatch())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(vocab), nil
+}
+
+func (s *dictionaryServer) AddForm(ctx context.Context, req *pb.AddFormRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "vocab_id", req.GetVocabId())
+	form := formFromPB(&val, "form", req.GetForm())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	vocab, err := s.dict.AddForm(ctx, vocabId, form)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(vocab), nil
+}
+
+func (s *dictionaryServer) PatchForm(ctx context.Context, req *pb.PatchFormRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "vocab_id", req.GetVocabId())
+	formId := parseRequiredId(&val, "form_id", req.GetFormId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	vocab, err := s.dict.PatchForm(ctx, vocabId, formId, req.GetPatch())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(vocab), nil
+}
+
+func (s *dictionaryServer) RemoveForm(ctx context.Context, req *pb.RemoveFormRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "vocab_id", req.GetVocabId())
+	formId := parseRequiredId(&val, "form_id", req.GetFormId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	vocab, err := s.dict.RemoveForm(ctx, vocabId, formId)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(vocab), nil
+}
+
+func (s *dictionaryServer) MergeWords(ctx context.Context, req *pb.MergeWordsRequest) (*pb.Vocab, error) {
+	var val validate.Validator
+	vocabId := parseRequiredId(&val, "vocab_id", req.GetVocabId())
+	val.Items("vocab_ids", len(req.GetVocabIds()), 1, classroom.MaxBulkSize)
+	duplicateIds := parseIds(&val, "vocab_ids", req.GetVocabIds())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	vocab, err := s.dict.MergeWords(ctx, vocabId, duplicateIds)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return vocabToPB(vocab), nil
+}
+
+func (s *dictionaryServer) BulkSaveWords(ctx context.Context, req *pb.BulkSaveWordsRequest) (*pb.BulkResponse, error) {
+	var val validate.Validator
+	val.Items("vocabs", len(req.GetVocabs()), 0, classroom.MaxBulkSize)
+	vocabs := vocabsFromPB(&val, "vocabs", req.GetVocabs())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	results, err := s.dict.BulkSaveWords(ctx, vocabs)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return bulkResultsToPB(results), nil
+}
+
+func (s *dictionaryServer) BulkRemoveWords(ctx context.Context, req *pb.BulkRemoveWordsRequest) (*pb.BulkResponse, error) {
+	var val validate.Validator
+	val.Items("ids", len(req.GetIds()), 0, classroom.MaxBulkSize)
+	ids := parseIds(&val, "ids", req.GetIds())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	results, err := s.dict.BulkRemoveWords(ctx, ids)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return bulkResultsToPB(results), nil
+}
+
+func (s *dictionaryServer) ImportWords(ctx context.Context, req *pb.ImportWordsRequest) (*pb.ImportWordsResponse, error) {
+	var val validate.Validator
+	val.Items("vocabs", len(req.GetVocabs()), 0, classroom.MaxImportSize)
+	vocabs := vocabsFromPB(&val, "vocabs", req.GetVocabs())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	results, err := s.dict.ImportWords(ctx, vocabs, req.GetDryRun())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return importResultsToPB(results), nil
+}
@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/validate"
+)
+
+// kindCodes maps error kinds to status codes, like kindStatuses of the
+// HTTP server.
+var kindCodes = []struct {
+	kind error
+	code codes.Code
+}{
+	{classroom.ErrNotFound, codes.NotFound},
+	{classroom.ErrValidation, codes.InvalidArgument},
+	{classroom.ErrConflict, codes.AlreadyExists},
+	{classroom.ErrForbidden, codes.PermissionDenied},
+}
+
+// errorReason is the domain of the ErrorInfo details, which carry the
+// error code also sent in problem responses.
+const errorReason = "danish"
+
+// toStatus turns an error of a service into a status error. Errors that
+// are not domain errors are logged and reported without details.
+func toStatus(ctx context.Context, err error) error {
+	var domainErr *classroom.Error
+	if !errors.As(err, &domainErr) {
+		logger.FromCtx(ctx).Error("request failed", zap.Error(err))
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	code := codes.Internal
+	for _, kc := range kindCodes {
+		if errors.Is(domainErr, kc.kind) {
+			code = kc.code
+			break
+		}
+	}
+
+	st := status.New(code, err.Error())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: domainErr.Code, Domain: errorReason}}
+	var validationErr *validate.Error
+	if errors.As(err, &validationErr) {
+		details = append(details, badRequest(validationErr))
+	}
+	if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// invalidArgument reports a request that could not be read.
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	var validationErr *validate.Error
+	if errors.As(err, &validationErr) {
+		if withDetails, detailsErr := st.WithDetails(badRequest(validationErr)); detailsErr == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
+
+// resourceExhausted reports a spent rate limit, with the time to wait in
+// the RetryInfo details.
+func resourceExhausted(message string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, message)
+	if withDetails, detailsErr := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	}); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func badRequest(err *validate.Error) *errdetails.BadRequest {
+	details := &errdetails.BadRequest{}
+	for _, violation := range err.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Message,
+		})
+	}
+	return details
+}
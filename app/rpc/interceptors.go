@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/requestid"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/config"
+)
+
+const requestIdKey = "x-request-id"
+
+// loggingInterceptor gives every call a request id and a logger carrying
+// it, recovers panics and writes a sampled access log like the HTTP
+// server. Failed calls are always logged.
+func loggingInterceptor(log *zap.Logger, cfg *config.LogConfig) grpc.UnaryServerInterceptor {
+	access := log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, time.Second, cfg.AccessSampleFirst, cfg.AccessSampleThereafter)
+	}))
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+
+		requestId := firstValue(ctx, requestIdKey)
+		if !requestid.Valid(requestId) {
+			requestId = uuid.NewString()
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(requestIdKey, requestId))
+
+		fields := []zap.Field{zap.String("request_id", requestId)}
+		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+			fields = append(fields, zap.String("trace_id", spanCtx.TraceID().String()))
+		}
+		ctx = requestid.ToCtx(ctx, requestId)
+		ctx = logger.ToCtx(ctx, log.With(fields...))
+
+		defer func() {
+			if p := recover(); p != nil {
+				log.Error("panic in gRPC call", append(fields, zap.Any("panic", p), zap.Stack("stack"))...)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+
+			code := status.Code(err)
+			fields = append(fields,
+				zap.String("method", info.FullMethod),
+				zap.String("code", code.String()),
+				zap.Duration("duration", time.Since(start)),
+			)
+			switch code {
+			case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+				log.Error("gRPC call", fields...)
+			default:
+				access.Info("gRPC call", fields...)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// metricsInterceptor records every call with its status code.
+func metricsInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.ObserveGRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// readMethods are the calls an API key with the read scope may make.
+var readMethods = map[string]bool{
+	"/danish.v1.DictionaryService/GetWord":     true,
+	"/danish.v1.DictionaryService/ListWords":   true,
+	"/danish.v1.DictionaryService/SearchWords": true,
+	"/danish.v1.WordPoolService/GetBatch":      true,
+	"/danish.v1.SetService/ListSets":           true,
+	"/danish.v1.SetService/GetSetBatch":        true,
+}
+
+// authInterceptor verifies the bearer token or API key of a call, the same
+// way the HTTP server does, and puts the user in the context. API keys are
+// sent in the x-api-key metadata or as bearer token.
+func authInterceptor(verifier auth.TokenVerifier, keys *classroom.APIKeyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		credential := firstValue(ctx, "x-api-key")
+		if credential == "" {
+			authHeader := firstValue(ctx, "authorization")
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization metadata")
+			}
+			credential = strings.TrimPrefix(authHeader, "Bearer ")
+		}
+
+		log := logger.FromCtx(ctx)
+		var uid string
+		if classroom.IsAPIKey(credential) {
+			key, err := authenticateAPIKey(ctx, keys, credential, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			uid = key.UserId
+		} else {
+			token, err := verifier.Verify(ctx, credential)
+			if errors.Is(err, auth.ErrInvalidToken) {
+				log.Error("auth error on validate", zap.Error(err))
+				return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+			}
+			if err != nil {
+				log.Error("failed to verify token", zap.Error(err))
+				return nil, status.Error(codes.Internal, "failed to verify token")
+			}
+			uid = token.UID
+		}
+
+		ctx = userid.ToCtx(ctx, uid)
+		ctx = logger.ToCtx(ctx, log.With(zap.String("uid", uid)))
+		return handler(ctx, req)
+	}
+}
+
+// authenticateAPIKey checks an API key and whether its scope allows the
+// call.
+func authenticateAPIKey(
+	ctx context.Context, keys *classroom.APIKeyService, credential string, method string,
+) (*model.APIKey, error) {
+	key, err := keys.Authenticate(ctx, credential)
+	var rateLimitErr *classroom.RateLimitError
+	switch {
+	case errors.Is(err, classroom.ErrAPIKeyNotFound):
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	case errors.As(err, &rateLimitErr):
+		return nil, resourceExhausted("API key rate limit exceeded", rateLimitErr.RetryAfter)
+	case err != nil:
+		logger.FromCtx(ctx).Error("failed to authenticate api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to authenticate API key")
+	}
+
+	scope := model.APIKeyScopeWrite
+	if readMethods[method] {
+		scope = model.APIKeyScopeRead
+	}
+	if !key.Allows(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key lacks the %s scope", scope)
+	}
+
+	return key, nil
+}
+
+func firstValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,125 @@
+// Package rpc serves the Dictionary, WordPool and SetService operations
+// over gRPC, for clients that prefer typed stubs to the HTTP API.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/config"
+)
+
+var Module = fx.Module(
+	"rpc",
+	fx.Provide(
+		NewServer,
+	),
+	fx.Invoke(
+		RegisterHooks,
+	),
+)
+
+type NewServerParams struct {
+	fx.In
+	Logger   *zap.Logger
+	LogCfg   *config.LogConfig
+	Verifier auth.TokenVerifier
+	APIKeys  *classroom.APIKeyService
+	Dict     *classroom.Dictionary
+	Pool     *classroom.WordPool
+	Set      *classroom.SetService
+	Metrics  *metrics.Metrics
+	RateCfg  *config.RateLimitConfig
+	Limiter  ratelimit.Limiter
+}
+
+// NewServer returns the gRPC server with the interceptors of the HTTP
+// middleware: metrics, logging, rate limits by address and by user, and
+// authentication.
+func NewServer(p NewServerParams) (*grpc.Server, error) {
+	log := p.Logger.With(zap.String("type", "grpc"))
+
+	limits, err := newRateLimits(p.RateCfg, p.Limiter)
+	if err != nil {
+		return nil, err
+	}
+	interceptors := []grpc.UnaryServerInterceptor{
+		metricsInterceptor(p.Metrics),
+		loggingInterceptor(log, p.LogCfg),
+	}
+	if p.RateCfg.Enabled {
+		interceptors = append(interceptors, limits.ipInterceptor)
+	}
+	interceptors = append(interceptors, authInterceptor(p.Verifier, p.APIKeys))
+	if p.RateCfg.Enabled {
+		interceptors = append(interceptors, limits.userInterceptor)
+	}
+
+	srv := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(interceptors...),
+	)
+
+	pb.RegisterDictionaryServiceServer(srv, &dictionaryServer{dict: p.Dict})
+	pb.RegisterWordPoolServiceServer(srv, &wordPoolServer{dict: p.Dict, pool: p.Pool, metrics: p.Metrics})
+	pb.RegisterSetServiceServer(srv, &setServer{set: p.Set})
+
+	return srv, nil
+}
+
+type HooksParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Cfg       *config.GRPCServerConfig
+	Server    *grpc.Server
+	Logger    *zap.Logger
+}
+
+func RegisterHooks(p HooksParams) {
+	if !p.Cfg.Enabled {
+		return
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%d", p.Cfg.Port))
+			if err != nil {
+				return fmt.Errorf("failed to listen for grpc: %w", err)
+			}
+
+			go func() {
+				p.Logger.Info("Starting gRPC server on " + lis.Addr().String())
+				if err := p.Server.Serve(lis); err != nil {
+					p.Logger.Error("Error serving gRPC", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			p.Logger.Info("Stopping gRPC server...")
+			stopped := make(chan struct{})
+			go func() {
+				p.Server.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				p.Server.Stop()
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,1970 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: danish.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VocabForm struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Form          string                 `protobuf:"bytes,3,opt,name=form,proto3" json:"form,omitempty"`
+	Level         int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	LastSuccess   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	SuccessInRow  int32                  `protobuf:"varint,6,opt,name=success_in_row,json=successInRow,proto3" json:"success_in_row,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VocabForm) Reset() {
+	*x = VocabForm{}
+	mi := &file_danish_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VocabForm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VocabForm) ProtoMessage() {}
+
+func (x *VocabForm) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VocabForm.ProtoReflect.Descriptor instead.
+func (*VocabForm) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VocabForm) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VocabForm) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *VocabForm) GetForm() string {
+	if x != nil {
+		return x.Form
+	}
+	return ""
+}
+
+func (x *VocabForm) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *VocabForm) GetLastSuccess() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return nil
+}
+
+func (x *VocabForm) GetSuccessInRow() int32 {
+	if x != nil {
+		return x.SuccessInRow
+	}
+	return 0
+}
+
+type Vocab struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Definition   string                 `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	PartOfSpeech string                 `protobuf:"bytes,3,opt,name=part_of_speech,json=partOfSpeech,proto3" json:"part_of_speech,omitempty"`
+	Forms        []*VocabForm           `protobuf:"bytes,4,rep,name=forms,proto3" json:"forms,omitempty"`
+	// Unset while the vocab is not paused.
+	PausedUntil   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=paused_until,json=pausedUntil,proto3" json:"paused_until,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DueAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vocab) Reset() {
+	*x = Vocab{}
+	mi := &file_danish_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vocab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vocab) ProtoMessage() {}
+
+func (x *Vocab) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vocab.ProtoReflect.Descriptor instead.
+func (*Vocab) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Vocab) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vocab) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *Vocab) GetPartOfSpeech() string {
+	if x != nil {
+		return x.PartOfSpeech
+	}
+	return ""
+}
+
+func (x *Vocab) GetForms() []*VocabForm {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+func (x *Vocab) GetPausedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PausedUntil
+	}
+	return nil
+}
+
+func (x *Vocab) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Vocab) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+type VocabSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	VocabIds      []string               `protobuf:"bytes,3,rep,name=vocab_ids,json=vocabIds,proto3" json:"vocab_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VocabSet) Reset() {
+	*x = VocabSet{}
+	mi := &file_danish_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VocabSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VocabSet) ProtoMessage() {}
+
+func (x *VocabSet) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VocabSet.ProtoReflect.Descriptor instead.
+func (*VocabSet) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VocabSet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VocabSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VocabSet) GetVocabIds() []string {
+	if x != nil {
+		return x.VocabIds
+	}
+	return nil
+}
+
+type Batch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vocabs        []*Vocab               `protobuf:"bytes,1,rep,name=vocabs,proto3" json:"vocabs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Batch) Reset() {
+	*x = Batch{}
+	mi := &file_danish_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batch) ProtoMessage() {}
+
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batch.ProtoReflect.Descriptor instead.
+func (*Batch) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Batch) GetVocabs() []*Vocab {
+	if x != nil {
+		return x.Vocabs
+	}
+	return nil
+}
+
+type BulkResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkResult) Reset() {
+	*x = BulkResult{}
+	mi := &file_danish_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkResult) ProtoMessage() {}
+
+func (x *BulkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkResult.ProtoReflect.Descriptor instead.
+func (*BulkResult) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BulkResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ImportResult is the outcome of one imported vocab. Merged vocab point to
+// the vocab they were merged into.
+type ImportResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id    string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// One of created, merged or rejected.
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	mi := &file_danish_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResult) ProtoMessage() {}
+
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AddWordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vocab         *Vocab                 `protobuf:"bytes,1,opt,name=vocab,proto3" json:"vocab,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWordRequest) Reset() {
+	*x = AddWordRequest{}
+	mi := &file_danish_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWordRequest) ProtoMessage() {}
+
+func (x *AddWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWordRequest.ProtoReflect.Descriptor instead.
+func (*AddWordRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddWordRequest) GetVocab() *Vocab {
+	if x != nil {
+		return x.Vocab
+	}
+	return nil
+}
+
+type UpdateWordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vocab         *Vocab                 `protobuf:"bytes,1,opt,name=vocab,proto3" json:"vocab,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWordRequest) Reset() {
+	*x = UpdateWordRequest{}
+	mi := &file_danish_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWordRequest) ProtoMessage() {}
+
+func (x *UpdateWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWordRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateWordRequest) GetVocab() *Vocab {
+	if x != nil {
+		return x.Vocab
+	}
+	return nil
+}
+
+type GetWordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordRequest) Reset() {
+	*x = GetWordRequest{}
+	mi := &file_danish_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordRequest) ProtoMessage() {}
+
+func (x *GetWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordRequest.ProtoReflect.Descriptor instead.
+func (*GetWordRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetWordRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListWordsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PartOfSpeech string                 `protobuf:"bytes,1,opt,name=part_of_speech,json=partOfSpeech,proto3" json:"part_of_speech,omitempty"`
+	MinLevel     *int32                 `protobuf:"varint,2,opt,name=min_level,json=minLevel,proto3,oneof" json:"min_level,omitempty"`
+	MaxLevel     *int32                 `protobuf:"varint,3,opt,name=max_level,json=maxLevel,proto3,oneof" json:"max_level,omitempty"`
+	Paused       *bool                  `protobuf:"varint,4,opt,name=paused,proto3,oneof" json:"paused,omitempty"`
+	SetId        string                 `protobuf:"bytes,5,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
+	// One of definition, created_at or due_at.
+	Sort       string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending bool   `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+	Cursor     string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 0 returns everything after the cursor.
+	Limit         int32 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWordsRequest) Reset() {
+	*x = ListWordsRequest{}
+	mi := &file_danish_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWordsRequest) ProtoMessage() {}
+
+func (x *ListWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWordsRequest.ProtoReflect.Descriptor instead.
+func (*ListWordsRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWordsRequest) GetPartOfSpeech() string {
+	if x != nil {
+		return x.PartOfSpeech
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetMinLevel() int32 {
+	if x != nil && x.MinLevel != nil {
+		return *x.MinLevel
+	}
+	return 0
+}
+
+func (x *ListWordsRequest) GetMaxLevel() int32 {
+	if x != nil && x.MaxLevel != nil {
+		return *x.MaxLevel
+	}
+	return 0
+}
+
+func (x *ListWordsRequest) GetPaused() bool {
+	if x != nil && x.Paused != nil {
+		return *x.Paused
+	}
+	return false
+}
+
+func (x *ListWordsRequest) GetSetId() string {
+	if x != nil {
+		return x.SetId
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListWordsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWordsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Vocabs []*Vocab               `protobuf:"bytes,1,rep,name=vocabs,proto3" json:"vocabs,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWordsResponse) Reset() {
+	*x = ListWordsResponse{}
+	mi := &file_danish_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWordsResponse) ProtoMessage() {}
+
+func (x *ListWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWordsResponse.ProtoReflect.Descriptor instead.
+func (*ListWordsResponse) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListWordsResponse) GetVocabs() []*Vocab {
+	if x != nil {
+		return x.Vocabs
+	}
+	return nil
+}
+
+func (x *ListWordsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type SearchWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchWordsRequest) Reset() {
+	*x = SearchWordsRequest{}
+	mi := &file_danish_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWordsRequest) ProtoMessage() {}
+
+func (x *SearchWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWordsRequest.ProtoReflect.Descriptor instead.
+func (*SearchWordsRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchWordsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchWordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchWordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vocabs        []*Vocab               `protobuf:"bytes,1,rep,name=vocabs,proto3" json:"vocabs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchWordsResponse) Reset() {
+	*x = SearchWordsResponse{}
+	mi := &file_danish_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWordsResponse) ProtoMessage() {}
+
+func (x *SearchWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWordsResponse.ProtoReflect.Descriptor instead.
+func (*SearchWordsResponse) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchWordsResponse) GetVocabs() []*Vocab {
+	if x != nil {
+		return x.Vocabs
+	}
+	return nil
+}
+
+type RemoveWordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWordRequest) Reset() {
+	*x = RemoveWordRequest{}
+	mi := &file_danish_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWordRequest) ProtoMessage() {}
+
+func (x *RemoveWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWordRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWordRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RemoveWordRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Patches are JSON Merge Patches (RFC 7396) of the vocab or form in the
+// JSON form of the HTTP API.
+type PatchWordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Patch         []byte                 `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchWordRequest) Reset() {
+	*x = PatchWordRequest{}
+	mi := &file_danish_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchWordRequest) ProtoMessage() {}
+
+func (x *PatchWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchWordRequest.ProtoReflect.Descriptor instead.
+func (*PatchWordRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PatchWordRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchWordRequest) GetPatch() []byte {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+type AddFormRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VocabId       string                 `protobuf:"bytes,1,opt,name=vocab_id,json=vocabId,proto3" json:"vocab_id,omitempty"`
+	Form          *VocabForm             `protobuf:"bytes,2,opt,name=form,proto3" json:"form,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddFormRequest) Reset() {
+	*x = AddFormRequest{}
+	mi := &file_danish_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFormRequest) ProtoMessage() {}
+
+func (x *AddFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFormRequest.ProtoReflect.Descriptor instead.
+func (*AddFormRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddFormRequest) GetVocabId() string {
+	if x != nil {
+		return x.VocabId
+	}
+	return ""
+}
+
+func (x *AddFormRequest) GetForm() *VocabForm {
+	if x != nil {
+		return x.Form
+	}
+	return nil
+}
+
+type PatchFormRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VocabId       string                 `protobuf:"bytes,1,opt,name=vocab_id,json=vocabId,proto3" json:"vocab_id,omitempty"`
+	FormId        string                 `protobuf:"bytes,2,opt,name=form_id,json=formId,proto3" json:"form_id,omitempty"`
+	Patch         []byte                 `protobuf:"bytes,3,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchFormRequest) Reset() {
+	*x = PatchFormRequest{}
+	mi := &file_danish_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchFormRequest) ProtoMessage() {}
+
+func (x *PatchFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchFormRequest.ProtoReflect.Descriptor instead.
+func (*PatchFormRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PatchFormRequest) GetVocabId() string {
+	if x != nil {
+		return x.VocabId
+	}
+	return ""
+}
+
+func (x *PatchFormRequest) GetFormId() string {
+	if x != nil {
+		return x.FormId
+	}
+	return ""
+}
+
+func (x *PatchFormRequest) GetPatch() []byte {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+type RemoveFormRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VocabId       string                 `protobuf:"bytes,1,opt,name=vocab_id,json=vocabId,proto3" json:"vocab_id,omitempty"`
+	FormId        string                 `protobuf:"bytes,2,opt,name=form_id,json=formId,proto3" json:"form_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFormRequest) Reset() {
+	*x = RemoveFormRequest{}
+	mi := &file_danish_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFormRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFormRequest) ProtoMessage() {}
+
+func (x *RemoveFormRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFormRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFormRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveFormRequest) GetVocabId() string {
+	if x != nil {
+		return x.VocabId
+	}
+	return ""
+}
+
+func (x *RemoveFormRequest) GetFormId() string {
+	if x != nil {
+		return x.FormId
+	}
+	return ""
+}
+
+type MergeWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VocabId       string                 `protobuf:"bytes,1,opt,name=vocab_id,json=vocabId,proto3" json:"vocab_id,omitempty"`
+	VocabIds      []string               `protobuf:"bytes,2,rep,name=vocab_ids,json=vocabIds,proto3" json:"vocab_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeWordsRequest) Reset() {
+	*x = MergeWordsRequest{}
+	mi := &file_danish_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeWordsRequest) ProtoMessage() {}
+
+func (x *MergeWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeWordsRequest.ProtoReflect.Descriptor instead.
+func (*MergeWordsRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MergeWordsRequest) GetVocabId() string {
+	if x != nil {
+		return x.VocabId
+	}
+	return ""
+}
+
+func (x *MergeWordsRequest) GetVocabIds() []string {
+	if x != nil {
+		return x.VocabIds
+	}
+	return nil
+}
+
+type BulkSaveWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vocabs        []*Vocab               `protobuf:"bytes,1,rep,name=vocabs,proto3" json:"vocabs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkSaveWordsRequest) Reset() {
+	*x = BulkSaveWordsRequest{}
+	mi := &file_danish_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkSaveWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkSaveWordsRequest) ProtoMessage() {}
+
+func (x *BulkSaveWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkSaveWordsRequest.ProtoReflect.Descriptor instead.
+func (*BulkSaveWordsRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BulkSaveWordsRequest) GetVocabs() []*Vocab {
+	if x != nil {
+		return x.Vocabs
+	}
+	return nil
+}
+
+type BulkRemoveWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkRemoveWordsRequest) Reset() {
+	*x = BulkRemoveWordsRequest{}
+	mi := &file_danish_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkRemoveWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkRemoveWordsRequest) ProtoMessage() {}
+
+func (x *BulkRemoveWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkRemoveWordsRequest.ProtoReflect.Descriptor instead.
+func (*BulkRemoveWordsRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BulkRemoveWordsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BulkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BulkResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkResponse) Reset() {
+	*x = BulkResponse{}
+	mi := &file_danish_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkResponse) ProtoMessage() {}
+
+func (x *BulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkResponse.ProtoReflect.Descriptor instead.
+func (*BulkResponse) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BulkResponse) GetResults() []*BulkResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ImportWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vocabs        []*Vocab               `protobuf:"bytes,1,rep,name=vocabs,proto3" json:"vocabs,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportWordsRequest) Reset() {
+	*x = ImportWordsRequest{}
+	mi := &file_danish_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWordsRequest) ProtoMessage() {}
+
+func (x *ImportWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWordsRequest.ProtoReflect.Descriptor instead.
+func (*ImportWordsRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImportWordsRequest) GetVocabs() []*Vocab {
+	if x != nil {
+		return x.Vocabs
+	}
+	return nil
+}
+
+func (x *ImportWordsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportWordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ImportResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportWordsResponse) Reset() {
+	*x = ImportWordsResponse{}
+	mi := &file_danish_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWordsResponse) ProtoMessage() {}
+
+func (x *ImportWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWordsResponse.ProtoReflect.Descriptor instead.
+func (*ImportWordsResponse) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportWordsResponse) GetResults() []*ImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ReviewResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WithoutMistake []*Vocab               `protobuf:"bytes,1,rep,name=without_mistake,json=withoutMistake,proto3" json:"without_mistake,omitempty"`
+	WithMistake    []*Vocab               `protobuf:"bytes,2,rep,name=with_mistake,json=withMistake,proto3" json:"with_mistake,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReviewResult) Reset() {
+	*x = ReviewResult{}
+	mi := &file_danish_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewResult) ProtoMessage() {}
+
+func (x *ReviewResult) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewResult.ProtoReflect.Descriptor instead.
+func (*ReviewResult) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReviewResult) GetWithoutMistake() []*Vocab {
+	if x != nil {
+		return x.WithoutMistake
+	}
+	return nil
+}
+
+func (x *ReviewResult) GetWithMistake() []*Vocab {
+	if x != nil {
+		return x.WithMistake
+	}
+	return nil
+}
+
+type ListSetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sets          []*VocabSet            `protobuf:"bytes,1,rep,name=sets,proto3" json:"sets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSetsResponse) Reset() {
+	*x = ListSetsResponse{}
+	mi := &file_danish_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSetsResponse) ProtoMessage() {}
+
+func (x *ListSetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSetsResponse.ProtoReflect.Descriptor instead.
+func (*ListSetsResponse) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSetsResponse) GetSets() []*VocabSet {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
+type GetSetBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	SetId string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
+	// 0 returns the whole set.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSetBatchRequest) Reset() {
+	*x = GetSetBatchRequest{}
+	mi := &file_danish_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSetBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSetBatchRequest) ProtoMessage() {}
+
+func (x *GetSetBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSetBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetSetBatchRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetSetBatchRequest) GetSetId() string {
+	if x != nil {
+		return x.SetId
+	}
+	return ""
+}
+
+func (x *GetSetBatchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AddSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	VocabIds      []string               `protobuf:"bytes,2,rep,name=vocab_ids,json=vocabIds,proto3" json:"vocab_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSetRequest) Reset() {
+	*x = AddSetRequest{}
+	mi := &file_danish_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSetRequest) ProtoMessage() {}
+
+func (x *AddSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSetRequest.ProtoReflect.Descriptor instead.
+func (*AddSetRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AddSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddSetRequest) GetVocabIds() []string {
+	if x != nil {
+		return x.VocabIds
+	}
+	return nil
+}
+
+type UpdateSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SetId         string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	VocabIds      []string               `protobuf:"bytes,3,rep,name=vocab_ids,json=vocabIds,proto3" json:"vocab_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSetRequest) Reset() {
+	*x = UpdateSetRequest{}
+	mi := &file_danish_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSetRequest) ProtoMessage() {}
+
+func (x *UpdateSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSetRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSetRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateSetRequest) GetSetId() string {
+	if x != nil {
+		return x.SetId
+	}
+	return ""
+}
+
+func (x *UpdateSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSetRequest) GetVocabIds() []string {
+	if x != nil {
+		return x.VocabIds
+	}
+	return nil
+}
+
+type RemoveSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SetId         string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSetRequest) Reset() {
+	*x = RemoveSetRequest{}
+	mi := &file_danish_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSetRequest) ProtoMessage() {}
+
+func (x *RemoveSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_danish_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSetRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSetRequest) Descriptor() ([]byte, []int) {
+	return file_danish_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RemoveSetRequest) GetSetId() string {
+	if x != nil {
+		return x.SetId
+	}
+	return ""
+}
+
+var File_danish_proto protoreflect.FileDescriptor
+
+const file_danish_proto_rawDesc = "" +
+	"\n" +
+	"\fdanish.proto\x12\tdanish.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x01\n" +
+	"\tVocabForm\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04form\x18\x03 \x01(\tR\x04form\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\x12=\n" +
+	"\flast_success\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastSuccess\x12$\n" +
+	"\x0esuccess_in_row\x18\x06 \x01(\x05R\fsuccessInRow\"\xb6\x02\n" +
+	"\x05Vocab\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x02 \x01(\tR\n" +
+	"definition\x12$\n" +
+	"\x0epart_of_speech\x18\x03 \x01(\tR\fpartOfSpeech\x12*\n" +
+	"\x05forms\x18\x04 \x03(\v2\x14.danish.v1.VocabFormR\x05forms\x12=\n" +
+	"\fpaused_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vpausedUntil\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x121\n" +
+	"\x06due_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\"K\n" +
+	"\bVocabSet\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tvocab_ids\x18\x03 \x03(\tR\bvocabIds\"1\n" +
+	"\x05Batch\x12(\n" +
+	"\x06vocabs\x18\x01 \x03(\v2\x10.danish.v1.VocabR\x06vocabs\"b\n" +
+	"\n" +
+	"BulkResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"d\n" +
+	"\fImportResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"8\n" +
+	"\x0eAddWordRequest\x12&\n" +
+	"\x05vocab\x18\x01 \x01(\v2\x10.danish.v1.VocabR\x05vocab\";\n" +
+	"\x11UpdateWordRequest\x12&\n" +
+	"\x05vocab\x18\x01 \x01(\v2\x10.danish.v1.VocabR\x05vocab\" \n" +
+	"\x0eGetWordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb9\x02\n" +
+	"\x10ListWordsRequest\x12$\n" +
+	"\x0epart_of_speech\x18\x01 \x01(\tR\fpartOfSpeech\x12 \n" +
+	"\tmin_level\x18\x02 \x01(\x05H\x00R\bminLevel\x88\x01\x01\x12 \n" +
+	"\tmax_level\x18\x03 \x01(\x05H\x01R\bmaxLevel\x88\x01\x01\x12\x1b\n" +
+	"\x06paused\x18\x04 \x01(\bH\x02R\x06paused\x88\x01\x01\x12\x15\n" +
+	"\x06set_id\x18\x05 \x01(\tR\x05setId\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\a \x01(\bR\n" +
+	"descending\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limitB\f\n" +
+	"\n" +
+	"_min_levelB\f\n" +
+	"\n" +
+	"_max_levelB\t\n" +
+	"\a_paused\"^\n" +
+	"\x11ListWordsResponse\x12(\n" +
+	"\x06vocabs\x18\x01 \x03(\v2\x10.danish.v1.VocabR\x06vocabs\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"@\n" +
+	"\x12SearchWordsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"?\n" +
+	"\x13SearchWordsResponse\x12(\n" +
+	"\x06vocabs\x18\x01 \x03(\v2\x10.danish.v1.VocabR\x06vocabs\"#\n" +
+	"\x11RemoveWordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x10PatchWordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05patch\x18\x02 \x01(\fR\x05patch\"U\n" +
+	"\x0eAddFormRequest\x12\x19\n" +
+	"\bvocab_id\x18\x01 \x01(\tR\avocabId\x12(\n" +
+	"\x04form\x18\x02 \x01(\v2\x14.danish.v1.VocabFormR\x04form\"\\\n" +
+	"\x10PatchFormRequest\x12\x19\n" +
+	"\bvocab_id\x18\x01 \x01(\tR\avocabId\x12\x17\n" +
+	"\aform_id\x18\x02 \x01(\tR\x06formId\x12\x14\n" +
+	"\x05patch\x18\x03 \x01(\fR\x05patch\"G\n" +
+	"\x11RemoveFormRequest\x12\x19\n" +
+	"\bvocab_id\x18\x01 \x01(\tR\avocabId\x12\x17\n" +
+	"\aform_id\x18\x02 \x01(\tR\x06formId\"K\n" +
+	"\x11MergeWordsRequest\x12\x19\n" +
+	"\bvocab_id\x18\x01 \x01(\tR\avocabId\x12\x1b\n" +
+	"\tvocab_ids\x18\x02 \x03(\tR\bvocabIds\"@\n" +
+	"\x14BulkSaveWordsRequest\x12(\n" +
+	"\x06vocabs\x18\x01 \x03(\v2\x10.danish.v1.VocabR\x06vocabs\"*\n" +
+	"\x16BulkRemoveWordsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"?\n" +
+	"\fBulkResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.danish.v1.BulkResultR\aresults\"W\n" +
+	"\x12ImportWordsRequest\x12(\n" +
+	"\x06vocabs\x18\x01 \x03(\v2\x10.danish.v1.VocabR\x06vocabs\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"H\n" +
+	"\x13ImportWordsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.danish.v1.ImportResultR\aresults\"~\n" +
+	"\fReviewResult\x129\n" +
+	"\x0fwithout_mistake\x18\x01 \x03(\v2\x10.danish.v1.VocabR\x0ewithoutMistake\x123\n" +
+	"\fwith_mistake\x18\x02 \x03(\v2\x10.danish.v1.VocabR\vwithMistake\";\n" +
+	"\x10ListSetsResponse\x12'\n" +
+	"\x04sets\x18\x01 \x03(\v2\x13.danish.v1.VocabSetR\x04sets\"A\n" +
+	"\x12GetSetBatchRequest\x12\x15\n" +
+	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"@\n" +
+	"\rAddSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tvocab_ids\x18\x02 \x03(\tR\bvocabIds\"Z\n" +
+	"\x10UpdateSetRequest\x12\x15\n" +
+	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tvocab_ids\x18\x03 \x03(\tR\bvocabIds\")\n" +
+	"\x10RemoveSetRequest\x12\x15\n" +
+	"\x06set_id\x18\x01 \x01(\tR\x05setId2\xaf\a\n" +
+	"\x11DictionaryService\x126\n" +
+	"\aAddWord\x12\x19.danish.v1.AddWordRequest\x1a\x10.danish.v1.Vocab\x12<\n" +
+	"\n" +
+	"UpdateWord\x12\x1c.danish.v1.UpdateWordRequest\x1a\x10.danish.v1.Vocab\x126\n" +
+	"\aGetWord\x12\x19.danish.v1.GetWordRequest\x1a\x10.danish.v1.Vocab\x12F\n" +
+	"\tListWords\x12\x1b.danish.v1.ListWordsRequest\x1a\x1c.danish.v1.ListWordsResponse\x12L\n" +
+	"\vSearchWords\x12\x1d.danish.v1.SearchWordsRequest\x1a\x1e.danish.v1.SearchWordsResponse\x12B\n" +
+	"\n" +
+	"RemoveWord\x12\x1c.danish.v1.RemoveWordRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\tPatchWord\x12\x1b.danish.v1.PatchWordRequest\x1a\x10.danish.v1.Vocab\x126\n" +
+	"\aAddForm\x12\x19.danish.v1.AddFormRequest\x1a\x10.danish.v1.Vocab\x12:\n" +
+	"\tPatchForm\x12\x1b.danish.v1.PatchFormRequest\x1a\x10.danish.v1.Vocab\x12<\n" +
+	"\n" +
+	"RemoveForm\x12\x1c.danish.v1.RemoveFormRequest\x1a\x10.danish.v1.Vocab\x12<\n" +
+	"\n" +
+	"MergeWords\x12\x1c.danish.v1.MergeWordsRequest\x1a\x10.danish.v1.Vocab\x12I\n" +
+	"\rBulkSaveWords\x12\x1f.danish.v1.BulkSaveWordsRequest\x1a\x17.danish.v1.BulkResponse\x12M\n" +
+	"\x0fBulkRemoveWords\x12!.danish.v1.BulkRemoveWordsRequest\x1a\x17.danish.v1.BulkResponse\x12L\n" +
+	"\vImportWords\x12\x1d.danish.v1.ImportWordsRequest\x1a\x1e.danish.v1.ImportWordsResponse2\x88\x01\n" +
+	"\x0fWordPoolService\x124\n" +
+	"\bGetBatch\x12\x16.google.protobuf.Empty\x1a\x10.danish.v1.Batch\x12?\n" +
+	"\fSubmitReview\x12\x17.danish.v1.ReviewResult\x1a\x16.google.protobuf.Empty2\xca\x02\n" +
+	"\n" +
+	"SetService\x12?\n" +
+	"\bListSets\x12\x16.google.protobuf.Empty\x1a\x1b.danish.v1.ListSetsResponse\x12>\n" +
+	"\vGetSetBatch\x12\x1d.danish.v1.GetSetBatchRequest\x1a\x10.danish.v1.Batch\x127\n" +
+	"\x06AddSet\x12\x18.danish.v1.AddSetRequest\x1a\x13.danish.v1.VocabSet\x12@\n" +
+	"\tUpdateSet\x12\x1b.danish.v1.UpdateSetRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\tRemoveSet\x12\x1b.danish.v1.RemoveSetRequest\x1a\x16.google.protobuf.EmptyB)Z'github.com/vladazn/danish/app/rpc/pb;pbb\x06proto3"
+
+var (
+	file_danish_proto_rawDescOnce sync.Once
+	file_danish_proto_rawDescData []byte
+)
+
+func file_danish_proto_rawDescGZIP() []byte {
+	file_danish_proto_rawDescOnce.Do(func() {
+		file_danish_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_danish_proto_rawDesc), len(file_danish_proto_rawDesc)))
+	})
+	return file_danish_proto_rawDescData
+}
+
+var file_danish_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_danish_proto_goTypes = []any{
+	(*VocabForm)(nil),              // 0: danish.v1.VocabForm
+	(*Vocab)(nil),                  // 1: danish.v1.Vocab
+	(*VocabSet)(nil),               // 2: danish.v1.VocabSet
+	(*Batch)(nil),                  // 3: danish.v1.Batch
+	(*BulkResult)(nil),             // 4: danish.v1.BulkResult
+	(*ImportResult)(nil),           // 5: danish.v1.ImportResult
+	(*AddWordRequest)(nil),         // 6: danish.v1.AddWordRequest
+	(*UpdateWordRequest)(nil),      // 7: danish.v1.UpdateWordRequest
+	(*GetWordRequest)(nil),         // 8: danish.v1.GetWordRequest
+	(*ListWordsRequest)(nil),       // 9: danish.v1.ListWordsRequest
+	(*ListWordsResponse)(nil),      // 10: danish.v1.ListWordsResponse
+	(*SearchWordsRequest)(nil),     // 11: danish.v1.SearchWordsRequest
+	(*SearchWordsResponse)(nil),    // 12: danish.v1.SearchWordsResponse
+	(*RemoveWordRequest)(nil),      // 13: danish.v1.RemoveWordRequest
+	(*PatchWordRequest)(nil),       // 14: danish.v1.PatchWordRequest
+	(*AddFormRequest)(nil),         // 15: danish.v1.AddFormRequest
+	(*PatchFormRequest)(nil),       // 16: danish.v1.PatchFormRequest
+	(*RemoveFormRequest)(nil),      // 17: danish.v1.RemoveFormRequest
+	(*MergeWordsRequest)(nil),      // 18: danish.v1.MergeWordsRequest
+	(*BulkSaveWordsRequest)(nil),   // 19: danish.v1.BulkSaveWordsRequest
+	(*BulkRemoveWordsRequest)(nil), // 20: danish.v1.BulkRemoveWordsRequest
+	(*BulkResponse)(nil),           // 21: danish.v1.BulkResponse
+	(*ImportWordsRequest)(nil),     // 22: danish.v1.ImportWordsRequest
+	(*ImportWordsResponse)(nil),    // 23: danish.v1.ImportWordsResponse
+	(*ReviewResult)(nil),           // 24: danish.v1.ReviewResult
+	(*ListSetsResponse)(nil),       // 25: danish.v1.ListSetsResponse
+	(*GetSetBatchRequest)(nil),     // 26: danish.v1.GetSetBatchRequest
+	(*AddSetRequest)(nil),          // 27: danish.v1.AddSetRequest
+	(*UpdateSetRequest)(nil),       // 28: danish.v1.UpdateSetRequest
+	(*RemoveSetRequest)(nil),       // 29: danish.v1.RemoveSetRequest
+	(*timestamppb.Timestamp)(nil),  // 30: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 31: google.protobuf.Empty
+}
+var file_danish_proto_depIdxs = []int32{
+	30, // 0: danish.v1.VocabForm.last_success:type_name -> google.protobuf.Timestamp
+	0,  // 1: danish.v1.Vocab.forms:type_name -> danish.v1.VocabForm
+	30, // 2: danish.v1.Vocab.paused_until:type_name -> google.protobuf.Timestamp
+	30, // 3: danish.v1.Vocab.created_at:type_name -> google.protobuf.Timestamp
+	30, // 4: danish.v1.Vocab.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: danish.v1.Batch.vocabs:type_name -> danish.v1.Vocab
+	1,  // 6: danish.v1.AddWordRequest.vocab:type_name -> danish.v1.Vocab
+	1,  // 7: danish.v1.UpdateWordRequest.vocab:type_name -> danish.v1.Vocab
+	1,  // 8: danish.v1.ListWordsResponse.vocabs:type_name -> danish.v1.Vocab
+	1,  // 9: danish.v1.SearchWordsResponse.vocabs:type_name -> danish.v1.Vocab
+	0,  // 10: danish.v1.AddFormRequest.form:type_name -> danish.v1.VocabForm
+	1,  // 11: danish.v1.BulkSaveWordsRequest.vocabs:type_name -> danish.v1.Vocab
+	4,  // 12: danish.v1.BulkResponse.results:type_name -> danish.v1.BulkResult
+	1,  // 13: danish.v1.ImportWordsRequest.vocabs:type_name -> danish.v1.Vocab
+	5,  // 14: danish.v1.ImportWordsResponse.results:type_name -> danish.v1.ImportResult
+	1,  // 15: danish.v1.ReviewResult.without_mistake:type_name -> danish.v1.Vocab
+	1,  // 16: danish.v1.ReviewResult.with_mistake:type_name -> danish.v1.Vocab
+	2,  // 17: danish.v1.ListSetsResponse.sets:type_name -> danish.v1.VocabSet
+	6,  // 18: danish.v1.DictionaryService.AddWord:input_type -> danish.v1.AddWordRequest
+	7,  // 19: danish.v1.DictionaryService.UpdateWord:input_type -> danish.v1.UpdateWordRequest
+	8,  // 20: danish.v1.DictionaryService.GetWord:input_type -> danish.v1.GetWordRequest
+	9,  // 21: danish.v1.DictionaryService.ListWords:input_type -> danish.v1.ListWordsRequest
+	11, // 22: danish.v1.DictionaryService.SearchWords:input_type -> danish.v1.SearchWordsRequest
+	13, // 23: danish.v1.DictionaryService.RemoveWord:input_type -> danish.v1.RemoveWordRequest
+	14, // 24: danish.v1.DictionaryService.PatchWord:input_type -> danish.v1.PatchWordRequest
+	15, // 25: danish.v1.DictionaryService.AddForm:input_type -> danish.v1.AddFormRequest
+	16, // 26: danish.v1.DictionaryService.PatchForm:input_type -> danish.v1.PatchFormRequest
+	17, // 27: danish.v1.DictionaryService.RemoveForm:input_type -> danish.v1.RemoveFormRequest
+	18, // 28: danish.v1.DictionaryService.MergeWords:input_type -> danish.v1.MergeWordsRequest
+	19, // 29: danish.v1.DictionaryService.BulkSaveWords:input_type -> danish.v1.BulkSaveWordsRequest
+	20, // 30: danish.v1.DictionaryService.BulkRemoveWords:input_type -> danish.v1.BulkRemoveWordsRequest
+	22, // 31: danish.v1.DictionaryService.ImportWords:input_type -> danish.v1.ImportWordsRequest
+	31, // 32: danish.v1.WordPoolService.GetBatch:input_type -> google.protobuf.Empty
+	24, // 33: danish.v1.WordPoolService.SubmitReview:input_type -> danish.v1.ReviewResult
+	31, // 34: danish.v1.SetService.ListSets:input_type -> google.protobuf.Empty
+	26, // 35: danish.v1.SetService.GetSetBatch:input_type -> danish.v1.GetSetBatchRequest
+	27, // 36: danish.v1.SetService.AddSet:input_type -> danish.v1.AddSetRequest
+	28, // 37: danish.v1.SetService.UpdateSet:input_type -> danish.v1.UpdateSetRequest
+	29, // 38: danish.v1.SetService.RemoveSet:input_type -> danish.v1.RemoveSetRequest
+	1,  // 39: danish.v1.DictionaryService.AddWord:output_type -> danish.v1.Vocab
+	1,  // 40: danish.v1.DictionaryService.UpdateWord:output_type -> danish.v1.Vocab
+	1,  // 41: danish.v1.DictionaryService.GetWord:output_type -> danish.v1.Vocab
+	10, // 42: danish.v1.DictionaryService.ListWords:output_type -> danish.v1.ListWordsResponse
+	12, // 43: danish.v1.DictionaryService.SearchWords:output_type -> danish.v1.SearchWordsResponse
+	31, // 44: danish.v1.DictionaryService.RemoveWord:output_type -> google.protobuf.Empty
+	1,  // 45: danish.v1.DictionaryService.PatchWord:output_type -> danish.v1.Vocab
+	1,  // 46: danish.v1.DictionaryService.AddForm:output_type -> danish.v1.Vocab
+	1,  // 47: danish.v1.DictionaryService.PatchForm:output_type -> danish.v1.Vocab
+	1,  // 48: danish.v1.DictionaryService.RemoveForm:output_type -> danish.v1.Vocab
+	1,  // 49: danish.v1.DictionaryService.MergeWords:output_type -> danish.v1.Vocab
+	21, // 50: danish.v1.DictionaryService.BulkSaveWords:output_type -> danish.v1.BulkResponse
+	21, // 51: danish.v1.DictionaryService.BulkRemoveWords:output_type -> danish.v1.BulkResponse
+	23, // 52: danish.v1.DictionaryService.ImportWords:output_type -> danish.v1.ImportWordsResponse
+	3,  // 53: danish.v1.WordPoolService.GetBatch:output_type -> danish.v1.Batch
+	31, // 54: danish.v1.WordPoolService.SubmitReview:output_type -> google.protobuf.Empty
+	25, // 55: danish.v1.SetService.ListSets:output_type -> danish.v1.ListSetsResponse
+	3,  // 56: danish.v1.SetService.GetSetBatch:output_type -> danish.v1.Batch
+	2,  // 57: danish.v1.SetService.AddSet:output_type -> danish.v1.VocabSet
+	31, // 58: danish.v1.SetService.UpdateSet:output_type -> google.protobuf.Empty
+	31, // 59: danish.v1.SetService.RemoveSet:output_type -> google.protobuf.Empty
+	39, // [39:60] is the sub-list for method output_type
+	18, // [18:39] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_danish_proto_init() }
+func file_danish_proto_init() {
+	if File_danish_proto != nil {
+		return
+	}
+	file_danish_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_danish_proto_rawDesc), len(file_danish_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   30,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_danish_proto_goTypes,
+		DependencyIndexes: file_danish_proto_depIdxs,
+		MessageInfos:      file_danish_proto_msgTypes,
+	}.Build()
+	File_danish_proto = out.File
+	file_danish_proto_goTypes = nil
+	file_danish_proto_depIdxs = nil
+}
@@ -0,0 +1,1020 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: danish.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DictionaryService_AddWord_FullMethodName         = "/danish.v1.DictionaryService/AddWord"
+	DictionaryService_UpdateWord_FullMethodName      = "/danish.v1.DictionaryService/UpdateWord"
+	DictionaryService_GetWord_FullMethodName         = "/danish.v1.DictionaryService/GetWord"
+	DictionaryService_ListWords_FullMethodName       = "/danish.v1.DictionaryService/ListWords"
+	DictionaryService_SearchWords_FullMethodName     = "/danish.v1.DictionaryService/SearchWords"
+	DictionaryService_RemoveWord_FullMethodName      = "/danish.v1.DictionaryService/RemoveWord"
+	DictionaryService_PatchWord_FullMethodName       = "/danish.v1.DictionaryService/PatchWord"
+	DictionaryService_AddForm_FullMethodName         = "/danish.v1.DictionaryService/AddForm"
+	DictionaryService_PatchForm_FullMethodName       = "/danish.v1.DictionaryService/PatchForm"
+	DictionaryService_RemoveForm_FullMethodName      = "/danish.v1.DictionaryService/RemoveForm"
+	DictionaryService_MergeWords_FullMethodName      = "/danish.v1.DictionaryService/MergeWords"
+	DictionaryService_BulkSaveWords_FullMethodName   = "/danish.v1.DictionaryService/BulkSaveWords"
+	DictionaryService_BulkRemoveWords_FullMethodName = "/danish.v1.DictionaryService/BulkRemoveWords"
+	DictionaryService_ImportWords_FullMethodName     = "/danish.v1.DictionaryService/ImportWords"
+)
+
+// DictionaryServiceClient is the client API for DictionaryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DictionaryServiceClient interface {
+	AddWord(ctx context.Context, in *AddWordRequest, opts ...grpc.CallOption) (*Vocab, error)
+	UpdateWord(ctx context.Context, in *UpdateWordRequest, opts ...grpc.CallOption) (*Vocab, error)
+	GetWord(ctx context.Context, in *GetWordRequest, opts ...grpc.CallOption) (*Vocab, error)
+	ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error)
+	SearchWords(ctx context.Context, in *SearchWordsRequest, opts ...grpc.CallOption) (*SearchWordsResponse, error)
+	RemoveWord(ctx context.Context, in *RemoveWordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PatchWord(ctx context.Context, in *PatchWordRequest, opts ...grpc.CallOption) (*Vocab, error)
+	AddForm(ctx context.Context, in *AddFormRequest, opts ...grpc.CallOption) (*Vocab, error)
+	PatchForm(ctx context.Context, in *PatchFormRequest, opts ...grpc.CallOption) (*Vocab, error)
+	RemoveForm(ctx context.Context, in *RemoveFormRequest, opts ...grpc.CallOption) (*Vocab, error)
+	MergeWords(ctx context.Context, in *MergeWordsRequest, opts ...grpc.CallOption) (*Vocab, error)
+	BulkSaveWords(ctx context.Context, in *BulkSaveWordsRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	BulkRemoveWords(ctx context.Context, in *BulkRemoveWordsRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	// ImportWords adds vocab, merging vocab that matches existing vocab by id,
+	// definition or form into it. With dry_run nothing is written and the
+	// results show what would happen.
+	ImportWords(ctx context.Context, in *ImportWordsRequest, opts ...grpc.CallOption) (*ImportWordsResponse, error)
+}
+
+type dictionaryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDictionaryServiceClient(cc grpc.ClientConnInterface) DictionaryServiceClient {
+	return &dictionaryServiceClient{cc}
+}
+
+func (c *dictionaryServiceClient) AddWord(ctx context.Context, in *AddWordRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_AddWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) UpdateWord(ctx context.Context, in *UpdateWordRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_UpdateWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) GetWord(ctx context.Context, in *GetWordRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_GetWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWordsResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_ListWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) SearchWords(ctx context.Context, in *SearchWordsRequest, opts ...grpc.CallOption) (*SearchWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchWordsResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_SearchWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) RemoveWord(ctx context.Context, in *RemoveWordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DictionaryService_RemoveWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) PatchWord(ctx context.Context, in *PatchWordRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_PatchWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) AddForm(ctx context.Context, in *AddFormRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_AddForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) PatchForm(ctx context.Context, in *PatchFormRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_PatchForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) RemoveForm(ctx context.Context, in *RemoveFormRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_RemoveForm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) MergeWords(ctx context.Context, in *MergeWordsRequest, opts ...grpc.CallOption) (*Vocab, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vocab)
+	err := c.cc.Invoke(ctx, DictionaryService_MergeWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) BulkSaveWords(ctx context.Context, in *BulkSaveWordsRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_BulkSaveWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) BulkRemoveWords(ctx context.Context, in *BulkRemoveWordsRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_BulkRemoveWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) ImportWords(ctx context.Context, in *ImportWordsRequest, opts ...grpc.CallOption) (*ImportWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportWordsResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_ImportWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DictionaryServiceServer is the server API for DictionaryService service.
+// All implementations must embed UnimplementedDictionaryServiceServer
+// for forward compatibility.
+type DictionaryServiceServer interface {
+	AddWord(context.Context, *AddWordRequest) (*Vocab, error)
+	UpdateWord(context.Context, *UpdateWordRequest) (*Vocab, error)
+	GetWord(context.Context, *GetWordRequest) (*Vocab, error)
+	ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error)
+	SearchWords(context.Context, *SearchWordsRequest) (*SearchWordsResponse, error)
+	RemoveWord(context.Context, *RemoveWordRequest) (*emptypb.Empty, error)
+	PatchWord(context.Context, *PatchWordRequest) (*Vocab, error)
+	AddForm(context.Context, *AddFormRequest) (*Vocab, error)
+	PatchForm(context.Context, *PatchFormRequest) (*Vocab, error)
+	RemoveForm(context.Context, *RemoveFormRequest) (*Vocab, error)
+	MergeWords(context.Context, *MergeWordsRequest) (*Vocab, error)
+	BulkSaveWords(context.Context, *BulkSaveWordsRequest) (*BulkResponse, error)
+	BulkRemoveWords(context.Context, *BulkRemoveWordsRequest) (*BulkResponse, error)
+	// ImportWords adds vocab, merging vocab that matches existing vocab by id,
+	// definition or form into it. With dry_run nothing is written and the
+	// results show what would happen.
+	ImportWords(context.Context, *ImportWordsRequest) (*ImportWordsResponse, error)
+	mustEmbedUnimplementedDictionaryServiceServer()
+}
+
+// UnimplementedDictionaryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDictionaryServiceServer struct{}
+
+func (UnimplementedDictionaryServiceServer) AddWord(context.Context, *AddWordRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWord not implemented")
+}
+func (UnimplementedDictionaryServiceServer) UpdateWord(context.Context, *UpdateWordRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWord not implemented")
+}
+func (UnimplementedDictionaryServiceServer) GetWord(context.Context, *GetWordRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWord not implemented")
+}
+func (UnimplementedDictionaryServiceServer) ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWords not implemented")
+}
+func (UnimplementedDictionaryServiceServer) SearchWords(context.Context, *SearchWordsRequest) (*SearchWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchWords not implemented")
+}
+func (UnimplementedDictionaryServiceServer) RemoveWord(context.Context, *RemoveWordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWord not implemented")
+}
+func (UnimplementedDictionaryServiceServer) PatchWord(context.Context, *PatchWordRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchWord not implemented")
+}
+func (UnimplementedDictionaryServiceServer) AddForm(context.Context, *AddFormRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddForm not implemented")
+}
+func (UnimplementedDictionaryServiceServer) PatchForm(context.Context, *PatchFormRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchForm not implemented")
+}
+func (UnimplementedDictionaryServiceServer) RemoveForm(context.Context, *RemoveFormRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveForm not implemented")
+}
+func (UnimplementedDictionaryServiceServer) MergeWords(context.Context, *MergeWordsRequest) (*Vocab, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeWords not implemented")
+}
+func (UnimplementedDictionaryServiceServer) BulkSaveWords(context.Context, *BulkSaveWordsRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkSaveWords not implemented")
+}
+func (UnimplementedDictionaryServiceServer) BulkRemoveWords(context.Context, *BulkRemoveWordsRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkRemoveWords not implemented")
+}
+func (UnimplementedDictionaryServiceServer) ImportWords(context.Context, *ImportWordsRequest) (*ImportWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportWords not implemented")
+}
+func (UnimplementedDictionaryServiceServer) mustEmbedUnimplementedDictionaryServiceServer() {}
+func (UnimplementedDictionaryServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDictionaryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DictionaryServiceServer will
+// result in compilation errors.
+type UnsafeDictionaryServiceServer interface {
+	mustEmbedUnimplementedDictionaryServiceServer()
+}
+
+func RegisterDictionaryServiceServer(s grpc.ServiceRegistrar, srv DictionaryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDictionaryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DictionaryService_ServiceDesc, srv)
+}
+
+func _DictionaryService_AddWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).AddWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_AddWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).AddWord(ctx, req.(*AddWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_UpdateWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).UpdateWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_UpdateWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).UpdateWord(ctx, req.(*UpdateWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_GetWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).GetWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_GetWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).GetWord(ctx, req.(*GetWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_ListWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).ListWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_ListWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).ListWords(ctx, req.(*ListWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_SearchWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).SearchWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_SearchWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).SearchWords(ctx, req.(*SearchWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_RemoveWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).RemoveWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_RemoveWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).RemoveWord(ctx, req.(*RemoveWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_PatchWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).PatchWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_PatchWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).PatchWord(ctx, req.(*PatchWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_AddForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).AddForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_AddForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).AddForm(ctx, req.(*AddFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_PatchForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).PatchForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_PatchForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).PatchForm(ctx, req.(*PatchFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_RemoveForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFormRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).RemoveForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_RemoveForm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).RemoveForm(ctx, req.(*RemoveFormRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_MergeWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).MergeWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_MergeWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).MergeWords(ctx, req.(*MergeWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_BulkSaveWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSaveWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).BulkSaveWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_BulkSaveWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).BulkSaveWords(ctx, req.(*BulkSaveWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_BulkRemoveWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRemoveWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).BulkRemoveWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_BulkRemoveWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).BulkRemoveWords(ctx, req.(*BulkRemoveWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_ImportWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).ImportWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_ImportWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).ImportWords(ctx, req.(*ImportWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DictionaryService_ServiceDesc is the grpc.ServiceDesc for DictionaryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DictionaryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "danish.v1.DictionaryService",
+	HandlerType: (*DictionaryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddWord",
+			Handler:    _DictionaryService_AddWord_Handler,
+		},
+		{
+			MethodName: "UpdateWord",
+			Handler:    _DictionaryService_UpdateWord_Handler,
+		},
+		{
+			MethodName: "GetWord",
+			Handler:    _DictionaryService_GetWord_Handler,
+		},
+		{
+			MethodName: "ListWords",
+			Handler:    _DictionaryService_ListWords_Handler,
+		},
+		{
+			MethodName: "SearchWords",
+			Handler:    _DictionaryService_SearchWords_Handler,
+		},
+		{
+			MethodName: "RemoveWord",
+			Handler:    _DictionaryService_RemoveWord_Handler,
+		},
+		{
+			MethodName: "PatchWord",
+			Handler:    _DictionaryService_PatchWord_Handler,
+		},
+		{
+			MethodName: "AddForm",
+			Handler:    _DictionaryService_AddForm_Handler,
+		},
+		{
+			MethodName: "PatchForm",
+			Handler:    _DictionaryService_PatchForm_Handler,
+		},
+		{
+			MethodName: "RemoveForm",
+			Handler:    _DictionaryService_RemoveForm_Handler,
+		},
+		{
+			MethodName: "MergeWords",
+			Handler:    _DictionaryService_MergeWords_Handler,
+		},
+		{
+			MethodName: "BulkSaveWords",
+			Handler:    _DictionaryService_BulkSaveWords_Handler,
+		},
+		{
+			MethodName: "BulkRemoveWords",
+			Handler:    _DictionaryService_BulkRemoveWords_Handler,
+		},
+		{
+			MethodName: "ImportWords",
+			Handler:    _DictionaryService_ImportWords_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "danish.proto",
+}
+
+const (
+	WordPoolService_GetBatch_FullMethodName     = "/danish.v1.WordPoolService/GetBatch"
+	WordPoolService_SubmitReview_FullMethodName = "/danish.v1.WordPoolService/SubmitReview"
+)
+
+// WordPoolServiceClient is the client API for WordPoolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WordPoolServiceClient interface {
+	GetBatch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Batch, error)
+	// SubmitReview records the result of a reviewed batch, removing the vocab
+	// answered without mistakes from the pool.
+	SubmitReview(ctx context.Context, in *ReviewResult, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type wordPoolServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWordPoolServiceClient(cc grpc.ClientConnInterface) WordPoolServiceClient {
+	return &wordPoolServiceClient{cc}
+}
+
+func (c *wordPoolServiceClient) GetBatch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, WordPoolService_GetBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordPoolServiceClient) SubmitReview(ctx context.Context, in *ReviewResult, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WordPoolService_SubmitReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WordPoolServiceServer is the server API for WordPoolService service.
+// All implementations must embed UnimplementedWordPoolServiceServer
+// for forward compatibility.
+type WordPoolServiceServer interface {
+	GetBatch(context.Context, *emptypb.Empty) (*Batch, error)
+	// SubmitReview records the result of a reviewed batch, removing the vocab
+	// answered without mistakes from the pool.
+	SubmitReview(context.Context, *ReviewResult) (*emptypb.Empty, error)
+	mustEmbedUnimplementedWordPoolServiceServer()
+}
+
+// UnimplementedWordPoolServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWordPoolServiceServer struct{}
+
+func (UnimplementedWordPoolServiceServer) GetBatch(context.Context, *emptypb.Empty) (*Batch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatch not implemented")
+}
+func (UnimplementedWordPoolServiceServer) SubmitReview(context.Context, *ReviewResult) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitReview not implemented")
+}
+func (UnimplementedWordPoolServiceServer) mustEmbedUnimplementedWordPoolServiceServer() {}
+func (UnimplementedWordPoolServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeWordPoolServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WordPoolServiceServer will
+// result in compilation errors.
+type UnsafeWordPoolServiceServer interface {
+	mustEmbedUnimplementedWordPoolServiceServer()
+}
+
+func RegisterWordPoolServiceServer(s grpc.ServiceRegistrar, srv WordPoolServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWordPoolServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WordPoolService_ServiceDesc, srv)
+}
+
+func _WordPoolService_GetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordPoolServiceServer).GetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WordPoolService_GetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordPoolServiceServer).GetBatch(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordPoolService_SubmitReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewResult)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordPoolServiceServer).SubmitReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WordPoolService_SubmitReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordPoolServiceServer).SubmitReview(ctx, req.(*ReviewResult))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WordPoolService_ServiceDesc is the grpc.ServiceDesc for WordPoolService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WordPoolService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "danish.v1.WordPoolService",
+	HandlerType: (*WordPoolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBatch",
+			Handler:    _WordPoolService_GetBatch_Handler,
+		},
+		{
+			MethodName: "SubmitReview",
+			Handler:    _WordPoolService_SubmitReview_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "danish.proto",
+}
+
+const (
+	SetService_ListSets_FullMethodName    = "/danish.v1.SetService/ListSets"
+	SetService_GetSetBatch_FullMethodName = "/danish.v1.SetService/GetSetBatch"
+	SetService_AddSet_FullMethodName      = "/danish.v1.SetService/AddSet"
+	SetService_UpdateSet_FullMethodName   = "/danish.v1.SetService/UpdateSet"
+	SetService_RemoveSet_FullMethodName   = "/danish.v1.SetService/RemoveSet"
+)
+
+// SetServiceClient is the client API for SetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SetServiceClient interface {
+	ListSets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSetsResponse, error)
+	GetSetBatch(ctx context.Context, in *GetSetBatchRequest, opts ...grpc.CallOption) (*Batch, error)
+	AddSet(ctx context.Context, in *AddSetRequest, opts ...grpc.CallOption) (*VocabSet, error)
+	UpdateSet(ctx context.Context, in *UpdateSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveSet(ctx context.Context, in *RemoveSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type setServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSetServiceClient(cc grpc.ClientConnInterface) SetServiceClient {
+	return &setServiceClient{cc}
+}
+
+func (c *setServiceClient) ListSets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSetsResponse)
+	err := c.cc.Invoke(ctx, SetService_ListSets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *setServiceClient) GetSetBatch(ctx context.Context, in *GetSetBatchRequest, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, SetService_GetSetBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *setServiceClient) AddSet(ctx context.Context, in *AddSetRequest, opts ...grpc.CallOption) (*VocabSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VocabSet)
+	err := c.cc.Invoke(ctx, SetService_AddSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *setServiceClient) UpdateSet(ctx context.Context, in *UpdateSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SetService_UpdateSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *setServiceClient) RemoveSet(ctx context.Context, in *RemoveSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SetService_RemoveSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetServiceServer is the server API for SetService service.
+// All implementations must embed UnimplementedSetServiceServer
+// for forward compatibility.
+type SetServiceServer interface {
+	ListSets(context.Context, *emptypb.Empty) (*ListSetsResponse, error)
+	GetSetBatch(context.Context, *GetSetBatchRequest) (*Batch, error)
+	AddSet(context.Context, *AddSetRequest) (*VocabSet, error)
+	UpdateSet(context.Context, *UpdateSetRequest) (*emptypb.Empty, error)
+	RemoveSet(context.Context, *RemoveSetRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSetServiceServer()
+}
+
+// UnimplementedSetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSetServiceServer struct{}
+
+func (UnimplementedSetServiceServer) ListSets(context.Context, *emptypb.Empty) (*ListSetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSets not implemented")
+}
+func (UnimplementedSetServiceServer) GetSetBatch(context.Context, *GetSetBatchRequest) (*Batch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSetBatch not implemented")
+}
+func (UnimplementedSetServiceServer) AddSet(context.Context, *AddSetRequest) (*VocabSet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSet not implemented")
+}
+func (UnimplementedSetServiceServer) UpdateSet(context.Context, *UpdateSetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSet not implemented")
+}
+func (UnimplementedSetServiceServer) RemoveSet(context.Context, *RemoveSetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSet not implemented")
+}
+func (UnimplementedSetServiceServer) mustEmbedUnimplementedSetServiceServer() {}
+func (UnimplementedSetServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeSetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SetServiceServer will
+// result in compilation errors.
+type UnsafeSetServiceServer interface {
+	mustEmbedUnimplementedSetServiceServer()
+}
+
+func RegisterSetServiceServer(s grpc.ServiceRegistrar, srv SetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SetService_ServiceDesc, srv)
+}
+
+func _SetService_ListSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SetServiceServer).ListSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SetService_ListSets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SetServiceServer).ListSets(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SetService_GetSetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSetBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SetServiceServer).GetSetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SetService_GetSetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SetServiceServer).GetSetBatch(ctx, req.(*GetSetBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SetService_AddSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SetServiceServer).AddSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SetService_AddSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SetServiceServer).AddSet(ctx, req.(*AddSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SetService_UpdateSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SetServiceServer).UpdateSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SetService_UpdateSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SetServiceServer).UpdateSet(ctx, req.(*UpdateSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SetService_RemoveSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SetServiceServer).RemoveSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SetService_RemoveSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SetServiceServer).RemoveSet(ctx, req.(*RemoveSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SetService_ServiceDesc is the grpc.ServiceDesc for SetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "danish.v1.SetService",
+	HandlerType: (*SetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSets",
+			Handler:    _SetService_ListSets_Handler,
+		},
+		{
+			MethodName: "GetSetBatch",
+			Handler:    _SetService_GetSetBatch_Handler,
+		},
+		{
+			MethodName: "AddSet",
+			Handler:    _SetService_AddSet_Handler,
+		},
+		{
+			MethodName: "UpdateSet",
+			Handler:    _SetService_UpdateSet_Handler,
+		},
+		{
+			MethodName: "RemoveSet",
+			Handler:    _SetService_RemoveSet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "danish.proto",
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+)
+
+// wordPoolServer exposes classroom.WordPool.
+type wordPoolServer struct {
+	pb.UnimplementedWordPoolServiceServer
+	dict    *classroom.Dictionary
+	pool    *classroom.WordPool
+	metrics *metrics.Metrics
+}
+
+func (s *wordPoolServer) GetBatch(ctx context.Context, _ *emptypb.Empty) (*pb.Batch, error) {
+	batch, err := s.pool.GetBatch(ctx, userid.MustFromCtx(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return batchToPB(batch), nil
+}
+
+func (s *wordPoolServer) SubmitReview(ctx context.Context, req *pb.ReviewResult) (*emptypb.Empty, error) {
+	var val validate.Validator
+	withoutMistake := vocabsFromPB(&val, "without_mistake", req.GetWithoutMistake())
+	withMistake := vocabsFromPB(&val, "with_mistake", req.GetWithMistake())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.pool.RemoveFromPool(ctx, userid.MustFromCtx(ctx), withoutMistake); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := s.dict.RegisterProgress(ctx, withoutMistake, withMistake); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	s.metrics.ObserveBatchResult(metrics.RatingCorrect, countForms(withoutMistake))
+	s.metrics.ObserveBatchResult(metrics.RatingMistake, countForms(withMistake))
+
+	return &emptypb.Empty{}, nil
+}
+
+func countForms(vocabs []model.Vocab) int {
+	n := 0
+	for _, vocab := range vocabs {
+		n += len(vocab.Forms)
+	}
+	return n
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/config"
+)
+
+// methodRoutes maps calls to the HTTP routes doing the same, so a budget
+// the config sets for a route applies to the call as well, from the same
+// bucket.
+var methodRoutes = map[string]string{
+	"/danish.v1.DictionaryService/AddWord":         "POST /vocab",
+	"/danish.v1.DictionaryService/UpdateWord":      "PUT /vocab",
+	"/danish.v1.DictionaryService/GetWord":         "GET /vocab/{id}",
+	"/danish.v1.DictionaryService/ListWords":       "GET /vocab",
+	"/danish.v1.DictionaryService/SearchWords":     "GET /vocab/search",
+	"/danish.v1.DictionaryService/RemoveWord":      "DELETE /vocab/{id}",
+	"/danish.v1.DictionaryService/PatchWord":       "PATCH /vocab/{id}",
+	"/danish.v1.DictionaryService/AddForm":         "POST /vocab/{id}/forms",
+	"/danish.v1.DictionaryService/PatchForm":       "PATCH /vocab/{id}/forms/{formId}",
+	"/danish.v1.DictionaryService/RemoveForm":      "DELETE /vocab/{id}/forms/{formId}",
+	"/danish.v1.DictionaryService/MergeWords":      "POST /vocab/{id}/merge",
+	"/danish.v1.DictionaryService/BulkSaveWords":   "POST /vocab/bulk",
+	"/danish.v1.DictionaryService/BulkRemoveWords": "DELETE /vocab/bulk",
+	"/danish.v1.DictionaryService/ImportWords":     "POST /vocab/import",
+	"/danish.v1.WordPoolService/GetBatch":          "GET /classroom/batch",
+	"/danish.v1.WordPoolService/SubmitReview":      "POST /classroom/batch",
+	"/danish.v1.SetService/ListSets":               "GET /classroom/sets",
+	"/danish.v1.SetService/GetSetBatch":            "GET /classroom/sets/{setId}/batch",
+	"/danish.v1.SetService/AddSet":                 "POST /classroom/sets",
+	"/danish.v1.SetService/UpdateSet":              "PUT /classroom/sets/{setId}",
+	"/danish.v1.SetService/RemoveSet":              "DELETE /classroom/sets/{setId}",
+}
+
+// rateLimits applies the budgets of the HTTP server to calls. Buckets are
+// keyed like the HTTP ones, so a client spends one budget over both.
+type rateLimits struct {
+	limiter        ratelimit.Limiter
+	budgets        ratelimit.Budgets
+	trustForwarded bool
+	trustedProxies int
+}
+
+func newRateLimits(cfg *config.RateLimitConfig, limiter ratelimit.Limiter) (*rateLimits, error) {
+	budgets, err := ratelimit.ParseBudgets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimits{
+		limiter:        limiter,
+		budgets:        budgets,
+		trustForwarded: cfg.TrustForwarded,
+		trustedProxies: cfg.TrustedProxies,
+	}, nil
+}
+
+// ipInterceptor limits the calls of every client address. It runs before
+// authentication, so bad credentials are limited too.
+func (rl *rateLimits) ipInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	if err := rl.take(ctx, "ip:"+rl.clientIP(ctx), rl.budgets.IP, false); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// userInterceptor limits the calls of every user, giving calls whose route
+// has its own budget a bucket of their own. It has to run after
+// authentication.
+func (rl *rateLimits) userInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	key, budget := "user:"+userid.MustFromCtx(ctx), rl.budgets.User
+	if route, ok := methodRoutes[info.FullMethod]; ok {
+		if routeBudget, ok := rl.budgets.Routes[route]; ok {
+			key, budget = key+":"+route, routeBudget
+		}
+	}
+
+	if err := rl.take(ctx, key, budget, true); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// take takes a call from the budget of key, failing with ResourceExhausted
+// if it is spent. Calls are let through if the limiter fails, so an outage
+// of a shared backend does not take the service down. With report the
+// budget is sent in the x-ratelimit header metadata; only one budget may
+// be, since header metadata adds up rather than replacing earlier values.
+func (rl *rateLimits) take(ctx context.Context, key string, budget ratelimit.Budget, report bool) error {
+	result, err := rl.limiter.Take(ctx, key, budget)
+	if err != nil {
+		logger.FromCtx(ctx).Error("failed to check rate limit", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+
+	if report {
+		grpc.SetHeader(ctx, metadata.Pairs(
+			"x-ratelimit-limit", strconv.Itoa(budget.Requests),
+			"x-ratelimit-remaining", strconv.Itoa(result.Remaining),
+		))
+	}
+	if !result.Allowed {
+		return resourceExhausted("rate limit exceeded", result.RetryAfter)
+	}
+	return nil
+}
+
+// clientIP returns the address of the client, or the one the trusted
+// proxies recorded in x-forwarded-for if they are trusted to set it.
+func (rl *rateLimits) clientIP(ctx context.Context) string {
+	if rl.trustForwarded {
+		forwarded := strings.Join(metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"), ",")
+		if client := ratelimit.ForwardedClient(forwarded, rl.trustedProxies); client != "" {
+			return client
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestRateLimits_ClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustForwarded bool
+		trustedProxies int
+		forwarded      []string
+		want           string
+	}{
+		{"peer address", false, 1, nil, "192.0.2.1"},
+		{"forwarded without trust", false, 1, []string{"1.2.3.4"}, "192.0.2.1"},
+		{"set by the proxy", true, 1, []string{"10.0.0.1"}, "10.0.0.1"},
+		{"forged by the client", true, 1, []string{"1.2.3.4, 10.0.0.1"}, "10.0.0.1"},
+		{"over several values", true, 1, []string{"1.2.3.4", "10.0.0.1"}, "10.0.0.1"},
+		{"behind two proxies", true, 2, []string{"1.2.3.4, 10.0.0.1, 192.168.0.1"}, "10.0.0.1"},
+		{"missing", true, 1, nil, "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := &rateLimits{trustForwarded: tt.trustForwarded, trustedProxies: tt.trustedProxies}
+			md := metadata.MD{}
+			for _, value := range tt.forwarded {
+				md.Append("x-forwarded-for", value)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+
+			require.Equal(t, tt.want, rl.clientIP(ctx))
+		})
+	}
+}
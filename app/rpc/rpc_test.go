@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+	"github.com/vladazn/danish/config"
+)
+
+func TestVocabConversion(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	vocab := model.Vocab{
+		Id:           uuid.New(),
+		Definition:   "house",
+		PartOfSpeech: model.PartOfSpeechNoun,
+		Forms: []model.VocabForm{
+			{Id: uuid.New(), Value: "et hus", Form: "indefinite_singular", Level: 2, LastSuccess: now, SuccessInRow: 3},
+			{Id: uuid.New(), Value: "huset", Form: "definite_singular"},
+		},
+		PausedUntil: &now,
+		CreatedAt:   now,
+		DueAt:       now.Add(time.Hour),
+	}
+
+	var val validate.Validator
+	require.Equal(t, vocab, vocabFromPB(&val, "vocab", vocabToPB(vocab)))
+	require.NoError(t, val.Err())
+
+	t.Run("malformed ids", func(t *testing.T) {
+		var val validate.Validator
+		vocabFromPB(&val, "vocab", &pb.Vocab{Id: "x", Forms: []*pb.VocabForm{{Id: "y"}}})
+		parseRequiredId(&val, "set_id", "")
+
+		err := invalidArgument(val.Err())
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		var fields []string
+		for _, detail := range status.Convert(err).Details() {
+			for _, violation := range detail.(*errdetails.BadRequest).FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+		require.Equal(t, []string{"vocab.id", "vocab.forms[0].id", "set_id"}, fields)
+	})
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	cfg := config.JWTConfig{Algorithm: "HS256", Secret: "secret", Issuer: "test", TTL: time.Hour}
+	issuer, err := auth.NewJWTIssuer(cfg)
+	require.NoError(t, err)
+	verifier, err := auth.NewJWTVerifier(cfg)
+	require.NoError(t, err)
+
+	interceptor := authInterceptor(verifier, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/danish.v1.WordPoolService/GetBatch"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return userid.MustFromCtx(ctx), nil
+	}
+	call := func(md metadata.MD) (any, error) {
+		return interceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+	}
+
+	raw, err := issuer.Mint("user", nil, 0)
+	require.NoError(t, err)
+	uid, err := call(metadata.Pairs("authorization", "Bearer "+raw))
+	require.NoError(t, err)
+	require.Equal(t, "user", uid)
+
+	_, err = call(metadata.MD{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(metadata.Pairs("authorization", "Bearer nonsense"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,223 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/common/rand"
+	"github.com/vladazn/danish/config"
+)
+
+// testServer is a server on a LocalStore behind an in-memory listener.
+type testServer struct {
+	conn    *grpc.ClientConn
+	metrics *metrics.Metrics
+	issuer  *auth.JWTIssuer
+}
+
+func newTestServer(t *testing.T, rateCfg config.RateLimitConfig) *testServer {
+	t.Helper()
+
+	jwtCfg := config.JWTConfig{Algorithm: "HS256", Secret: "secret", Issuer: "test", TTL: time.Hour}
+	issuer, err := auth.NewJWTIssuer(jwtCfg)
+	require.NoError(t, err)
+	verifier, err := auth.NewJWTVerifier(jwtCfg)
+	require.NoError(t, err)
+
+	store, err := classroom.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	m := metrics.New()
+
+	srv, err := NewServer(NewServerParams{
+		Logger:   zap.NewNop(),
+		LogCfg:   &config.LogConfig{AccessSampleFirst: 100, AccessSampleThereafter: 10},
+		Verifier: verifier,
+		APIKeys:  classroom.NewAPIKeyService(classroom.NewAPIKeyServiceParams{Store: store}),
+		Dict:     classroom.NewDictionary(classroom.NewDictionaryParams{Store: store}),
+		Pool:     classroom.NewWordPool(classroom.NewWordPoolParams{Rand: rand.New(), Store: store, Metrics: m}),
+		Set:      classroom.NewSetService(classroom.NewSetServiceParams{Store: store}),
+		Metrics:  m,
+		RateCfg:  &rateCfg,
+		Limiter:  ratelimit.NewMemoryLimiter(),
+	})
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &testServer{conn: conn, metrics: m, issuer: issuer}
+}
+
+// userCtx returns a context sending a token of uid with every call.
+func (s *testServer) userCtx(t *testing.T, uid string) context.Context {
+	t.Helper()
+
+	token, err := s.issuer.Mint(uid, nil, 0)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func newHouse() *pb.Vocab {
+	return &pb.Vocab{
+		Definition:   "house",
+		PartOfSpeech: "noun",
+		Forms:        []*pb.VocabForm{{Value: "et hus", Form: "indefinite_singular"}},
+	}
+}
+
+func TestServer_RoundTrip(t *testing.T) {
+	s := newTestServer(t, config.RateLimitConfig{IP: "100/1m", User: "100/1m"})
+	ctx := s.userCtx(t, "user")
+	dict := pb.NewDictionaryServiceClient(s.conn)
+	sets := pb.NewSetServiceClient(s.conn)
+	pool := pb.NewWordPoolServiceClient(s.conn)
+
+	_, err := dict.ListWords(context.Background(), &pb.ListWordsRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	house, err := dict.AddWord(ctx, &pb.AddWordRequest{Vocab: newHouse()})
+	require.NoError(t, err)
+	require.NotEmpty(t, house.GetId())
+
+	got, err := dict.GetWord(ctx, &pb.GetWordRequest{Id: house.GetId()})
+	require.NoError(t, err)
+	require.Equal(t, "house", got.GetDefinition())
+
+	_, err = dict.GetWord(ctx, &pb.GetWordRequest{Id: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	patched, err := dict.PatchWord(ctx, &pb.PatchWordRequest{
+		Id:    house.GetId(),
+		Patch: []byte(`{"definition": "a house"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a house", patched.GetDefinition())
+
+	patched, err = dict.PatchForm(ctx, &pb.PatchFormRequest{
+		VocabId: house.GetId(),
+		FormId:  house.GetForms()[0].GetId(),
+		Patch:   []byte(`{"value": "huset", "form": "definite_singular"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "huset", patched.GetForms()[0].GetValue())
+
+	tree := &pb.Vocab{
+		Definition:   "tree",
+		PartOfSpeech: "noun",
+		Forms:        []*pb.VocabForm{{Value: "et træ", Form: "indefinite_singular"}},
+	}
+	imported, err := dict.ImportWords(ctx, &pb.ImportWordsRequest{
+		Vocabs: []*pb.Vocab{{Id: house.GetId(), Definition: "a house", PartOfSpeech: "noun"}, tree},
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, imported.GetResults(), 2)
+	require.Equal(t, "merged", imported.GetResults()[0].GetStatus())
+	require.Equal(t, house.GetId(), imported.GetResults()[0].GetId())
+	require.Equal(t, "created", imported.GetResults()[1].GetStatus())
+
+	// The dry run wrote nothing.
+	words, err := dict.ListWords(ctx, &pb.ListWordsRequest{})
+	require.NoError(t, err)
+	require.Len(t, words.GetVocabs(), 1)
+
+	set, err := sets.AddSet(ctx, &pb.AddSetRequest{Name: "Home", VocabIds: []string{house.GetId()}})
+	require.NoError(t, err)
+	listed, err := sets.ListSets(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, listed.GetSets(), 1)
+	require.Equal(t, set.GetId(), listed.GetSets()[0].GetId())
+
+	_, err = pool.GetBatch(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	s.metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	scraped := w.Body.String()
+	require.Contains(t, scraped, `danish_grpc_calls_total{code="OK",method="/danish.v1.DictionaryService/AddWord"} 1`)
+	require.Contains(t, scraped, `danish_grpc_calls_total{code="InvalidArgument",method="/danish.v1.DictionaryService/GetWord"} 1`)
+	require.Contains(t, scraped, `danish_grpc_calls_total{code="Unauthenticated",method="/danish.v1.DictionaryService/ListWords"} 1`)
+	require.Contains(t, scraped, `danish_grpc_call_duration_seconds_count{method="/danish.v1.WordPoolService/GetBatch"} 1`)
+}
+
+func TestServer_RateLimits(t *testing.T) {
+	exhausted := func(t *testing.T, err error) {
+		t.Helper()
+
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		require.Positive(t, details[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+	}
+
+	t.Run("by user and route", func(t *testing.T) {
+		s := newTestServer(t, config.RateLimitConfig{
+			Enabled: true,
+			IP:      "100/1m",
+			User:    "3/1m",
+			Routes:  map[string]string{"POST /vocab": "1/1m"},
+		})
+		ctx := s.userCtx(t, "user")
+		dict := pb.NewDictionaryServiceClient(s.conn)
+
+		var header metadata.MD
+		_, err := dict.AddWord(ctx, &pb.AddWordRequest{Vocab: newHouse()}, grpc.Header(&header))
+		require.NoError(t, err)
+		require.Equal(t, []string{"1"}, header.Get("x-ratelimit-limit"))
+		require.Equal(t, []string{"0"}, header.Get("x-ratelimit-remaining"))
+		_, err = dict.AddWord(ctx, &pb.AddWordRequest{Vocab: newHouse()})
+		exhausted(t, err)
+
+		// Other calls share the user budget.
+		for range 3 {
+			_, err = dict.ListWords(ctx, &pb.ListWordsRequest{})
+			require.NoError(t, err)
+		}
+		_, err = dict.ListWords(ctx, &pb.ListWordsRequest{})
+		exhausted(t, err)
+
+		// Every user has a budget of their own.
+		_, err = dict.ListWords(s.userCtx(t, "other"), &pb.ListWordsRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("by address before authentication", func(t *testing.T) {
+		s := newTestServer(t, config.RateLimitConfig{Enabled: true, IP: "1/1m", User: "100/1m"})
+		dict := pb.NewDictionaryServiceClient(s.conn)
+
+		_, err := dict.ListWords(context.Background(), &pb.ListWordsRequest{})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = dict.ListWords(s.userCtx(t, "user"), &pb.ListWordsRequest{})
+		exhausted(t, err)
+	})
+}
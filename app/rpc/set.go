@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/rpc/pb"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+)
+
+// setServer exposes classroom.SetService.
+type setServer struct {
+	pb.UnimplementedSetServiceServer
+	set *classroom.SetService
+}
+
+func (s *setServer) ListSets(ctx context.Context, _ *emptypb.Empty) (*pb.ListSetsResponse, error) {
+	sets, err := s.set.GetSetList(ctx, userid.MustFromCtx(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	resp := &pb.ListSetsResponse{Sets: make([]*pb.VocabSet, len(sets))}
+	for i, set := range sets {
+		resp.Sets[i] = setToPB(set)
+	}
+	return resp, nil
+}
+
+func (s *setServer) GetSetBatch(ctx context.Context, req *pb.GetSetBatchRequest) (*pb.Batch, error) {
+	var val validate.Validator
+	setId := parseRequiredId(&val, "set_id", req.GetSetId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	batch, err := s.set.GetSetVocabBatch(ctx, userid.MustFromCtx(ctx), setId, max(int(req.GetLimit()), 0))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return batchToPB(batch), nil
+}
+
+func (s *setServer) AddSet(ctx context.Context, req *pb.AddSetRequest) (*pb.VocabSet, error) {
+	var val validate.Validator
+	vocabIds := parseIds(&val, "vocab_ids", req.GetVocabIds())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	set, err := s.set.AddSet(ctx, userid.MustFromCtx(ctx), req.GetName(), vocabIds)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return setToPB(*set), nil
+}
+
+func (s *setServer) UpdateSet(ctx context.Context, req *pb.UpdateSetRequest) (*emptypb.Empty, error) {
+	var val validate.Validator
+	setId := parseRequiredId(&val, "set_id", req.GetSetId())
+	vocabIds := parseIds(&val, "vocab_ids", req.GetVocabIds())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.set.UpdateSet(ctx, userid.MustFromCtx(ctx), setId, req.GetName(), vocabIds); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *setServer) RemoveSet(ctx context.Context, req *pb.RemoveSetRequest) (*emptypb.Empty, error) {
+	var val validate.Validator
+	setId := parseRequiredId(&val, "set_id", req.GetSetId())
+	if err := val.Err(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.set.RemoveSet(ctx, userid.MustFromCtx(ctx), setId); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
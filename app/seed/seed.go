@@ -0,0 +1,32 @@
+// Package seed holds a small starter vocabulary for new and test accounts.
+package seed
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/transfer"
+)
+
+//go:embed starter.csv
+var starter []byte
+
+// Vocab returns the starter vocabulary without ids.
+func Vocab() ([]model.Vocab, error) {
+	rows, err := transfer.ReadCSV(bytes.NewReader(starter), ',', nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not read starter vocabulary: %w", err)
+	}
+
+	vocabs := make([]model.Vocab, 0, len(rows))
+	for _, row := range rows {
+		if row.Err != nil {
+			return nil, fmt.Errorf("starter vocabulary line %d: %w", row.Line, row.Err)
+		}
+		vocabs = append(vocabs, row.Vocab)
+	}
+
+	return vocabs, nil
+}
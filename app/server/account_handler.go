@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+// maxArchiveSize limits the size of an uploaded account archive.
+const maxArchiveSize = 50 << 20
+
+// @Summary Export the account
+// @Description Returns a versioned JSON archive of all data stored for the user: vocab, sets, the learning pool, published sets, API key metadata, the role and class memberships. Not allowed with an API key.
+// @Tags account
+// @Produce json
+// @Success 200 {object} model.AccountArchive
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /account/export [get]
+func (h *handler) handleExportAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	archive, err := h.account.Export(ctx, userId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="account.json"`)
+	json.NewEncoder(w).Encode(archive)
+}
+
+// @Summary Import an account archive
+// @Description Restores the vocab, sets and learning pool of an archive from the export endpoint; published sets, API keys, the role and class memberships are not restored. The account must not have any vocab or sets yet. Not allowed with an API key.
+// @Tags account
+// @Accept json
+// @Param archive body model.AccountArchive true "Account archive"
+// @Success 204
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Failure 409 {object} Problem "Account is not empty"
+// @Failure 413 {object} Problem "Request Entity Too Large"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /account/import [post]
+func (h *handler) handleImportAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	var archive model.AccountArchive
+	if !decodeBody(w, r, &archive, maxArchiveSize) {
+		return
+	}
+
+	if err := h.account.Import(ctx, userId, archive); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Delete the account
+// @Description Permanently removes all data stored for the user. Not allowed with an API key.
+// @Tags account
+// @Success 204
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /account [delete]
+func (h *handler) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	if err := h.account.Delete(ctx, userId); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
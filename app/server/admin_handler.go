@@ -0,0 +1,177 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+)
+
+type RoleResponse struct {
+	UserId string     `json:"user_id"`
+	Role   model.Role `json:"role"`
+}
+
+type SetRoleRequest struct {
+	Role model.Role `json:"role"`
+}
+
+func (req *SetRoleRequest) check(val *validate.Validator) {
+	val.Check(req.Role.Valid(), "role", "must be learner, teacher or admin")
+}
+
+// @Summary Get the own role
+// @Description Returns the role of the authenticated user: learner, teacher or admin. Not allowed with an API key.
+// @Tags account
+// @Produce json
+// @Success 200 {object} RoleResponse
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Router /account/role [get]
+func (h *handler) handleGetOwnRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RoleResponse{UserId: userId, Role: roleFromCtx(ctx)})
+}
+
+// @Summary List users
+// @Description Lists the ids of all users with stored data. Admin only, not allowed with an API key.
+// @Tags admin
+// @Produce json
+// @Success 200 {array} string
+// @Failure 403 {object} Problem "Requires the admin role, not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /admin/users [get]
+func (h *handler) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.admin.ListUsers(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(users)
+}
+
+// @Summary Get user stats
+// @Description Returns vocab, set and pool counts of any user. Admin only, not allowed with an API key.
+// @Tags admin
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} v1.UserStats
+// @Failure 403 {object} Problem "Requires the admin role, not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /admin/users/{userId}/stats [get]
+func (h *handler) handleUserStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.admin.Stats(r.Context(), chi.URLParam(r, "userId"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromUserStats(stats))
+}
+
+// @Summary Export a user
+// @Description Returns the account archive of any user. Admin only, not allowed with an API key.
+// @Tags admin
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} model.AccountArchive
+// @Failure 403 {object} Problem "Requires the admin role, not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /admin/users/{userId}/export [get]
+func (h *handler) handleExportUser(w http.ResponseWriter, r *http.Request) {
+	archive, err := h.account.Export(r.Context(), chi.URLParam(r, "userId"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="account.json"`)
+	json.NewEncoder(w).Encode(archive)
+}
+
+// @Summary Repair a user
+// @Description Brings the stored data of any user up to date with the current model: missing form ids and times, the search index and set and pool entries of removed vocab. Admin only, not allowed with an API key.
+// @Tags admin
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param dry_run query bool false "Only report what would be repaired"
+// @Success 200 {object} v1.RepairReport
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 403 {object} Problem "Requires the admin role, not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /admin/users/{userId}/repair [post]
+func (h *handler) handleRepairUser(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	report, err := h.admin.Repair(r.Context(), chi.URLParam(r, "userId"), dryRun)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromRepairReport(report))
+}
+
+// @Summary Get a user's role
+// @Description Returns the stored role of any user. Roles from token claims are not known here. Admin only, not allowed with an API key.
+// @Tags admin
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} RoleResponse
+// @Failure 403 {object} Problem "Requires the admin role, not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /admin/users/{userId}/role [get]
+func (h *handler) handleGetUserRole(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "userId")
+
+	role, err := h.roles.GetRole(r.Context(), userId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RoleResponse{UserId: userId, Role: role})
+}
+
+// @Summary Set a user's role
+// @Description Stores the role of any user. Roles in token claims take precedence. Admin only, not allowed with an API key.
+// @Tags admin
+// @Accept json
+// @Param userId path string true "User ID"
+// @Param role body SetRoleRequest true "Role"
+// @Success 204
+// @Failure 400 {object} Problem "Invalid role"
+// @Failure 403 {object} Problem "Requires the admin role, not allowed with an API key"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /admin/users/{userId}/role [put]
+func (h *handler) handleSetUserRole(w http.ResponseWriter, r *http.Request) {
+	var req SetRoleRequest
+	if !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	if err := h.roles.SetRole(r.Context(), chi.URLParam(r, "userId"), req.Role); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/userid"
+)
+
+type CreateAPIKeyRequest struct {
+	Name   string              `json:"name"`
+	Scopes []model.APIKeyScope `json:"scopes"`
+	// RateLimit is in requests per minute, 0 for the default.
+	RateLimit int `json:"rate_limit"`
+}
+
+// @Summary List API keys
+// @Description Lists the API keys of the authenticated user. The keys themselves are not shown. Not allowed with an API key.
+// @Tags account
+// @Produce json
+// @Success 200 {array} v1.APIKey
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /account/api-keys [get]
+func (h *handler) handleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	keys, err := h.apiKeys.List(ctx, userId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromAPIKeys(keys))
+}
+
+// @Summary Create an API key
+// @Description Creates a long-lived key for scripts, sent as X-API-Key header or bearer token. The key is only returned by this request. A read scope allows GET requests, write allows all. Not allowed with an API key.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param key body CreateAPIKeyRequest true "Name, scopes and rate limit"
+// @Success 201 {object} v1.CreatedAPIKey
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Failure 409 {object} Problem "Too many API keys"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /account/api-keys [post]
+func (h *handler) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	var req CreateAPIKeyRequest
+	if !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	key, err := h.apiKeys.Create(ctx, userId, req.Name, req.Scopes, req.RateLimit)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v1.FromCreatedAPIKey(*key))
+}
+
+// @Summary Revoke an API key
+// @Description Removes an API key of the authenticated user. Not allowed with an API key.
+// @Tags account
+// @Param keyId path string true "API key ID"
+// @Success 204
+// @Failure 403 {object} Problem "Not allowed with an API key"
+// @Failure 404 {object} Problem "API key not found"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /account/api-keys/{keyId} [delete]
+func (h *handler) handleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	if err := h.apiKeys.Revoke(ctx, userId, chi.URLParam(r, "keyId")); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
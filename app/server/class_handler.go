@@ -0,0 +1,378 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+)
+
+type CreateClassRequest struct {
+	Name string `json:"name"`
+}
+
+type JoinClassRequest struct {
+	InviteCode string `json:"invite_code"`
+}
+
+func (req *JoinClassRequest) check(val *validate.Validator) {
+	val.Required("invite_code", req.InviteCode)
+}
+
+type AssignSetRequest struct {
+	SetId uuid.UUID `json:"set_id"`
+	// Name defaults to the name of the set.
+	Name  string    `json:"name"`
+	DueAt time.Time `json:"due_at"`
+}
+
+func (req *AssignSetRequest) check(val *validate.Validator) {
+	val.Check(req.SetId != uuid.Nil, "set_id", "is required")
+	val.MaxLength("name", req.Name, classroom.MaxSetNameLength)
+	val.Check(!req.DueAt.IsZero(), "due_at", "is required")
+}
+
+// classIdParam parses the class id of the route, writing a 400 if invalid.
+func classIdParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	classId, err := uuid.Parse(chi.URLParam(r, "classId"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid class ID")
+		return uuid.Nil, false
+	}
+	return classId, true
+}
+
+// @Summary List classes
+// @Description Lists the classes the authenticated user teaches or attends. Invite codes and students are only shown to the teacher.
+// @Tags classes
+// @Produce json
+// @Success 200 {array} v1.Class
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes [get]
+func (h *handler) handleListClasses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classes, err := h.class.ListClasses(ctx, userId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromClasses(classes))
+}
+
+// @Summary Create a class
+// @Description Creates a class taught by the authenticated user with a new invite code. Teachers only, not allowed with an API key.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param class body CreateClassRequest true "Class information"
+// @Success 201 {object} v1.Class
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes [post]
+func (h *handler) handleCreateClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	var req CreateClassRequest
+	if !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	class, err := h.class.CreateClass(ctx, userId, req.Name)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v1.FromClass(*class))
+}
+
+// @Summary Join a class
+// @Description Enrolls the authenticated user in the class with the invite code and copies its assignments into the user's library
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param request body JoinClassRequest true "Invite code"
+// @Success 200 {object} v1.Class
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 404 {object} Problem "Invalid invite code"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/join [post]
+func (h *handler) handleJoinClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	var req JoinClassRequest
+	if !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	class, err := h.class.Join(ctx, userId, req.InviteCode)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromClass(*class))
+}
+
+// @Summary Get a class
+// @Description Returns a class the authenticated user teaches or attends
+// @Tags classes
+// @Produce json
+// @Param classId path string true "Class ID"
+// @Success 200 {object} v1.Class
+// @Failure 400 {object} Problem "Invalid class ID"
+// @Failure 404 {object} Problem "Class not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId} [get]
+func (h *handler) handleGetClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	class, err := h.class.GetClass(ctx, userId, classId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromClass(*class))
+}
+
+// @Summary Delete a class
+// @Description Removes a class and its assignments. Vocab copied to the students stays in their libraries. Teachers only, not allowed with an API key.
+// @Tags classes
+// @Param classId path string true "Class ID"
+// @Success 204
+// @Failure 400 {object} Problem "Invalid class ID"
+// @Failure 403 {object} Problem "Not the teacher of the class"
+// @Failure 404 {object} Problem "Class not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId} [delete]
+func (h *handler) handleDeleteClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.class.DeleteClass(ctx, userId, classId); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Rotate the invite code
+// @Description Replaces the invite code of a class so the old code stops working. Teachers only, not allowed with an API key.
+// @Tags classes
+// @Produce json
+// @Param classId path string true "Class ID"
+// @Success 200 {object} v1.Class
+// @Failure 400 {object} Problem "Invalid class ID"
+// @Failure 403 {object} Problem "Not the teacher of the class"
+// @Failure 404 {object} Problem "Class not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId}/invite [post]
+func (h *handler) handleRotateInviteCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	class, err := h.class.RotateInviteCode(ctx, userId, classId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromClass(*class))
+}
+
+// @Summary Remove a student
+// @Description Takes a student out of a class. Teachers may remove any student, students only themselves.
+// @Tags classes
+// @Param classId path string true "Class ID"
+// @Param studentId path string true "Student user ID"
+// @Success 204
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 403 {object} Problem "Not the teacher of the class"
+// @Failure 404 {object} Problem "Class not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId}/students/{studentId} [delete]
+func (h *handler) handleRemoveStudent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.class.RemoveStudent(ctx, userId, classId, chi.URLParam(r, "studentId")); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary List assignments
+// @Description Lists the assignments of a class ordered by due date. Students only see their own copy of each assignment.
+// @Tags classes
+// @Produce json
+// @Param classId path string true "Class ID"
+// @Success 200 {array} v1.Assignment
+// @Failure 400 {object} Problem "Invalid class ID"
+// @Failure 404 {object} Problem "Class not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId}/assignments [get]
+func (h *handler) handleListAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.class.ListAssignments(ctx, userId, classId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromAssignments(assignments))
+}
+
+// @Summary Assign a set
+// @Description Assigns one of the teacher's sets to the class with a due date. The vocab is copied without progress into every student's library, merging with words they already have. Teachers only, not allowed with an API key.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param classId path string true "Class ID"
+// @Param request body AssignSetRequest true "Assignment"
+// @Success 201 {object} v1.Assignment
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 403 {object} Problem "Not the teacher of the class"
+// @Failure 404 {object} Problem "Class or set not found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId}/assignments [post]
+func (h *handler) handleAssignSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req AssignSetRequest
+	if !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	assignment, err := h.class.Assign(ctx, userId, classId, req.SetId, req.Name, req.DueAt)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v1.FromAssignment(*assignment))
+}
+
+// @Summary Remove an assignment
+// @Description Removes an assignment from a class. The copies in the students' libraries are kept. Teachers only, not allowed with an API key.
+// @Tags classes
+// @Param classId path string true "Class ID"
+// @Param assignmentId path string true "Assignment ID"
+// @Success 204
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 403 {object} Problem "Not the teacher of the class"
+// @Failure 404 {object} Problem "Class or assignment not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId}/assignments/{assignmentId} [delete]
+func (h *handler) handleRemoveAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+	assignmentId, err := uuid.Parse(chi.URLParam(r, "assignmentId"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid assignment ID")
+		return
+	}
+
+	if err := h.class.RemoveAssignment(ctx, userId, classId, assignmentId); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Class dashboard
+// @Description Shows the teacher each student's progress on each assignment, computed from the levels of the assigned vocab in the student's library. Teachers only, not allowed with an API key.
+// @Tags classes
+// @Produce json
+// @Param classId path string true "Class ID"
+// @Success 200 {object} v1.ClassDashboard
+// @Failure 400 {object} Problem "Invalid class ID"
+// @Failure 403 {object} Problem "Not the teacher of the class"
+// @Failure 404 {object} Problem "Class not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classes/{classId}/dashboard [get]
+func (h *handler) handleClassDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	classId, ok := classIdParam(w, r)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.class.Dashboard(ctx, userId, classId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromClassDashboard(*dashboard))
+}
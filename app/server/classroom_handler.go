@@ -10,26 +10,28 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	v1 "github.com/vladazn/danish/app/api/v1"
 	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
 	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/logger"
 	"github.com/vladazn/danish/common/userid"
 )
 
 type ClassroomHandlerParams struct {
 	fx.In
-	FirebaseApp *FirebaseClient
-	Logger      *zap.Logger
-	Dict        *classroom.Dictionary
-	Pool        *classroom.WordPool
-	Set         *classroom.SetService
+	Logger *zap.Logger
+	Dict   *classroom.Dictionary
+	Pool   *classroom.WordPool
+	Set    *classroom.SetService
 }
 
 // @Summary Get a new batch of words from the pool
 // @Description Fetches a batch of 20 vocab entries from the user's learning pool
 // @Tags pool
 // @Produce json
-// @Success 200 {object} model.Batch
-// @Failure 500 {string} string "Server error"
+// @Success 200 {object} v1.Batch
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/batch [get]
 func (h *handler) handleGetBatch(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -37,17 +39,17 @@ func (h *handler) handleGetBatch(w http.ResponseWriter, r *http.Request) {
 
 	batch, err := h.pool.GetBatch(ctx, userId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(batch)
+	json.NewEncoder(w).Encode(v1.FromBatch(batch))
 }
 
 type BatchResult struct {
-	WithoutMistake []model.Vocab `json:"without_mistake"`
-	WithMistake    []model.Vocab `json:"with_mistake"`
+	WithoutMistake []v1.Vocab `json:"without_mistake"`
+	WithMistake    []v1.Vocab `json:"with_mistake"`
 }
 
 // @Summary Remove vocab from the learning pool
@@ -57,31 +59,38 @@ type BatchResult struct {
 // @Produce json
 // @Param vocab body BatchResult true "Vocab items to remove"
 // @Success 200
-// @Failure 400 {string} string "Invalid request"
-// @Failure 500 {string} string "Server error"
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/batch [post]
 func (h *handler) handleBatchResult(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userId := userid.MustFromCtx(ctx) // replace with your actual userID extraction
 
 	var batchResult BatchResult
-	if err := json.NewDecoder(r.Body).Decode(&batchResult); err != nil {
-		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+	if !decodeBody(w, r, &batchResult, maxBodySize) {
 		return
 	}
 
-	b, _ := json.Marshal(batchResult)
-	println(string(b))
+	logger.FromCtx(ctx).Debug("batch result",
+		zap.Int("without_mistake", len(batchResult.WithoutMistake)),
+		zap.Int("with_mistake", len(batchResult.WithMistake)),
+	)
 
-	if err := h.pool.RemoveFromPool(ctx, userId, batchResult.WithoutMistake); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	withoutMistake := v1.ToVocabs(batchResult.WithoutMistake)
+	withMistake := v1.ToVocabs(batchResult.WithMistake)
+
+	if err := h.pool.RemoveFromPool(ctx, userId, withoutMistake); err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
-	if err := h.dict.RegisterProgress(ctx, batchResult.WithoutMistake, batchResult.WithMistake); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.dict.RegisterProgress(ctx, withoutMistake, withMistake); err != nil {
+		h.writeError(w, r, err)
 		return
 	}
+	h.metrics.ObserveBatchResult(metrics.RatingCorrect, countForms(withoutMistake))
+	h.metrics.ObserveBatchResult(metrics.RatingMistake, countForms(withMistake))
 
 	w.WriteHeader(http.StatusOK)
 }
@@ -90,8 +99,8 @@ func (h *handler) handleBatchResult(w http.ResponseWriter, r *http.Request) {
 // @Description Fetches all vocab sets for the authenticated user
 // @Tags sets
 // @Produce json
-// @Success 200 {array} model.VocabSet
-// @Failure 500 {string} string "Server error"
+// @Success 200 {array} v1.VocabSet
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/sets [get]
 func (h *handler) handleGetSetList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -99,7 +108,7 @@ func (h *handler) handleGetSetList(w http.ResponseWriter, r *http.Request) {
 
 	sets, err := h.set.GetSetList(ctx, userId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 	if len(sets) == 0 {
@@ -107,7 +116,7 @@ func (h *handler) handleGetSetList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(sets)
+	json.NewEncoder(w).Encode(v1.FromVocabSets(sets))
 }
 
 // @Summary Get vocab batch from a specific set
@@ -116,10 +125,10 @@ func (h *handler) handleGetSetList(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param setId path string true "Set ID"
 // @Param limit query int false "Limit number of items (default: all)"
-// @Success 200 {object} model.Batch
-// @Failure 400 {string} string "Invalid request"
-// @Failure 404 {string} string "Set not found"
-// @Failure 500 {string} string "Server error"
+// @Success 200 {object} v1.Batch
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 404 {object} Problem "Set not found"
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/sets/{setId}/batch [get]
 func (h *handler) handleGetSetVocabBatch(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -129,7 +138,7 @@ func (h *handler) handleGetSetVocabBatch(w http.ResponseWriter, r *http.Request)
 	setIdStr := chi.URLParam(r, "setId")
 	setId, err := uuid.Parse(setIdStr)
 	if err != nil {
-		http.Error(w, "Invalid set ID", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid set ID")
 		return
 	}
 
@@ -143,16 +152,12 @@ func (h *handler) handleGetSetVocabBatch(w http.ResponseWriter, r *http.Request)
 
 	batch, err := h.set.GetSetVocabBatch(ctx, userId, setId, limit)
 	if err != nil {
-		if err.Error() == "vocab set not found" {
-			http.Error(w, "Set not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(batch)
+	json.NewEncoder(w).Encode(v1.FromBatch(batch))
 }
 
 // @Summary Add a new vocab set
@@ -161,34 +166,29 @@ func (h *handler) handleGetSetVocabBatch(w http.ResponseWriter, r *http.Request)
 // @Accept json
 // @Produce json
 // @Param set body AddSetRequest true "Set information"
-// @Success 200 {object} model.VocabSet
-// @Failure 400 {string} string "Invalid request"
-// @Failure 500 {string} string "Server error"
+// @Success 200 {object} v1.VocabSet
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/sets [post]
 func (h *handler) handleAddSet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userId := userid.MustFromCtx(ctx)
 
 	var req AddSetRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
-		return
-	}
-
-	if req.Name == "" {
-		http.Error(w, "Set name is required", http.StatusBadRequest)
+	if !decodeBody(w, r, &req, maxBodySize) {
 		return
 	}
 
 	set, err := h.set.AddSet(ctx, userId, req.Name, req.VocabIds)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(set)
+	json.NewEncoder(w).Encode(v1.FromVocabSet(*set))
 }
 
 // @Summary Update a vocab set
@@ -199,9 +199,10 @@ func (h *handler) handleAddSet(w http.ResponseWriter, r *http.Request) {
 // @Param setId path string true "Set ID"
 // @Param set body UpdateSetRequest true "Updated set information"
 // @Success 200
-// @Failure 400 {string} string "Invalid request"
-// @Failure 404 {string} string "Set not found"
-// @Failure 500 {string} string "Server error"
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 404 {object} Problem "Set not found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/sets/{setId} [put]
 func (h *handler) handleUpdateSet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -211,28 +212,18 @@ func (h *handler) handleUpdateSet(w http.ResponseWriter, r *http.Request) {
 	setIdStr := chi.URLParam(r, "setId")
 	setId, err := uuid.Parse(setIdStr)
 	if err != nil {
-		http.Error(w, "Invalid set ID", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid set ID")
 		return
 	}
 
 	var req UpdateSetRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
-		return
-	}
-
-	if req.Name == "" {
-		http.Error(w, "Set name is required", http.StatusBadRequest)
+	if !decodeBody(w, r, &req, maxBodySize) {
 		return
 	}
 
 	err = h.set.UpdateSet(ctx, userId, setId, req.Name, req.VocabIds)
 	if err != nil {
-		if err.Error() == "vocab set not found" {
-			http.Error(w, "Set not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
@@ -245,9 +236,9 @@ func (h *handler) handleUpdateSet(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param setId path string true "Set ID"
 // @Success 200
-// @Failure 400 {string} string "Invalid set ID"
-// @Failure 404 {string} string "Set not found"
-// @Failure 500 {string} string "Server error"
+// @Failure 400 {object} Problem "Invalid set ID"
+// @Failure 404 {object} Problem "Set not found"
+// @Failure 500 {object} Problem "Server error"
 // @Router /classroom/sets/{setId} [delete]
 func (h *handler) handleRemoveSet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -257,17 +248,13 @@ func (h *handler) handleRemoveSet(w http.ResponseWriter, r *http.Request) {
 	setIdStr := chi.URLParam(r, "setId")
 	setId, err := uuid.Parse(setIdStr)
 	if err != nil {
-		http.Error(w, "Invalid set ID", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid set ID")
 		return
 	}
 
 	err = h.set.RemoveSet(ctx, userId, setId)
 	if err != nil {
-		if err.Error() == "vocab set not found" {
-			http.Error(w, "Set not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
@@ -284,3 +271,11 @@ type UpdateSetRequest struct {
 	Name     string      `json:"name"`
 	VocabIds []uuid.UUID `json:"vocab_ids"`
 }
+
+func countForms(vocabs []model.Vocab) int {
+	n := 0
+	for _, vocab := range vocabs {
+		n += len(vocab.Forms)
+	}
+	return n
+}
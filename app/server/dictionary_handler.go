@@ -2,64 +2,310 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/classroom"
 	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/validate"
 )
 
 // @Summary Add vocab
+// @Description Fails with 409 when a vocab with the same normalized definition or form value exists.
 // @Tags vocab
 // @Accept json
 // @Produce json
-// @Param vocab body model.Vocab true "Vocabulary"
-// @Success 200 {object} model.Vocab
-// @Failure 400 {string} string "Bad Request"
-// @Failure 500 {string} string "Internal Server Error"
+// @Param vocab body v1.Vocab true "Vocabulary"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 409 {object} Problem "Duplicate"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
 // @Router /vocab [post]
 func (h *handler) handleAddWord(w http.ResponseWriter, r *http.Request) {
-	var v model.Vocab
-	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	var v v1.Vocab
+	if !decodeBody(w, r, &v, maxBodySize) {
 		return
 	}
 
-	addedWord, err := h.dict.AddWord(r.Context(), v)
+	addedWord, err := h.dict.AddWord(r.Context(), v.ToModel())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(addedWord)
+	json.NewEncoder(w).Encode(v1.FromVocab(addedWord))
 }
 
 // @Summary Update vocab
 // @Tags vocab
 // @Accept json
 // @Produce json
-// @Param vocab body model.Vocab true "Vocabulary"
-// @Success 200 {object} model.Vocab
-// @Failure 400 {string} string "Bad Request"
-// @Failure 500 {string} string "Internal Server Error"
+// @Param vocab body v1.Vocab true "Vocabulary"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
 // @Router /vocab [put]
 func (h *handler) handleUpdateWord(w http.ResponseWriter, r *http.Request) {
-	var v model.Vocab
-	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	var v v1.Vocab
+	if !decodeBody(w, r, &v, maxBodySize) {
 		return
 	}
 
-	updatedWord, err := h.dict.UpdateWord(r.Context(), v)
+	updatedWord, err := h.dict.UpdateWord(r.Context(), v.ToModel())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updatedWord)
+	json.NewEncoder(w).Encode(v1.FromVocab(updatedWord))
+}
+
+// @Summary Partially update vocab
+// @Description Applies a JSON Merge Patch (RFC 7396) to the vocab
+// @Tags vocab
+// @Accept json
+// @Produce json
+// @Param id path string true "Vocab ID"
+// @Param patch body object true "Merge patch"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/{id} [patch]
+func (h *handler) handlePatchWord(w http.ResponseWriter, r *http.Request) {
+	vocabId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	patchedWord, err := h.dict.PatchWord(r.Context(), vocabId, patch)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromVocab(patchedWord))
+}
+
+// @Summary Partially update vocab form
+// @Description Applies a JSON Merge Patch (RFC 7396) to a single form of the vocab
+// @Tags vocab
+// @Accept json
+// @Produce json
+// @Param id path string true "Vocab ID"
+// @Param formId path string true "Form ID"
+// @Param patch body object true "Merge patch"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/{id}/forms/{formId} [patch]
+func (h *handler) handlePatchForm(w http.ResponseWriter, r *http.Request) {
+	vocabId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
+		return
+	}
+
+	formId, err := uuid.Parse(chi.URLParam(r, "formId"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid form UUID")
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	patchedWord, err := h.dict.PatchForm(r.Context(), vocabId, formId, patch)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromVocab(patchedWord))
+}
+
+// @Summary Add vocab form
+// @Tags vocab
+// @Accept json
+// @Produce json
+// @Param id path string true "Vocab ID"
+// @Param form body v1.VocabForm true "Form"
+// @Success 201 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/{id}/forms [post]
+func (h *handler) handleAddForm(w http.ResponseWriter, r *http.Request) {
+	vocabId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
+		return
+	}
+
+	var form v1.VocabForm
+	if !decodeBody(w, r, &form, maxBodySize) {
+		return
+	}
+
+	updatedWord, err := h.dict.AddForm(r.Context(), vocabId, form.ToModel())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v1.FromVocab(updatedWord))
+}
+
+// @Summary Remove vocab form
+// @Tags vocab
+// @Produce json
+// @Param id path string true "Vocab ID"
+// @Param formId path string true "Form ID"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/{id}/forms/{formId} [delete]
+func (h *handler) handleRemoveForm(w http.ResponseWriter, r *http.Request) {
+	vocabId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
+		return
+	}
+
+	formId, err := uuid.Parse(chi.URLParam(r, "formId"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid form UUID")
+		return
+	}
+
+	updatedWord, err := h.dict.RemoveForm(r.Context(), vocabId, formId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromVocab(updatedWord))
+}
+
+// @Summary Merge duplicate vocab
+// @Description Combines the forms of the listed vocab into this one, keeping the higher progress
+// @Description of duplicate forms, points vocab sets to it and removes the listed vocab.
+// @Tags vocab
+// @Accept json
+// @Produce json
+// @Param id path string true "Vocab ID to merge into"
+// @Param request body MergeVocabRequest true "Vocab to merge"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/{id}/merge [post]
+func (h *handler) handleMergeWords(w http.ResponseWriter, r *http.Request) {
+	vocabId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
+		return
+	}
+
+	var req MergeVocabRequest
+	if !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	mergedWord, err := h.dict.MergeWords(r.Context(), vocabId, req.VocabIds)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromVocab(mergedWord))
+}
+
+// @Summary Add or update many vocab
+// @Description Creates or updates up to 300 vocab in one request. Vocab with an existing id is updated,
+// @Description the rest is created. Every item gets its own result, failed items do not stop the others.
+// @Tags vocab
+// @Accept json
+// @Produce json
+// @Param request body BulkVocabRequest true "Vocab to save"
+// @Success 200 {object} BulkResponse
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/bulk [post]
+func (h *handler) handleBulkSaveWords(w http.ResponseWriter, r *http.Request) {
+	var req BulkVocabRequest
+	if !decodeBody(w, r, &req, maxBulkBodySize) {
+		return
+	}
+
+	results, err := h.dict.BulkSaveWords(r.Context(), v1.ToVocabs(req.Vocabs))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(BulkResponse{Results: v1.FromBulkResults(results)})
+}
+
+// @Summary Remove many vocab
+// @Tags vocab
+// @Accept json
+// @Produce json
+// @Param request body BulkRemoveRequest true "Vocab ids to remove"
+// @Success 200 {object} BulkResponse
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/bulk [delete]
+func (h *handler) handleBulkRemoveWords(w http.ResponseWriter, r *http.Request) {
+	var req BulkRemoveRequest
+	if !decodeBody(w, r, &req, maxBulkBodySize) {
+		return
+	}
+
+	results, err := h.dict.BulkRemoveWords(r.Context(), req.Ids)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(BulkResponse{Results: v1.FromBulkResults(results)})
 }
 
 // @Summary Remove vocab
@@ -72,31 +318,220 @@ func (h *handler) handleRemoveWord(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	vocabId, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "Invalid UUID", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
 		return
 	}
 
 	if err := h.dict.RemoveWord(r.Context(), vocabId); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
-// @Summary Get all vocab for user
+// @Summary Get vocab for user
+// @Description Returns the user's vocabulary, optionally filtered, sorted and paginated.
+// @Description When more items are available a Link header with rel="next" is set.
 // @Tags vocab
 // @Produce json
-// @Success 200 {array} model.Vocab
+// @Param part_of_speech query string false "Part of speech"
+// @Param min_level query int false "Minimum vocab level"
+// @Param max_level query int false "Maximum vocab level"
+// @Param paused query bool false "Only paused (true) or only active (false) vocab"
+// @Param set_id query string false "Only vocab in this set"
+// @Param sort query string false "Sort field" Enums(definition, created_at, due_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (default: all)"
+// @Success 200 {array} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 422 {object} Problem "Validation failed"
 // @Failure 500
 // @Router /vocab [get]
 func (h *handler) handleGetAllWords(w http.ResponseWriter, r *http.Request) {
-	vocabs, err := h.dict.GetAllWords(r.Context())
+	query, err := parseVocabQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	page, err := h.dict.ListWords(r.Context(), query)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		next := *r.URL
+		params := next.Query()
+		params.Set("cursor", page.NextCursor)
+		next.RawQuery = params.Encode()
+		w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromVocabs(page.Vocabs))
+}
+
+// @Summary Get vocab
+// @Tags vocab
+// @Produce json
+// @Param id path string true "Vocab ID"
+// @Success 200 {object} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/{id} [get]
+func (h *handler) handleGetWord(w http.ResponseWriter, r *http.Request) {
+	vocabId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid UUID")
+		return
+	}
+
+	vocab, err := h.dict.GetWord(r.Context(), vocabId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromVocab(vocab))
+}
+
+// @Summary Search vocab
+// @Description Finds vocab whose definition or forms start with every word of the query.
+// @Description Matching ignores case, accents and the spelling of æ, ø and å; best matches come first.
+// @Tags vocab
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results (default: 20)"
+// @Success 200 {array} v1.Vocab
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/search [get]
+func (h *handler) handleSearchWords(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Query is required")
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid limit")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	vocabs, err := h.dict.Search(r.Context(), q, limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(vocabs)
+	json.NewEncoder(w).Encode(v1.FromVocabs(vocabs))
+}
+
+// @Summary Rebuild the search index
+// @Description Re-indexes all vocab of the user, e.g. for words added before search existed
+// @Tags vocab
+// @Success 204
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/search/reindex [post]
+func (h *handler) handleRebuildSearchIndex(w http.ResponseWriter, r *http.Request) {
+	if err := h.dict.RebuildSearchIndex(r.Context()); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseVocabQuery(params url.Values) (model.VocabQuery, error) {
+	query := model.VocabQuery{
+		PartOfSpeech: model.PartOfSpeech(params.Get("part_of_speech")),
+		SortBy:       model.VocabSort(params.Get("sort")),
+		Cursor:       params.Get("cursor"),
+	}
+
+	for name, dst := range map[string]**int{"min_level": &query.MinLevel, "max_level": &query.MaxLevel} {
+		if s := params.Get(name); s != "" {
+			level, err := strconv.Atoi(s)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s", name)
+			}
+			*dst = &level
+		}
+	}
+
+	if s := params.Get("paused"); s != "" {
+		paused, err := strconv.ParseBool(s)
+		if err != nil {
+			return query, fmt.Errorf("invalid paused")
+		}
+		query.Paused = &paused
+	}
+
+	if s := params.Get("set_id"); s != "" {
+		setId, err := uuid.Parse(s)
+		if err != nil {
+			return query, fmt.Errorf("invalid set_id")
+		}
+		query.SetId = setId
+	}
+
+	switch params.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, fmt.Errorf("invalid order")
+	}
+
+	if s := params.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil {
+			return query, fmt.Errorf("invalid limit")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// Request/Response types
+type MergeVocabRequest struct {
+	VocabIds []uuid.UUID `json:"vocab_ids"`
+}
+
+func (req *MergeVocabRequest) check(val *validate.Validator) {
+	val.Items("vocab_ids", len(req.VocabIds), 1, classroom.MaxBulkSize)
+}
+
+type BulkVocabRequest struct {
+	Vocabs []v1.Vocab `json:"vocabs"`
+}
+
+// check only limits the size; every vocab is validated on its own and
+// reported in its result.
+func (req *BulkVocabRequest) check(val *validate.Validator) {
+	val.Items("vocabs", len(req.Vocabs), 0, classroom.MaxBulkSize)
+}
+
+type BulkRemoveRequest struct {
+	Ids []uuid.UUID `json:"ids"`
+}
+
+func (req *BulkRemoveRequest) check(val *validate.Validator) {
+	val.Items("ids", len(req.Ids), 0, classroom.MaxBulkSize)
+}
+
+type BulkResponse struct {
+	Results []v1.BulkResult `json:"results"`
 }
@@ -2,10 +2,18 @@ package server
 
 import (
 	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
 )
 
 type handler struct {
-	dict *classroom.Dictionary
-	pool *classroom.WordPool
-	set  *classroom.SetService
+	dict    *classroom.Dictionary
+	pool    *classroom.WordPool
+	set     *classroom.SetService
+	account *classroom.AccountService
+	publish *classroom.PublishService
+	class   *classroom.ClassService
+	admin   *classroom.AdminService
+	roles   *classroom.RoleService
+	apiKeys *classroom.APIKeyService
+	metrics *metrics.Metrics
 }
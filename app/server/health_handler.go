@@ -0,0 +1,46 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vladazn/danish/app/health"
+)
+
+// @Summary Liveness probe
+// @Description Reports that the process is up and serving. It does not check dependencies, so a failing
+// @Description dependency does not get the process restarted.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Router /livez [get]
+func handleLivez(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// @Summary Readiness probe
+// @Description Checks the storage backend and the auth provider, each with a timeout, and reports whether every
+// @Description dependency passed. Results are reused for a few seconds; the errors are only logged. Fails with the
+// @Description status "draining" once the server is shutting down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report "Not ready"
+// @Router /readyz [get]
+func handleReadyz(checker *health.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Ready(r.Context()).Public()
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		writeHealthReport(w, status, report)
+	}
+}
+
+func writeHealthReport(w http.ResponseWriter, status int, report health.Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/app/health"
+)
+
+func TestHandleReadyz(t *testing.T) {
+	failing := health.Check{Name: "auth", Func: func(context.Context) error {
+		return errors.New("dial tcp 10.0.0.1:443: connection refused")
+	}}
+	handler := handleReadyz(health.NewChecker(time.Second, 0, zap.NewNop(), failing))
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.JSONEq(t, `{"status": "failing", "checks": {"auth": {"status": "failing"}}}`, w.Body.String())
+}
@@ -10,19 +10,39 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	"github.com/vladazn/danish/app/auth"
 	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/health"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/config"
 )
 
+// apiV1Prefix is where version 1 of the API is mounted.
+const apiV1Prefix = "/v1"
+
 type RouterParams struct {
 	fx.In
-	FirebaseApp *FirebaseClient
-	Logger      *zap.Logger
-	Dict        *classroom.Dictionary
-	Pool        *classroom.WordPool
-	Set         *classroom.SetService
+	Verifier auth.TokenVerifier
+	Logger   *zap.Logger
+	LogCfg   *config.LogConfig
+	Dict     *classroom.Dictionary
+	Pool     *classroom.WordPool
+	Set      *classroom.SetService
+	Account  *classroom.AccountService
+	Publish  *classroom.PublishService
+	Class    *classroom.ClassService
+	Admin    *classroom.AdminService
+	Roles    *classroom.RoleService
+	APIKeys  *classroom.APIKeyService
+	Metrics  *metrics.Metrics
+	RateCfg  *config.RateLimitConfig
+	Limiter  ratelimit.Limiter
+	Health   *health.Checker
 }
 
-func NewRouter(p RouterParams) *chi.Mux {
+func NewRouter(p RouterParams) (*chi.Mux, error) {
 	r := chi.NewRouter()
 	log := p.Logger.With(zap.String("type", "http"))
 	r.Use(cors.Handler(cors.Options{
@@ -33,47 +53,139 @@ func NewRouter(p RouterParams) *chi.Mux {
 				origin == "http://localhost" ||
 				len(origin) > 16 && origin[:17] == "http://localhost:"
 		},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Deprecation", "Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
-	r.Use(loggerMiddleware(log))
+	r.Use(tracingMiddleware())
+	r.Use(loggerMiddleware(log, p.LogCfg))
 	r.Use(middleware.Recoverer)
-	r.Use(firebaseAuthMiddleware(p.FirebaseApp, log))
+	r.Use(metricsMiddleware(p.Metrics))
 
-	h := &handler{p.Dict, p.Pool, p.Set}
+	// The probes come without credentials. Metrics are served on their own
+	// port, see metrics.RegisterHooks.
+	r.Get("/livez", handleLivez)
+	r.Get("/healthz", handleLivez)
+	r.Get("/readyz", handleReadyz(p.Health))
 
-	r.Route("/vocab", func(r chi.Router) {
-		r.Post("/", h.handleAddWord)
-		r.Put("/", h.handleUpdateWord)
-		r.Delete("/{id}", h.handleRemoveWord)
-		r.Get("/", h.handleGetAllWords)
-	})
+	h := &handler{p.Dict, p.Pool, p.Set, p.Account, p.Publish, p.Class, p.Admin, p.Roles, p.APIKeys, p.Metrics}
+
+	limits, err := newRateLimits(p.RateCfg, p.Limiter)
+	if err != nil {
+		return nil, err
+	}
 
-	r.Route("/classroom", func(r chi.Router) {
-		r.Get("/batch", h.handleGetBatch)
-		r.Post("/batch", h.handleBatchResult)
+	mux := r
+	api := func(r chi.Router) {
+		// Clients are limited by address before authentication, so bad
+		// credentials are limited too.
+		if p.RateCfg.Enabled {
+			r.Use(limits.ipMiddleware)
+		}
+		r.Use(authMiddleware(p.Verifier, p.APIKeys, p.Roles))
+		if p.RateCfg.Enabled {
+			r.Use(limits.userMiddleware(mux))
+		}
 
-		r.Route("/sets", func(r chi.Router) {
-			r.Get("/", h.handleGetSetList)
-			r.Post("/", h.handleAddSet)
-			r.Get("/{setId}/batch", h.handleGetSetVocabBatch)
-			r.Put("/{setId}", h.handleUpdateSet)
-			r.Delete("/{setId}", h.handleRemoveSet)
+		r.Route("/vocab", func(r chi.Router) {
+			r.Post("/", h.handleAddWord)
+			r.Put("/", h.handleUpdateWord)
+			r.Post("/bulk", h.handleBulkSaveWords)
+			r.Delete("/bulk", h.handleBulkRemoveWords)
+			r.Post("/import", h.handleImportWords)
+			r.Post("/import/anki", h.handleImportAnki)
+			r.Get("/export", h.handleExportWords)
+			r.Get("/export/anki", h.handleExportAnki)
+			r.Get("/search", h.handleSearchWords)
+			r.Post("/search/reindex", h.handleRebuildSearchIndex)
+			r.Get("/{id}", h.handleGetWord)
+			r.Patch("/{id}", h.handlePatchWord)
+			r.Delete("/{id}", h.handleRemoveWord)
+			r.Post("/{id}/merge", h.handleMergeWords)
+			r.Post("/{id}/forms", h.handleAddForm)
+			r.Patch("/{id}/forms/{formId}", h.handlePatchForm)
+			r.Delete("/{id}/forms/{formId}", h.handleRemoveForm)
+			r.Get("/", h.handleGetAllWords)
 		})
-	})
 
-	r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("ok"))
-	})
+		// API keys are for scripts working with vocab; the account, and the
+		// keys themselves, can only be managed with a session.
+		r.Route("/account", func(r chi.Router) {
+			r.Use(requireSession)
+
+			r.Get("/role", h.handleGetOwnRole)
+			r.Route("/api-keys", func(r chi.Router) {
+				r.Get("/", h.handleListAPIKeys)
+				r.Post("/", h.handleCreateAPIKey)
+				r.Delete("/{keyId}", h.handleRevokeAPIKey)
+			})
+			r.Get("/export", h.handleExportAccount)
+			r.Post("/import", h.handleImportAccount)
+			r.Delete("/", h.handleDeleteAccount)
+		})
+
+		r.Route("/classroom", func(r chi.Router) {
+			r.Get("/batch", h.handleGetBatch)
+			r.Post("/batch", h.handleBatchResult)
+
+			r.Route("/sets", func(r chi.Router) {
+				r.Get("/", h.handleGetSetList)
+				r.Post("/", h.handleAddSet)
+				r.Get("/{setId}/batch", h.handleGetSetVocabBatch)
+				r.Post("/{setId}/publish", h.handlePublishSet)
+				r.Put("/{setId}", h.handleUpdateSet)
+				r.Delete("/{setId}", h.handleRemoveSet)
+			})
+		})
+
+		r.Route("/published", func(r chi.Router) {
+			r.Get("/", h.handleBrowsePublished)
+			r.Get("/mine", h.handleListOwnPublished)
+			r.Get("/{shareId}", h.handlePreviewPublished)
+			r.Post("/{shareId}/clone", h.handleClonePublished)
+			r.Delete("/{shareId}", h.handleUnpublish)
+		})
+
+		r.Route("/classes", func(r chi.Router) {
+			// API keys carry the role of their owner, so teacher and admin
+			// routes are kept to sessions like the account routes.
+			teacher := r.With(requireSession, requireRole(model.RoleTeacher))
+
+			r.Get("/", h.handleListClasses)
+			teacher.Post("/", h.handleCreateClass)
+			r.Post("/join", h.handleJoinClass)
+			r.Get("/{classId}", h.handleGetClass)
+			teacher.Delete("/{classId}", h.handleDeleteClass)
+			teacher.Post("/{classId}/invite", h.handleRotateInviteCode)
+			r.Delete("/{classId}/students/{studentId}", h.handleRemoveStudent)
+			r.Get("/{classId}/assignments", h.handleListAssignments)
+			teacher.Post("/{classId}/assignments", h.handleAssignSet)
+			teacher.Delete("/{classId}/assignments/{assignmentId}", h.handleRemoveAssignment)
+			teacher.Get("/{classId}/dashboard", h.handleClassDashboard)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(requireSession, requireRole(model.RoleAdmin))
+
+			r.Get("/users", h.handleListUsers)
+			r.Get("/users/{userId}/stats", h.handleUserStats)
+			r.Get("/users/{userId}/export", h.handleExportUser)
+			r.Post("/users/{userId}/repair", h.handleRepairUser)
+			r.Get("/users/{userId}/role", h.handleGetUserRole)
+			r.Put("/users/{userId}/role", h.handleSetUserRole)
+		})
+	}
 
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
+	r.Route(apiV1Prefix, api)
+	// The unversioned routes predate /v1 and serve the same API, so
+	// deployed clients keep working while they move over.
+	r.Group(func(r chi.Router) {
+		r.Use(deprecatedMiddleware(apiV1Prefix))
+		api(r)
 	})
 
-	return r
+	return r, nil
 }
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/health"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/common/rand"
+	"github.com/vladazn/danish/config"
+)
+
+// testRouter is the router on a LocalStore, with "admin" configured as an
+// admin.
+type testRouter struct {
+	mux     *chi.Mux
+	issuer  *auth.JWTIssuer
+	apiKeys *classroom.APIKeyService
+}
+
+func newTestRouter(t *testing.T, rateCfg config.RateLimitConfig) *testRouter {
+	t.Helper()
+
+	jwtCfg := config.JWTConfig{Algorithm: "HS256", Secret: "secret", Issuer: "test", TTL: time.Hour}
+	issuer, err := auth.NewJWTIssuer(jwtCfg)
+	require.NoError(t, err)
+	verifier, err := auth.NewJWTVerifier(jwtCfg)
+	require.NoError(t, err)
+
+	store, err := classroom.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	m := metrics.New()
+	dict := classroom.NewDictionary(classroom.NewDictionaryParams{Store: store})
+	apiKeys := classroom.NewAPIKeyService(classroom.NewAPIKeyServiceParams{Store: store})
+
+	mux, err := NewRouter(RouterParams{
+		Verifier: verifier,
+		Logger:   zap.NewNop(),
+		LogCfg:   &config.LogConfig{AccessSampleFirst: 100, AccessSampleThereafter: 10},
+		Dict:     dict,
+		Pool:     classroom.NewWordPool(classroom.NewWordPoolParams{Rand: rand.New(), Store: store, Metrics: m}),
+		Set:      classroom.NewSetService(classroom.NewSetServiceParams{Store: store}),
+		Account:  classroom.NewAccountService(classroom.NewAccountServiceParams{Store: store}),
+		Publish:  classroom.NewPublishService(classroom.NewPublishServiceParams{Store: store, Dict: dict}),
+		Class:    classroom.NewClassService(classroom.NewClassServiceParams{Store: store, Dict: dict}),
+		Admin:    classroom.NewAdminService(classroom.NewAdminServiceParams{Store: store}),
+		Roles: classroom.NewRoleService(classroom.NewRoleServiceParams{
+			Store: store,
+			Cfg:   &config.AuthConfig{AdminIds: []string{"admin"}},
+		}),
+		APIKeys: apiKeys,
+		Metrics: m,
+		RateCfg: &rateCfg,
+		Limiter: ratelimit.NewMemoryLimiter(),
+		Health:  health.NewChecker(time.Second, 0, zap.NewNop()),
+	})
+	require.NoError(t, err)
+
+	return &testRouter{mux: mux, issuer: issuer, apiKeys: apiKeys}
+}
+
+// token returns a session token of uid.
+func (tr *testRouter) token(t *testing.T, uid string) string {
+	t.Helper()
+
+	token, err := tr.issuer.Mint(uid, nil, 0)
+	require.NoError(t, err)
+	return token
+}
+
+// apiKey returns a new API key of uid with both scopes.
+func (tr *testRouter) apiKey(t *testing.T, uid string) string {
+	t.Helper()
+
+	created, err := tr.apiKeys.Create(context.Background(), uid, "test",
+		[]model.APIKeyScope{model.APIKeyScopeRead, model.APIKeyScopeWrite}, 0)
+	require.NoError(t, err)
+	return created.Key
+}
+
+// do sends a request with the credential as bearer token.
+func (tr *testRouter) do(method, path, credential, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer "+credential)
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	tr.mux.ServeHTTP(w, r)
+	return w
+}
+
+func TestRouter_APIKeysAreKeptFromRoleRoutes(t *testing.T) {
+	tr := newTestRouter(t, config.RateLimitConfig{IP: "100/1m", User: "100/1m"})
+	session, key := tr.token(t, "admin"), tr.apiKey(t, "admin")
+
+	require.Equal(t, http.StatusOK, tr.do(http.MethodGet, "/v1/admin/users", session, "").Code)
+	// Admin routes take no API key, not even the key of an admin.
+	require.Equal(t, http.StatusForbidden, tr.do(http.MethodGet, "/v1/admin/users", key, "").Code)
+
+	class := `{"name": "Danish 1"}`
+	require.Equal(t, http.StatusCreated, tr.do(http.MethodPost, "/v1/classes", session, class).Code)
+	require.Equal(t, http.StatusForbidden, tr.do(http.MethodPost, "/v1/classes", key, class).Code)
+
+	// Routes open to learners still take the key.
+	require.Equal(t, http.StatusOK, tr.do(http.MethodGet, "/v1/classes", key, "").Code)
+}
+
+func TestRouter_MalformedAPIKey(t *testing.T) {
+	tr := newTestRouter(t, config.RateLimitConfig{IP: "100/1m", User: "100/1m"})
+
+	for _, key := range []string{"dk__x", "dk_a/b_x", "dk_../.._x"} {
+		require.Equal(t, http.StatusUnauthorized, tr.do(http.MethodGet, "/v1/vocab", key, "").Code, key)
+	}
+}
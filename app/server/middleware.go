@@ -1,15 +1,60 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/requestid"
 	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/config"
 )
 
+type roleCtxKey struct{}
+
+func roleToCtx(ctx context.Context, role model.Role) context.Context {
+	return context.WithValue(ctx, roleCtxKey{}, role)
+}
+
+type apiKeyCtxKey struct{}
+
+func apiKeyToCtx(ctx context.Context, key *model.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, key)
+}
+
+// apiKeyFromCtx returns the API key a request was made with, or nil if it
+// was made with an ID token.
+func apiKeyFromCtx(ctx context.Context) *model.APIKey {
+	key, _ := ctx.Value(apiKeyCtxKey{}).(*model.APIKey)
+	return key
+}
+
+// roleFromCtx returns the role set by the auth middleware, or learner.
+func roleFromCtx(ctx context.Context) model.Role {
+	if role, ok := ctx.Value(roleCtxKey{}).(model.Role); ok {
+		return role
+	}
+	return model.RoleLearner
+}
+
 // Helper to capture response status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -21,56 +66,262 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-func loggerMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
+// requestIdHeader carries the request id. An id sent by the client or a
+// proxy is kept, so a request can be followed across services.
+const requestIdHeader = "X-Request-ID"
+
+// accessEntry collects what inner middleware learns about a request for
+// the access log, which is written on the way out.
+type accessEntry struct {
+	uid string
+}
+
+type accessEntryCtxKey struct{}
+
+// setAccessUser records the user of a request for the access log.
+func setAccessUser(ctx context.Context, uid string) {
+	if entry, ok := ctx.Value(accessEntryCtxKey{}).(*accessEntry); ok {
+		entry.uid = uid
+	}
+}
+
+// loggerMiddleware assigns every request an id, puts a logger carrying it
+// in the context and writes a sampled access log. Failed requests are
+// always logged.
+func loggerMiddleware(log *zap.Logger, cfg *config.LogConfig) func(http.Handler) http.Handler {
+	access := log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, time.Second, cfg.AccessSampleFirst, cfg.AccessSampleThereafter)
+	}))
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
-			next.ServeHTTP(ww, r)
+			requestId := r.Header.Get(requestIdHeader)
+			if !requestid.Valid(requestId) {
+				requestId = uuid.NewString()
+			}
+			w.Header().Set(requestIdHeader, requestId)
+
+			fields := []zap.Field{zap.String("request_id", requestId)}
+			if spanCtx := trace.SpanContextFromContext(r.Context()); spanCtx.HasTraceID() {
+				fields = append(fields, zap.String("trace_id", spanCtx.TraceID().String()))
+			}
 
-			duration := time.Since(start)
+			entry := &accessEntry{}
+			ctx := requestid.ToCtx(r.Context(), requestId)
+			ctx = logger.ToCtx(ctx, log.With(fields...))
+			ctx = context.WithValue(ctx, accessEntryCtxKey{}, entry)
 
-			logger.Debug("HTTP request",
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			fields = append(fields,
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
+				zap.String("route", chi.RouteContext(ctx).RoutePattern()),
 				zap.Int("status", ww.statusCode),
 				zap.String("remote", r.RemoteAddr),
-				zap.Duration("duration", duration),
+				zap.Duration("duration", time.Since(start)),
 			)
+			if entry.uid != "" {
+				fields = append(fields, zap.String("uid", entry.uid))
+			}
+
+			if ww.statusCode >= http.StatusInternalServerError {
+				log.Error("HTTP request", fields...)
+				return
+			}
+			access.Info("HTTP request", fields...)
 		})
 	}
 }
 
-func firebaseAuthMiddleware(fa *FirebaseClient, log *zap.Logger) func(http.Handler) http.Handler {
+// authMiddleware verifies the bearer token or API key of a request and
+// puts the user and their role in the context. API keys are sent in the
+// X-API-Key header or as bearer token.
+func authMiddleware(
+	verifier auth.TokenVerifier, keys *classroom.APIKeyService, roles *classroom.RoleService,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-				http.Error(w, "Missing or invalid Authorization header", http.StatusUnauthorized)
-				return
+			credential := r.Header.Get("X-API-Key")
+			if credential == "" {
+				authHeader := r.Header.Get("Authorization")
+				if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+					writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid Authorization header")
+					return
+				}
+				credential = strings.TrimPrefix(authHeader, "Bearer ")
 			}
 
-			idToken := strings.TrimPrefix(authHeader, "Bearer ")
 			ctx := r.Context()
+			log := logger.FromCtx(ctx)
+			var uid string
+			var claims map[string]any
 
-			authClient, err := fa.app.Auth(ctx)
-			if err != nil {
-				http.Error(w, "Failed to initialize Firebase Auth", http.StatusInternalServerError)
-				return
+			if classroom.IsAPIKey(credential) {
+				key, ok := authenticateAPIKey(w, r, keys, credential)
+				if !ok {
+					return
+				}
+				uid = key.UserId
+				ctx = apiKeyToCtx(ctx, key)
+			} else {
+				token, err := verifier.Verify(ctx, credential)
+				if errors.Is(err, auth.ErrInvalidToken) {
+					log.Error("auth error on validate", zap.Error(err))
+					writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Invalid or expired token")
+					return
+				}
+				if err != nil {
+					log.Error("failed to verify token", zap.Error(err))
+					writeProblem(w, r, http.StatusInternalServerError, codeInternal, "Failed to verify token")
+					return
+				}
+				uid, claims = token.UID, token.Claims
 			}
 
-			token, err := authClient.VerifyIDToken(ctx, idToken)
+			role, err := roles.Resolve(ctx, uid, claims)
 			if err != nil {
-				log.Error("firebase auth error on validate", zap.Error(err))
-				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				log.Error("failed to resolve role", zap.String("uid", uid), zap.Error(err))
+				writeProblem(w, r, http.StatusInternalServerError, codeInternal, "Failed to resolve role")
 				return
 			}
 
-			uid := token.UID
 			ctx = userid.ToCtx(ctx, uid)
+			ctx = roleToCtx(ctx, role)
+			ctx = logger.ToCtx(ctx, log.With(zap.String("uid", uid)))
+			setAccessUser(ctx, uid)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// authenticateAPIKey checks an API key and whether its scope allows the
+// request, writing the error response if not.
+func authenticateAPIKey(
+	w http.ResponseWriter, r *http.Request, keys *classroom.APIKeyService, credential string,
+) (*model.APIKey, bool) {
+	key, err := keys.Authenticate(r.Context(), credential)
+	var rateLimitErr *classroom.RateLimitError
+	switch {
+	case errors.Is(err, classroom.ErrAPIKeyNotFound):
+		writeProblem(w, r, http.StatusUnauthorized, codeUnauthorized, "Invalid API key")
+		return nil, false
+	case errors.As(err, &rateLimitErr):
+		w.Header().Set("Retry-After", retryAfterSeconds(rateLimitErr.RetryAfter))
+		writeProblem(w, r, http.StatusTooManyRequests, codeRateLimited, "API key rate limit exceeded")
+		return nil, false
+	case err != nil:
+		logger.FromCtx(r.Context()).Error("failed to authenticate api key", zap.Error(err))
+		writeProblem(w, r, http.StatusInternalServerError, codeInternal, "Failed to authenticate API key")
+		return nil, false
+	}
+
+	scope := model.APIKeyScopeWrite
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		scope = model.APIKeyScopeRead
+	}
+	if !key.Allows(scope) {
+		writeProblem(w, r, http.StatusForbidden, codeForbidden, fmt.Sprintf("API key lacks the %s scope", scope))
+		return nil, false
+	}
+
+	return key, true
+}
+
+// requireRole only lets requests through whose user has at least the given
+// role.
+func requireRole(role model.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !roleFromCtx(r.Context()).Includes(role) {
+				writeProblem(w, r, http.StatusForbidden, codeForbidden, fmt.Sprintf("Requires the %s role", role))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireSession rejects requests made with an API key, so a leaked key
+// can not be used to create or revoke keys.
+func requireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKeyFromCtx(r.Context()) != nil {
+			writeProblem(w, r, http.StatusForbidden, codeForbidden, "Not allowed with an API key")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// metricsMiddleware records every request by its route pattern.
+func metricsMiddleware(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(ww, r)
+
+			// The pattern is only known once routing is done.
+			route := chi.RouteContext(r.Context()).RoutePattern()
+			if route == "" {
+				route = "unmatched"
+			}
+			m.ObserveHTTP(r.Method, route, ww.statusCode, time.Since(start))
+		})
+	}
+}
+
+// tracingMiddleware starts a server span for every request, continuing
+// the trace of the caller if it sent one. The span is named after the
+// route pattern once routing is done.
+func tracingMiddleware() func(http.Handler) http.Handler {
+	tracer := otel.Tracer("github.com/vladazn/danish/app/server")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if route := chi.RouteContext(ctx).RoutePattern(); route != "" {
+				span.SetName(r.Method + " " + route)
+				span.SetAttributes(semconv.HTTPRoute(route))
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(ww.statusCode))
+			if ww.statusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(ww.statusCode))
+			}
+		})
+	}
+}
+
+// unversionedDeprecatedAt is when the routes outside /v1 were deprecated.
+var unversionedDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// deprecatedMiddleware marks responses of the unversioned routes as
+// deprecated (RFC 9745) and links the same route under successorPrefix.
+func deprecatedMiddleware(successorPrefix string) func(http.Handler) http.Handler {
+	deprecation := fmt.Sprintf("@%d", unversionedDeprecatedAt.Unix())
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successorPrefix+r.URL.Path))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     model.Role
+		required model.Role
+		want     int
+	}{
+		{"no role is a learner", "", model.RoleLearner, http.StatusOK},
+		{"no role", "", model.RoleTeacher, http.StatusForbidden},
+		{"lower role", model.RoleLearner, model.RoleTeacher, http.StatusForbidden},
+		{"same role", model.RoleTeacher, model.RoleTeacher, http.StatusOK},
+		{"higher role", model.RoleAdmin, model.RoleTeacher, http.StatusOK},
+		{"teacher for admin", model.RoleTeacher, model.RoleAdmin, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := requireRole(tt.required)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+			if tt.role != "" {
+				r = r.WithContext(roleToCtx(r.Context(), tt.role))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			require.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusForbidden {
+				var problem Problem
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+				require.Equal(t, codeForbidden, problem.Code)
+				require.Equal(t, "Requires the "+string(tt.required)+" role", problem.Detail)
+			}
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	handler := requireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/account", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	r := httptest.NewRequest(http.MethodDelete, "/account", nil)
+	r = r.WithContext(apiKeyToCtx(r.Context(), &model.APIKey{Id: "key", Scopes: []model.APIKeyScope{model.APIKeyScopeWrite}}))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.New()
+	r := chi.NewRouter()
+	r.Use(metricsMiddleware(m))
+	r.Get("/vocab/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if chi.URLParam(r, "id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	for _, path := range []string{"/vocab/1", "/vocab/2", "/vocab/missing", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	scraped := w.Body.String()
+	// Requests are counted by route pattern, not by path.
+	require.Contains(t, scraped, `danish_http_requests_total{method="GET",route="/vocab/{id}",status="200"} 2`)
+	require.Contains(t, scraped, `danish_http_requests_total{method="GET",route="/vocab/{id}",status="404"} 1`)
+	require.Contains(t, scraped, `danish_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	require.Contains(t, scraped, `danish_http_request_duration_seconds_count{method="GET",route="/vocab/{id}"} 3`)
+}
@@ -11,28 +11,40 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/app/health"
+	"github.com/vladazn/danish/app/metrics"
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/app/rpc"
 	"github.com/vladazn/danish/config"
 )
 
 var Module = fx.Module(
 	"server",
+	auth.Module,
+	ratelimit.Module,
+	health.Module,
+	rpc.Module,
 	fx.Provide(
-		NewFirebaseClient,
 		NewRouter,
 	),
 	fx.Invoke(
 		RegisterHooks,
+		RegisterMigration,
+		metrics.RegisterHooks,
 	),
 )
 
 type ServerParams struct {
 	fx.In
 
-	Lifecycle      fx.Lifecycle
-	Cfg            *config.HttpServerConfig
-	FirebaseClient *FirebaseClient
-	Handler        *chi.Mux
-	Logger         *zap.Logger
+	Lifecycle fx.Lifecycle
+	Cfg       *config.HttpServerConfig
+	HealthCfg *config.HealthConfig
+	Handler   *chi.Mux
+	Health    *health.Checker
+	Logger    *zap.Logger
 }
 
 func RegisterHooks(p ServerParams) {
@@ -43,13 +55,9 @@ func RegisterHooks(p ServerParams) {
 
 	p.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			err := p.FirebaseClient.Connect(ctx)
-			if err != nil {
-				return err
-			}
 			go func() {
 				p.Logger.Info("Starting server on " + srv.Addr)
-				err = srv.ListenAndServe()
+				err := srv.ListenAndServe()
 				if !errors.Is(err, http.ErrServerClosed) {
 					p.Logger.Error("Error starting server", zap.Error(err))
 				}
@@ -58,6 +66,15 @@ func RegisterHooks(p ServerParams) {
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
+			// Fail the readiness probe first and keep serving until load
+			// balancers have noticed.
+			p.Health.Drain()
+			p.Logger.Info("Draining HTTP server...", zap.Duration("delay", p.HealthCfg.DrainDelay))
+			select {
+			case <-time.After(p.HealthCfg.DrainDelay):
+			case <-ctx.Done():
+			}
+
 			p.Logger.Info("Stopping HTTP server...")
 			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 			defer cancel()
@@ -65,3 +82,49 @@ func RegisterHooks(p ServerParams) {
 		},
 	})
 }
+
+type MigrationParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Cfg       *config.StorageConfig
+	Admin     *classroom.AdminService
+	Logger    *zap.Logger
+}
+
+// RegisterMigration migrates the stored data in the background once the
+// server has started, so an upgrade never waits on it.
+func RegisterMigration(p MigrationParams) {
+	if !p.Cfg.MigrateOnStart {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				users, err := p.Admin.Migrate(ctx)
+				if err != nil {
+					p.Logger.Error("Error migrating data", zap.Error(err), zap.Int("users", users))
+					return
+				}
+				if users > 0 {
+					p.Logger.Info("Migrated data",
+						zap.Int("version", classroom.DataVersion), zap.Int("users", users))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
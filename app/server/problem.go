@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/validate"
+)
+
+// Codes of errors that are not domain errors. Domain errors carry their
+// own code, see classroom.Error.
+const (
+	codeInvalidRequest  = "invalid_request"
+	codeUnauthorized    = "unauthorized"
+	codeForbidden       = "forbidden"
+	codeRateLimited     = "rate_limited"
+	codeRequestTooLarge = "request_too_large"
+	codeInternal        = "internal"
+)
+
+// problemTypePrefix turns an error code into the problem type URI.
+const problemTypePrefix = "urn:danish:problem:"
+
+// Problem is an RFC 7807 problem details body. Code repeats the last part
+// of Type for clients that only want the code.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	// Existing holds the vocab a duplicate_vocab error refers to.
+	Existing []v1.Vocab `json:"existing,omitempty"`
+	// Violations lists every rule a 422 request breaks.
+	Violations []validate.Violation `json:"violations,omitempty"`
+}
+
+// kindStatuses maps error kinds to response statuses.
+var kindStatuses = []struct {
+	kind   error
+	status int
+}{
+	{classroom.ErrNotFound, http.StatusNotFound},
+	{classroom.ErrValidation, http.StatusUnprocessableEntity},
+	{classroom.ErrConflict, http.StatusConflict},
+	{classroom.ErrForbidden, http.StatusForbidden},
+}
+
+// writeProblem writes a problem+json response.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code string, detail string) {
+	writeProblemBody(w, Problem{
+		Type:     problemTypePrefix + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Code:     code,
+	})
+}
+
+func writeProblemBody(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// writeBodyError writes the problem for a request body that could not be
+// read or decoded.
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeProblem(w, r, http.StatusRequestEntityTooLarge, codeRequestTooLarge, "Request body is too large")
+		return
+	}
+	writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+}
+
+// writeError writes the problem response for an error of a service. Errors
+// that are not domain errors are logged and reported without details.
+func (h *handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *classroom.Error
+	switch {
+	case errors.As(err, &domainErr):
+		status := http.StatusInternalServerError
+		for _, ks := range kindStatuses {
+			if errors.Is(domainErr, ks.kind) {
+				status = ks.status
+				break
+			}
+		}
+
+		problem := Problem{
+			Type:     problemTypePrefix + domainErr.Code,
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   err.Error(),
+			Instance: r.URL.Path,
+			Code:     domainErr.Code,
+		}
+		var duplicateErr *classroom.DuplicateVocabError
+		if errors.As(err, &duplicateErr) {
+			problem.Existing = v1.FromVocabs(duplicateErr.Existing)
+		}
+		var validationErr *validate.Error
+		if errors.As(err, &validationErr) {
+			problem.Violations = validationErr.Violations
+		}
+		writeProblemBody(w, problem)
+	default:
+		logger.FromCtx(r.Context()).Error("request failed", zap.String("path", r.URL.Path), zap.Error(err))
+		writeProblem(w, r, http.StatusInternalServerError, codeInternal, "Internal server error")
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/classroom"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/common/validate"
+)
+
+type CloneSetRequest struct {
+	// Name of the new set, defaults to the name of the published set.
+	Name string `json:"name"`
+}
+
+func (req *CloneSetRequest) check(val *validate.Validator) {
+	val.MaxLength("name", req.Name, classroom.MaxSetNameLength)
+}
+
+// @Summary Publish a vocab set
+// @Description Stores a read-only snapshot of the set's vocab, without progress, under a share id. Publishing the set again refreshes the snapshot.
+// @Tags published
+// @Produce json
+// @Param setId path string true "Set ID"
+// @Success 200 {object} v1.PublishedSet
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 404 {object} Problem "Set not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /classroom/sets/{setId}/publish [post]
+func (h *handler) handlePublishSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	setId, err := uuid.Parse(chi.URLParam(r, "setId"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid set ID")
+		return
+	}
+
+	published, err := h.publish.Publish(ctx, userId, setId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromPublishedSet(*published))
+}
+
+// @Summary Browse published sets
+// @Description Lists the newest published sets without their vocab
+// @Tags published
+// @Produce json
+// @Param limit query int false "Maximum number of sets (default and maximum: 100)"
+// @Success 200 {array} v1.PublishedSet
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 500 {object} Problem "Server error"
+// @Router /published [get]
+func (h *handler) handleBrowsePublished(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	limit := 0
+	if s := r.URL.Query().Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid limit")
+			return
+		}
+	}
+
+	sets, err := h.publish.Browse(ctx, userId, limit)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromPublishedSets(sets))
+}
+
+// @Summary List own published sets
+// @Description Lists the sets the authenticated user has published
+// @Tags published
+// @Produce json
+// @Success 200 {array} v1.PublishedSet
+// @Failure 500 {object} Problem "Server error"
+// @Router /published/mine [get]
+func (h *handler) handleListOwnPublished(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	sets, err := h.publish.ListOwn(ctx, userId)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromPublishedSets(sets))
+}
+
+// @Summary Preview a published set
+// @Description Returns a published set with its vocab
+// @Tags published
+// @Produce json
+// @Param shareId path string true "Share ID"
+// @Success 200 {object} v1.PublishedSet
+// @Failure 404 {object} Problem "Published set not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /published/{shareId} [get]
+func (h *handler) handlePreviewPublished(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	published, err := h.publish.Preview(ctx, userId, chi.URLParam(r, "shareId"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromPublishedSet(*published))
+}
+
+// @Summary Clone a published set
+// @Description Copies the vocab of a published set into the user's vocab and a new set. Words the user already has are merged instead of duplicated.
+// @Tags published
+// @Accept json
+// @Produce json
+// @Param shareId path string true "Share ID"
+// @Param request body CloneSetRequest false "Clone options"
+// @Success 201 {object} v1.CloneResult
+// @Failure 400 {object} Problem "Invalid request"
+// @Failure 404 {object} Problem "Published set not found"
+// @Failure 422 {object} Problem "Validation failed"
+// @Failure 500 {object} Problem "Server error"
+// @Router /published/{shareId}/clone [post]
+func (h *handler) handleClonePublished(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	var req CloneSetRequest
+	// The body is optional.
+	if r.ContentLength != 0 && !decodeBody(w, r, &req, maxBodySize) {
+		return
+	}
+
+	result, err := h.publish.Clone(ctx, userId, chi.URLParam(r, "shareId"), req.Name)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v1.FromCloneResult(*result))
+}
+
+// @Summary Unpublish a set
+// @Description Removes a published set of the authenticated user. Copies made by others are kept.
+// @Tags published
+// @Param shareId path string true "Share ID"
+// @Success 204
+// @Failure 403 {object} Problem "Published set belongs to another user"
+// @Failure 404 {object} Problem "Published set not found"
+// @Failure 500 {object} Problem "Server error"
+// @Router /published/{shareId} [delete]
+func (h *handler) handleUnpublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	if err := h.publish.Unpublish(ctx, userId, chi.URLParam(r, "shareId")); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
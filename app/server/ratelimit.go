@@ -0,0 +1,116 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/app/ratelimit"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/userid"
+	"github.com/vladazn/danish/config"
+)
+
+// rateLimits holds the parsed budgets of the config.
+type rateLimits struct {
+	limiter        ratelimit.Limiter
+	budgets        ratelimit.Budgets
+	trustForwarded bool
+	trustedProxies int
+}
+
+func newRateLimits(cfg *config.RateLimitConfig, limiter ratelimit.Limiter) (*rateLimits, error) {
+	budgets, err := ratelimit.ParseBudgets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimits{
+		limiter:        limiter,
+		budgets:        budgets,
+		trustForwarded: cfg.TrustForwarded,
+		trustedProxies: cfg.TrustedProxies,
+	}, nil
+}
+
+// ipMiddleware limits the requests of every client address.
+func (rl *rateLimits) ipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl.take(w, r, "ip:"+rl.clientIP(r), rl.budgets.IP) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// userMiddleware limits the requests of every user. Routes with their own
+// budget get a bucket of their own, all other routes share one. It has to
+// run after authentication and resolves the route pattern with mux, since
+// routing is not done yet when middleware runs.
+func (rl *rateLimits) userMiddleware(mux *chi.Mux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			uid := userid.MustFromCtx(r.Context())
+
+			key, budget := "user:"+uid, rl.budgets.User
+			rctx := chi.NewRouteContext()
+			if mux.Match(rctx, r.Method, r.URL.Path) {
+				// Versions of a route share the budget.
+				route := r.Method + " " + strings.TrimPrefix(rctx.RoutePattern(), apiV1Prefix)
+				if routeBudget, ok := rl.budgets.Routes[route]; ok {
+					key, budget = key+":"+route, routeBudget
+				}
+			}
+
+			if rl.take(w, r, key, budget) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// take takes a request from the budget of key, writing a 429 if it is
+// spent. Requests are let through if the limiter fails, so an outage of a
+// shared backend does not take the service down.
+func (rl *rateLimits) take(w http.ResponseWriter, r *http.Request, key string, budget ratelimit.Budget) bool {
+	result, err := rl.limiter.Take(r.Context(), key, budget)
+	if err != nil {
+		logger.FromCtx(r.Context()).Error("failed to check rate limit", zap.String("key", key), zap.Error(err))
+		return true
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(budget.Requests))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	if !result.Allowed {
+		w.Header().Set("Retry-After", retryAfterSeconds(result.RetryAfter))
+		writeProblem(w, r, http.StatusTooManyRequests, codeRateLimited, "Rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+// clientIP returns the address of the client, or the one the trusted
+// proxies recorded in X-Forwarded-For if they are trusted to set it.
+func (rl *rateLimits) clientIP(r *http.Request) string {
+	if rl.trustForwarded {
+		forwarded := strings.Join(r.Header.Values("X-Forwarded-For"), ",")
+		if client := ratelimit.ForwardedClient(forwarded, rl.trustedProxies); client != "" {
+			return client
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// retryAfterSeconds formats a wait for the Retry-After header, rounding up
+// so clients do not retry too early.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/config"
+)
+
+func TestRateLimits_ClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustForwarded bool
+		trustedProxies int
+		forwarded      []string
+		want           string
+	}{
+		{"remote address", false, 1, nil, "192.0.2.1"},
+		{"forwarded without trust", false, 1, []string{"1.2.3.4"}, "192.0.2.1"},
+		{"set by the proxy", true, 1, []string{"10.0.0.1"}, "10.0.0.1"},
+		{"forged by the client", true, 1, []string{"1.2.3.4, 10.0.0.1"}, "10.0.0.1"},
+		{"over several headers", true, 1, []string{"1.2.3.4", "10.0.0.1"}, "10.0.0.1"},
+		{"behind two proxies", true, 2, []string{"1.2.3.4, 10.0.0.1, 192.168.0.1"}, "10.0.0.1"},
+		{"missing", true, 1, nil, "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := &rateLimits{trustForwarded: tt.trustForwarded, trustedProxies: tt.trustedProxies}
+			r := httptest.NewRequest(http.MethodGet, "/v1/vocab", nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			require.Equal(t, tt.want, rl.clientIP(r))
+		})
+	}
+}
+
+func TestRateLimits_RouteBudgets(t *testing.T) {
+	tr := newTestRouter(t, config.RateLimitConfig{
+		Enabled: true,
+		IP:      "100/1m",
+		User:    "3/1m",
+		Routes:  map[string]string{"POST /vocab": "1/1m"},
+	})
+	token := tr.token(t, "user")
+	house := `{"definition": "house", "part_of_speech": "noun", "forms": [{"value": "et hus", "form": "indefinite_singular"}]}`
+
+	// The versioned route with a trailing slash and the deprecated one take
+	// from the same route bucket.
+	w := tr.do(http.MethodPost, "/v1/vocab/", token, house)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	require.Equal(t, http.StatusTooManyRequests, tr.do(http.MethodPost, "/vocab", token, house).Code)
+
+	// Other routes share the user budget, over both versions.
+	for _, path := range []string{"/v1/vocab", "/vocab", "/v1/classroom/sets"} {
+		w := tr.do(http.MethodGet, path, token, "")
+		require.Equal(t, http.StatusOK, w.Code, path)
+		require.Equal(t, "3", w.Header().Get("X-RateLimit-Limit"), path)
+	}
+	require.Equal(t, http.StatusTooManyRequests, tr.do(http.MethodGet, "/v1/vocab/search?q=hus", token, "").Code)
+
+	// Every user has budgets of their own.
+	require.Equal(t, http.StatusOK, tr.do(http.MethodGet, "/v1/vocab", tr.token(t, "other"), "").Code)
+}
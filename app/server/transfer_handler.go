@@ -0,0 +1,291 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	v1 "github.com/vladazn/danish/app/api/v1"
+	"github.com/vladazn/danish/app/model"
+	"github.com/vladazn/danish/app/transfer"
+	"github.com/vladazn/danish/common/logger"
+	"github.com/vladazn/danish/common/userid"
+)
+
+// Upload limits of import files. Anki packages may carry media, which is
+// not imported but still uploaded.
+const (
+	maxImportFileSize = 10 << 20
+	maxAnkiFileSize   = 100 << 20
+)
+
+// defaultDeckName names the Anki deck of a full vocabulary export.
+const defaultDeckName = "Danish"
+
+// @Summary Import vocab from CSV or TSV
+// @Description Imports vocab from a file with a header row, uploaded as the "file" field of a multipart form
+// @Description or as the raw request body. Without a mapping the columns id, definition and part_of_speech are used
+// @Description and every other column is a form named after its header; <form>_level, <form>_last_success and
+// @Description <form>_success_in_row columns carry progress. Vocab matching existing vocab is merged into it.
+// @Tags vocab
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param format query string false "File format (default: csv)" Enums(csv, tsv)
+// @Param dry_run query bool false "Only report what would be imported"
+// @Param mapping query string false "Column mapping as JSON, see transfer.CSVMapping"
+// @Param file formData file false "File to import"
+// @Success 200 {object} v1.ImportReport
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 413 {object} Problem "Request Entity Too Large"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/import [post]
+func (h *handler) handleImportWords(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	delimiter, err := transfer.Delimiter(params.Get("format"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	dryRun, err := parseDryRun(params)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	var mapping *transfer.CSVMapping
+	if mappingStr := params.Get("mapping"); mappingStr != "" {
+		mapping = &transfer.CSVMapping{}
+		if err := json.Unmarshal([]byte(mappingStr), mapping); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid mapping")
+			return
+		}
+	}
+
+	file, err := uploadedFile(w, r, maxImportFileSize)
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	rows, err := transfer.ReadCSV(file, delimiter, mapping)
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	results, err := h.dict.ImportWords(r.Context(), transfer.Valid(rows), dryRun)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromImportReport(transfer.NewImportReport(rows, results, dryRun)))
+}
+
+// @Summary Export vocab as CSV or TSV
+// @Description Exports all vocab with one column per form and the progress columns, in the format accepted by import.
+// @Tags vocab
+// @Produce text/csv
+// @Produce text/tab-separated-values
+// @Param format query string false "File format (default: csv)" Enums(csv, tsv)
+// @Success 200 {file} file
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/export [get]
+func (h *handler) handleExportWords(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	delimiter, err := transfer.Delimiter(format)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	vocabs, err := h.dict.GetAllWords(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	// Like the Anki export, the file is built before writing so a failure
+	// can still be reported with a proper status.
+	var buf bytes.Buffer
+	if err := transfer.WriteCSV(&buf, delimiter, vocabs); err != nil {
+		h.writeError(w, r, fmt.Errorf("could not write export: %w", err))
+		return
+	}
+
+	contentType := "text/csv"
+	if format == "tsv" {
+		contentType = "text/tab-separated-values"
+	}
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="vocab.%s"`, format))
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.FromCtx(r.Context()).Warn("could not send export", zap.Error(err))
+	}
+}
+
+// @Summary Import vocab from an Anki package
+// @Description Imports the notes of an .apkg file, uploaded as the "file" field of a multipart form or as the raw
+// @Description request body. Without a mapping the "Definition" field (or else the first field) is the definition,
+// @Description a "Part of speech" field the part of speech and every other field a form named after it.
+// @Description Forms take the review interval of the card asking for them. Packages must be exported with
+// @Description "Support older Anki versions". Vocab matching existing vocab is merged into it.
+// @Tags vocab
+// @Accept application/octet-stream
+// @Accept multipart/form-data
+// @Produce json
+// @Param dry_run query bool false "Only report what would be imported"
+// @Param mapping query string false "Field mapping as JSON, see transfer.AnkiMapping"
+// @Param file formData file false "Anki package to import"
+// @Success 200 {object} v1.ImportReport
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 413 {object} Problem "Request Entity Too Large"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/import/anki [post]
+func (h *handler) handleImportAnki(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	dryRun, err := parseDryRun(params)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	var mapping *transfer.AnkiMapping
+	if mappingStr := params.Get("mapping"); mappingStr != "" {
+		mapping = &transfer.AnkiMapping{}
+		if err := json.Unmarshal([]byte(mappingStr), mapping); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid mapping")
+			return
+		}
+	}
+
+	file, err := uploadedFile(w, r, maxAnkiFileSize)
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	rows, err := transfer.ReadAnki(bytes.NewReader(content), int64(len(content)), mapping)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	results, err := h.dict.ImportWords(r.Context(), transfer.Valid(rows), dryRun)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v1.FromImportReport(transfer.NewImportReport(rows, results, dryRun)))
+}
+
+// @Summary Export vocab as an Anki package
+// @Description Exports all vocab, or the vocab of one set, as an .apkg deck. Every vocab is a note with one field
+// @Description per form kind and one card per form kind; review intervals follow the form levels.
+// @Tags vocab
+// @Produce application/octet-stream
+// @Param set_id query string false "Only export the vocab of this set"
+// @Success 200 {file} file
+// @Failure 400 {object} Problem "Bad Request"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Router /vocab/export/anki [get]
+func (h *handler) handleExportAnki(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId := userid.MustFromCtx(ctx)
+
+	deckName := defaultDeckName
+	var vocabs []model.Vocab
+	var err error
+	if setIdStr := r.URL.Query().Get("set_id"); setIdStr != "" {
+		setId, err := uuid.Parse(setIdStr)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Invalid set_id")
+			return
+		}
+
+		vocabSet, setVocabs, err := h.set.ExportSet(ctx, userId, setId)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		deckName = vocabSet.Name
+		vocabs = setVocabs
+	} else {
+		vocabs, err = h.dict.GetAllWords(ctx)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+
+	// The package is built before writing so a failure can still be
+	// reported with a proper status.
+	var buf bytes.Buffer
+	if err := transfer.WriteAnki(&buf, deckName, vocabs); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="vocab.apkg"`)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.FromCtx(ctx).Warn("could not send export", zap.Error(err))
+	}
+}
+
+func parseDryRun(params url.Values) (bool, error) {
+	dryRunStr := params.Get("dry_run")
+	if dryRunStr == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(dryRunStr)
+	if err != nil {
+		return false, errors.New("invalid dry_run")
+	}
+	return dryRun, nil
+}
+
+// uploadedFile returns the "file" field of a multipart form, or else the
+// request body, limited to maxSize bytes.
+func uploadedFile(w http.ResponseWriter, r *http.Request, maxSize int64) (io.ReadCloser, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/vladazn/danish/common/validate"
+)
+
+// Body size limits of JSON requests. Bulk requests carry up to
+// classroom.MaxBulkSize vocab and get more room.
+const (
+	maxBodySize     = 1 << 20
+	maxBulkBodySize = 8 << 20
+)
+
+// codeValidationFailed is the code of a request whose fields break rules.
+const codeValidationFailed = "validation_failed"
+
+// requestRules is implemented by request bodies with rules beyond their
+// JSON shape. check records every rule the request breaks.
+type requestRules interface {
+	check(val *validate.Validator)
+}
+
+// decodeBody decodes a JSON request body of at most maxSize bytes into v,
+// rejecting unknown fields and checking the rules of v. It writes the
+// problem response and returns false if the body is not acceptable.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any, maxSize int64) bool {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		writeBodyError(w, r, err)
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		writeDecodeError(w, r, err, data, reflect.TypeOf(v))
+		return false
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Request body must hold a single JSON value")
+		return false
+	}
+
+	if rules, ok := v.(requestRules); ok {
+		var val validate.Validator
+		rules.check(&val)
+		if err := val.Err(); err != nil {
+			writeValidationProblem(w, r, codeValidationFailed, err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// writeDecodeError writes the problem for a body data that is not the
+// expected JSON of type t. Well-formed JSON with fields of the wrong type
+// or unknown fields is reported as a validation failure.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error, data []byte, t reflect.Type) {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		var val validate.Validator
+		val.Add(typeErr.Field, "must be a %s", jsonKind(typeErr.Type.Kind().String()))
+		writeValidationProblem(w, r, codeValidationFailed, val.Err())
+	case errors.Is(err, io.EOF):
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Request body is required")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest, "Request body is not valid JSON")
+	case errors.As(err, &syntaxErr):
+		writeProblem(w, r, http.StatusBadRequest, codeInvalidRequest,
+			fmt.Sprintf("Request body is not valid JSON at offset %d", syntaxErr.Offset))
+	default:
+		// The decoder has no error type for unknown fields, so look for
+		// them in the body rather than in the message.
+		if field, ok := unknownField(data, t, ""); ok {
+			var val validate.Validator
+			val.Add(field, "is not a known field")
+			writeValidationProblem(w, r, codeValidationFailed, val.Err())
+			return
+		}
+		writeBodyError(w, r, err)
+	}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// unknownField returns the path of a key in data that no field of t
+// decodes, looking into nested objects and lists, with path being the
+// path of data itself. Keys are checked in order, so the same body always
+// reports the same field.
+func unknownField(data []byte, t reflect.Type, path string) (string, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "", false
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return "", false
+		}
+		for _, key := range slices.Sorted(maps.Keys(object)) {
+			var elem reflect.Type
+			if t.Kind() == reflect.Struct {
+				field, ok := jsonField(t, key)
+				if !ok {
+					return validate.Join(path, key), true
+				}
+				elem = field.Type
+			} else {
+				elem = t.Elem()
+			}
+			if field, ok := unknownField(object[key], elem, validate.Join(path, key)); ok {
+				return field, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var list []json.RawMessage
+		if json.Unmarshal(data, &list) != nil {
+			return "", false
+		}
+		for i, item := range list {
+			if field, ok := unknownField(item, t.Elem(), validate.Index(path, i)); ok {
+				return field, true
+			}
+		}
+	}
+	return "", false
+}
+
+// jsonField returns the field of struct t that decodes key, matching
+// names without regard to case like encoding/json does.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if found, ok := jsonField(embedded, key); ok {
+					return found, true
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// jsonKind names a Go kind the way a JSON client would know it.
+func jsonKind(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "bool":
+		return "boolean"
+	case kind == "slice", kind == "array":
+		return "list"
+	case kind == "struct", kind == "map":
+		return "object"
+	default:
+		return kind
+	}
+}
+
+// writeValidationProblem writes a 422 listing the violations of err.
+func writeValidationProblem(w http.ResponseWriter, r *http.Request, code string, err error) {
+	problem := Problem{
+		Type:     problemTypePrefix + code,
+		Title:    http.StatusText(http.StatusUnprocessableEntity),
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+		Code:     code,
+	}
+	var validationErr *validate.Error
+	if errors.As(err, &validationErr) {
+		problem.Violations = validationErr.Violations
+	}
+	writeProblemBody(w, problem)
+}
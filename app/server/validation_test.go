@@ -0,0 +1,166 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/common/validate"
+)
+
+type testItem struct {
+	Value string `json:"value"`
+}
+
+type testRequest struct {
+	Name  string            `json:"name"`
+	Count int               `json:"count"`
+	Items []testItem        `json:"items"`
+	Tags  map[string]string `json:"tags"`
+}
+
+func (req *testRequest) check(val *validate.Validator) {
+	val.Required("name", req.Name)
+	val.Range("count", req.Count, 0, 10)
+	for i, item := range req.Items {
+		val.Required(validate.Join(validate.Index("items", i), "value"), item.Value)
+	}
+}
+
+func TestDecodeBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		maxSize    int64
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantFields []validate.Violation
+	}{
+		{
+			name:       "valid",
+			body:       `{"name": "house", "count": 2, "items": [{"value": "hus"}]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "field names ignore case",
+			body:       `{"Name": "house"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown field",
+			body:       `{"name": "house", "colour": "red"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeValidationFailed,
+			wantFields: []validate.Violation{{Field: "colour", Message: "is not a known field"}},
+		},
+		{
+			name:       "unknown field in a list",
+			body:       `{"name": "house", "items": [{"value": "hus"}, {"value": "hus", "extra": 1}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeValidationFailed,
+			wantFields: []validate.Violation{{Field: "items[1].extra", Message: "is not a known field"}},
+		},
+		{
+			name:       "map keys are not fields",
+			body:       `{"name": "house", "tags": {"anything": "goes"}}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "wrong type",
+			body:       `{"name": "house", "count": "two"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeValidationFailed,
+			wantFields: []validate.Violation{{Field: "count", Message: "must be a number"}},
+		},
+		{
+			name:       "broken rules",
+			body:       `{"name": " ", "count": 11, "items": [{"value": ""}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeValidationFailed,
+			wantDetail: "name is required; count must be between 0 and 10; items[0].value is required",
+			wantFields: []validate.Violation{
+				{Field: "name", Message: "is required"},
+				{Field: "count", Message: "must be between 0 and 10"},
+				{Field: "items[0].value", Message: "is required"},
+			},
+		},
+		{
+			name:       "trailing value",
+			body:       `{"name": "house"} {"name": "tree"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantDetail: "Request body must hold a single JSON value",
+		},
+		{
+			name:       "trailing garbage",
+			body:       `{"name": "house"} ]`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantDetail: "Request body must hold a single JSON value",
+		},
+		{
+			name:       "empty body",
+			body:       ``,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantDetail: "Request body is required",
+		},
+		{
+			name:       "truncated body",
+			body:       `{"name": "house"`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantDetail: "Request body is not valid JSON",
+		},
+		{
+			name:       "invalid JSON",
+			body:       `{"name": house}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantDetail: "Request body is not valid JSON at offset 10",
+		},
+		{
+			name:       "too large",
+			body:       `{"name": "` + strings.Repeat("a", 100) + `"}`,
+			maxSize:    64,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   codeRequestTooLarge,
+			wantDetail: "Request body is too large",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxSize := tt.maxSize
+			if maxSize == 0 {
+				maxSize = maxBodySize
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/vocab/", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			var req testRequest
+			if decodeBody(w, r, &req, maxSize) {
+				w.WriteHeader(http.StatusOK)
+			}
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			var problem Problem
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			require.Equal(t, tt.wantStatus, problem.Status)
+			require.Equal(t, tt.wantCode, problem.Code)
+			require.Equal(t, "/vocab/", problem.Instance)
+			if tt.wantDetail != "" {
+				require.Equal(t, tt.wantDetail, problem.Detail)
+			}
+			require.Equal(t, tt.wantFields, problem.Violations)
+		})
+	}
+}
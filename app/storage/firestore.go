@@ -2,10 +2,12 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"cloud.google.com/go/firestore"
 	"go.uber.org/fx"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 
 	"github.com/vladazn/danish/config"
@@ -22,7 +24,7 @@ type FirestoreClient struct {
 }
 
 func (fc *FirestoreClient) Connect(ctx context.Context) error {
-	client, err := firestore.NewClient(ctx, fc.cfg.ProjectId, option.WithCredentialsJSON(fc.cfg.AuthJSON))
+	client, err := firestore.NewClient(ctx, fc.cfg.ProjectId, option.WithCredentialsJSON([]byte(fc.cfg.AuthJSON.Value())))
 	if err != nil {
 		return fmt.Errorf("failed connect firestore: %w", err)
 	}
@@ -32,6 +34,19 @@ func (fc *FirestoreClient) Connect(ctx context.Context) error {
 	return nil
 }
 
+// Ping checks that Firestore can be reached by listing a collection.
+func (fc *FirestoreClient) Ping(ctx context.Context) error {
+	if fc.Client == nil {
+		return errors.New("firestore client is not connected")
+	}
+
+	_, err := fc.Client.Collections(ctx).Next()
+	if err != nil && !errors.Is(err, iterator.Done) {
+		return fmt.Errorf("failed to list firestore collections: %w", err)
+	}
+	return nil
+}
+
 func NewFirestoreClient(p NewFirestoreClientParams) *FirestoreClient {
 	return &FirestoreClient{
 		cfg: p.Cfg,
@@ -6,6 +6,8 @@ import (
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+
+	"github.com/vladazn/danish/config"
 )
 
 var Module = fx.Module(
@@ -20,12 +22,17 @@ var Module = fx.Module(
 
 type HooksParams struct {
 	fx.In
+	Cfg             *config.StorageConfig
 	Logger          *zap.Logger
 	Lifecycle       fx.Lifecycle
 	FirestoreClient *FirestoreClient
 }
 
 func RegisterHooks(p HooksParams) {
+	if p.Cfg.Backend != config.StorageBackendFirestore {
+		return
+	}
+
 	p.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			err := p.FirestoreClient.Connect(ctx)
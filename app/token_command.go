@@ -0,0 +1,52 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/vladazn/danish/app/auth"
+	"github.com/vladazn/danish/config"
+)
+
+// runToken mints a token of the local JWT issuer. It only reads the
+// config, so it works without any backend.
+func runToken(args []string) error {
+	flags := flag.NewFlagSet("token", flag.ContinueOnError)
+	user := flags.String("user", "", "uid the token is for")
+	role := flags.String("role", "", "role claim: learner, teacher or admin")
+	claims := flags.String("claims", "", `further custom claims as JSON, e.g. {"admin":true}`)
+	ttl := flags.Duration("ttl", 0, "how long the token is valid (default AUTH_JWT_TTL)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required("token", map[string]string{"user": *user}); err != nil {
+		return err
+	}
+
+	customClaims := map[string]any{}
+	if *claims != "" {
+		if err := json.Unmarshal([]byte(*claims), &customClaims); err != nil {
+			return fmt.Errorf("token: invalid -claims: %w", err)
+		}
+	}
+	if *role != "" {
+		customClaims["role"] = *role
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+	issuer, err := auth.NewJWTIssuer(cfg.AuthConfig.JWT)
+	if err != nil {
+		return fmt.Errorf("token: %w", err)
+	}
+
+	token, err := issuer.Mint(*user, customClaims, *ttl)
+	if err != nil {
+		return fmt.Errorf("token: %w", err)
+	}
+	fmt.Println(token)
+	return nil
+}
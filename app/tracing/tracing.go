@@ -0,0 +1,106 @@
+// Package tracing sets up the OpenTelemetry tracer provider. Packages
+// start spans with otel.Tracer, which follows the provider set here.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+
+	"github.com/vladazn/danish/config"
+)
+
+var Module = fx.Module(
+	"tracing",
+	fx.Provide(
+		NewTracerProvider,
+	),
+	fx.Invoke(
+		RegisterHooks,
+	),
+)
+
+type NewTracerProviderParams struct {
+	fx.In
+	Cfg *config.TracingConfig
+}
+
+// NewTracerProvider returns a provider exporting spans to the exporter
+// selected by the config, or one that records nothing.
+func NewTracerProvider(p NewTracerProviderParams) (trace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch p.Cfg.Exporter {
+	case config.TracingExporterNone:
+		return noop.NewTracerProvider(), nil
+	case config.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case config.TracingExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(p.Cfg.OTLPEndpoint)}
+		if p.Cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		// The client connects lazily, so an unreachable collector does not
+		// stop the service from starting.
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", p.Cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s span exporter: %w", p.Cfg.Exporter, err)
+	}
+
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName(p.Cfg.ServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe tracing resource: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(p.Cfg.SampleRatio))),
+	), nil
+}
+
+type HooksParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Provider  trace.TracerProvider
+}
+
+// RegisterHooks installs the provider globally, along with the W3C trace
+// context propagator, and flushes pending spans on stop.
+func RegisterHooks(p HooksParams) {
+	otel.SetTracerProvider(p.Provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	sdkProvider, ok := p.Provider.(*sdktrace.TracerProvider)
+	if !ok {
+		return
+	}
+	p.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			if err := sdkProvider.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to flush spans: %w", err)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,752 @@
+package transfer
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+// An Anki package is a zip holding the collection as a SQLite database and
+// a media index. Only the legacy schema (version 11) is read and written,
+// which every Anki version imports and exports with "Support older Anki
+// versions" enabled.
+const (
+	ankiCollection       = "collection.anki2"
+	ankiCollection21     = "collection.anki21"
+	ankiCollectionLatest = "collection.anki21b"
+	ankiMedia            = "media"
+	ankiFieldSeparator   = "\x1f"
+	ankiNoteTypeName     = "Danish vocab"
+	ankiDefinitionField  = "Definition"
+	ankiPOSField         = "Part of speech"
+	ankiDefaultFactor    = 2500
+	ankiDay              = 24 * time.Hour
+	// ankiMaxCollectionSize caps the extracted collection, so a small
+	// package cannot unpack into an arbitrarily large file.
+	ankiMaxCollectionSize = 512 << 20
+)
+
+// Card types and queues of the Anki scheduler.
+const (
+	ankiCardNew     = 0
+	ankiCardReview  = 2
+	ankiCardRelearn = 3
+)
+
+// AnkiMapping tells which note fields hold which vocab fields. Field names
+// are matched case-insensitively against the fields of every note type.
+type AnkiMapping struct {
+	Definition          string             `json:"definition"`
+	PartOfSpeech        string             `json:"part_of_speech,omitempty"`
+	DefaultPartOfSpeech model.PartOfSpeech `json:"default_part_of_speech,omitempty"`
+	// Forms maps form kinds to the name of the note field holding them.
+	Forms map[string]string `json:"forms"`
+}
+
+const ankiSchema = `
+CREATE TABLE col (
+	id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+	ver integer not null, dty integer not null, usn integer not null, ls integer not null,
+	conf text not null, models text not null, decks text not null, dconf text not null, tags text not null
+);
+CREATE TABLE notes (
+	id integer primary key, guid text not null, mid integer not null, mod integer not null,
+	usn integer not null, tags text not null, flds text not null, sfld integer not null,
+	csum integer not null, flags integer not null, data text not null
+);
+CREATE TABLE cards (
+	id integer primary key, nid integer not null, did integer not null, ord integer not null,
+	mod integer not null, usn integer not null, type integer not null, queue integer not null,
+	due integer not null, ivl integer not null, factor integer not null, reps integer not null,
+	lapses integer not null, left integer not null, odue integer not null, odid integer not null,
+	flags integer not null, data text not null
+);
+CREATE TABLE revlog (
+	id integer primary key, cid integer not null, usn integer not null, ease integer not null,
+	ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null,
+	type integer not null
+);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+type ankiField struct {
+	Name   string `json:"name"`
+	Ord    int    `json:"ord"`
+	Sticky bool   `json:"sticky"`
+	RTL    bool   `json:"rtl"`
+	Font   string `json:"font"`
+	Size   int    `json:"size"`
+	Media  []any  `json:"media"`
+}
+
+type ankiTemplate struct {
+	Name  string `json:"name"`
+	Ord   int    `json:"ord"`
+	Qfmt  string `json:"qfmt"`
+	Afmt  string `json:"afmt"`
+	Did   *int64 `json:"did"`
+	Bqfmt string `json:"bqfmt"`
+	Bafmt string `json:"bafmt"`
+}
+
+type ankiNoteType struct {
+	Id        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Type      int            `json:"type"`
+	Mod       int64          `json:"mod"`
+	Usn       int            `json:"usn"`
+	Sortf     int            `json:"sortf"`
+	Did       int64          `json:"did"`
+	Tmpls     []ankiTemplate `json:"tmpls"`
+	Flds      []ankiField    `json:"flds"`
+	CSS       string         `json:"css"`
+	LatexPre  string         `json:"latexPre"`
+	LatexPost string         `json:"latexPost"`
+	Tags      []string       `json:"tags"`
+	Vers      []any          `json:"vers"`
+	Req       [][]any        `json:"req"`
+}
+
+type ankiCard struct {
+	ord  int
+	kind int
+	due  int64
+	ivl  int64
+	reps int
+}
+
+// WriteAnki writes the vocab as an Anki package with a single deck. Every
+// vocab is a note with one field per form kind and one card per form kind
+// asking for it; review intervals are derived from the form levels.
+func WriteAnki(w io.Writer, deckName string, vocabs []model.Vocab) error {
+	dir, err := os.MkdirTemp("", "anki-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ankiCollection)
+	if err := writeAnkiCollection(path, deckName, vocabs); err != nil {
+		return fmt.Errorf("could not write collection: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	collection, err := zw.Create(ankiCollection)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(collection, f); err != nil {
+		return err
+	}
+
+	media, err := zw.Create(ankiMedia)
+	if err != nil {
+		return err
+	}
+	if _, err := media.Write([]byte("{}")); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeAnkiCollection(path, deckName string, vocabs []model.Vocab) (err error) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, db.Close())
+	}()
+
+	if _, err := db.ExecContext(ctx, ankiSchema); err != nil {
+		return err
+	}
+
+	var kinds []string
+	for _, vocab := range vocabs {
+		for _, form := range vocab.Forms {
+			if !slices.Contains(kinds, form.Form) {
+				kinds = append(kinds, form.Form)
+			}
+		}
+	}
+
+	now := time.Now()
+	// Anki ids are millisecond timestamps; the deck and note type only need
+	// to differ from the built-in ones.
+	baseId := now.UnixMilli()
+	deckId := baseId
+	noteType := newAnkiNoteType(baseId+1, deckId, kinds, now)
+	crt := ankiCreationTime(vocabs, now)
+
+	if err := insertAnkiCol(ctx, db, crt, now, deckName, deckId, noteType); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insertNote, err := tx.PrepareContext(ctx,
+		`INSERT INTO notes (id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data)
+		 VALUES (?, ?, ?, ?, -1, '', ?, ?, ?, 0, '')`)
+	if err != nil {
+		return err
+	}
+	insertCard, err := tx.PrepareContext(ctx,
+		`INSERT INTO cards (id, nid, did, ord, mod, usn, type, queue, due, ivl, factor, reps, lapses, left, odue, odid, flags, data)
+		 VALUES (?, ?, ?, ?, ?, -1, ?, ?, ?, ?, ?, ?, 0, 0, 0, 0, 0, '')`)
+	if err != nil {
+		return err
+	}
+
+	cardId := baseId
+	for i, vocab := range vocabs {
+		noteId := baseId + int64(i)
+
+		fields := make([]string, 0, 2+len(kinds))
+		fields = append(fields, html.EscapeString(vocab.Definition), html.EscapeString(string(vocab.PartOfSpeech)))
+		for _, kind := range kinds {
+			var values []string
+			for _, form := range vocab.Forms {
+				if form.Form == kind {
+					values = append(values, html.EscapeString(form.Value))
+				}
+			}
+			fields = append(fields, strings.Join(values, valueSeparator))
+		}
+
+		if _, err := insertNote.ExecContext(ctx,
+			noteId, vocab.Id.String(), noteType.Id, now.Unix(),
+			strings.Join(fields, ankiFieldSeparator), vocab.Definition, ankiChecksum(vocab.Definition),
+		); err != nil {
+			return err
+		}
+
+		for ord, kind := range kinds {
+			form, ok := mostProgressed(vocab.Forms, kind)
+			if !ok {
+				continue
+			}
+
+			card := ankiCard{ord: ord, kind: ankiCardNew, due: int64(i)}
+			if !form.LastSuccess.IsZero() {
+				card.kind = ankiCardReview
+				card.ivl = max(int64(form.Interval()/ankiDay), 1)
+				card.due = int64(form.DueAt().Sub(crt) / ankiDay)
+				card.reps = max(form.SuccessInRow, 1)
+			}
+
+			if _, err := insertCard.ExecContext(ctx,
+				cardId, noteId, deckId, card.ord, now.Unix(),
+				card.kind, card.kind, card.due, card.ivl, ankiDefaultFactor, card.reps,
+			); err != nil {
+				return err
+			}
+			cardId++
+		}
+	}
+
+	return tx.Commit()
+}
+
+func newAnkiNoteType(id, deckId int64, kinds []string, now time.Time) ankiNoteType {
+	noteType := ankiNoteType{
+		Id:        id,
+		Name:      ankiNoteTypeName,
+		Mod:       now.Unix(),
+		Usn:       -1,
+		Did:       deckId,
+		CSS:       ".card { font-family: arial; font-size: 20px; text-align: center; }",
+		LatexPre:  "\\documentclass[12pt]{article}\n\\begin{document}\n",
+		LatexPost: "\\end{document}",
+		Tags:      []string{},
+		Vers:      []any{},
+		Tmpls:     []ankiTemplate{},
+		Req:       [][]any{},
+	}
+
+	fields := append([]string{ankiDefinitionField, ankiPOSField}, kinds...)
+	for ord, name := range fields {
+		noteType.Flds = append(noteType.Flds, ankiField{Name: name, Ord: ord, Font: "Arial", Size: 20, Media: []any{}})
+	}
+
+	for ord, kind := range kinds {
+		noteType.Tmpls = append(noteType.Tmpls, ankiTemplate{
+			Name: kind,
+			Ord:  ord,
+			Qfmt: fmt.Sprintf("{{#%[1]s}}{{%[2]s}}<br><small>%[1]s</small>{{/%[1]s}}", kind, ankiDefinitionField),
+			Afmt: fmt.Sprintf("{{FrontSide}}<hr id=answer>{{%s}}", kind),
+		})
+		noteType.Req = append(noteType.Req, []any{ord, "all", []int{0, 2 + ord}})
+	}
+
+	return noteType
+}
+
+func insertAnkiCol(
+	ctx context.Context,
+	db *sql.DB,
+	crt time.Time,
+	now time.Time,
+	deckName string,
+	deckId int64,
+	noteType ankiNoteType,
+) error {
+	deck := func(id int64, name string) map[string]any {
+		return map[string]any{
+			"id": id, "name": name, "mod": now.Unix(), "usn": -1, "desc": "", "dyn": 0, "conf": 1,
+			"collapsed": false, "extendNew": 10, "extendRev": 50,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+	decks := map[string]any{
+		"1":                           deck(1, "Default"),
+		strconv.FormatInt(deckId, 10): deck(deckId, deckName),
+	}
+	deckConfig := map[string]any{
+		"1": map[string]any{
+			"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0,
+			"replayq": true, "dyn": false,
+			"new": map[string]any{
+				"delays": []float64{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": ankiDefaultFactor,
+				"order": 1, "perDay": 20, "bury": true, "separate": true,
+			},
+			"rev": map[string]any{
+				"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "ivlFct": 1, "maxIvl": 36500,
+				"bury": true, "minSpace": 1,
+			},
+			"lapse": map[string]any{
+				"delays": []float64{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0,
+			},
+		},
+	}
+	conf := map[string]any{
+		"activeDecks": []int64{deckId}, "curDeck": deckId, "curModel": noteType.Id,
+		"newSpread": 0, "collapseTime": 1200, "timeLim": 0, "estTimes": true, "dueCounts": true,
+		"sortType": "noteFld", "sortBackwards": false, "nextPos": 1,
+	}
+	noteTypes := map[string]any{strconv.FormatInt(noteType.Id, 10): noteType}
+
+	var encoded [4][]byte
+	for i, v := range []any{conf, noteTypes, decks, deckConfig} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		encoded[i] = b
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO col (id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags)
+		 VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		crt.Unix(), now.UnixMilli(), now.UnixMilli(),
+		string(encoded[0]), string(encoded[1]), string(encoded[2]), string(encoded[3]),
+	)
+	return err
+}
+
+// ankiCreationTime returns the day start the card due days count from. It
+// lies before every due date so no due day is negative.
+func ankiCreationTime(vocabs []model.Vocab, now time.Time) time.Time {
+	crt := now
+	for _, vocab := range vocabs {
+		for _, form := range vocab.Forms {
+			if !form.LastSuccess.IsZero() && form.LastSuccess.Before(crt) {
+				crt = form.LastSuccess
+			}
+		}
+	}
+
+	return crt.UTC().Truncate(ankiDay)
+}
+
+// ankiChecksum is the first 8 hex digits of the SHA-1 of the sort field,
+// which Anki uses to find duplicate notes.
+func ankiChecksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+func mostProgressed(forms []model.VocabForm, kind string) (model.VocabForm, bool) {
+	var best model.VocabForm
+	found := false
+	for _, form := range forms {
+		if form.Form == kind && (!found || form.MoreProgressThan(best)) {
+			best = form
+			found = true
+		}
+	}
+	return best, found
+}
+
+// ReadAnki reads the notes of an Anki package. Without a mapping, the field
+// named "Definition" (or else the first field) is the definition, a "Part of
+// speech" field the part of speech, and every other field a form named
+// after it. Forms take the review interval of the card that asks for them.
+func ReadAnki(r io.ReaderAt, size int64, mapping *AnkiMapping) ([]Row, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not an Anki package: %w", err)
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range zr.File {
+		files[file.Name] = file
+	}
+	collection := files[ankiCollection21]
+	if collection == nil {
+		collection = files[ankiCollection]
+	}
+	if collection == nil {
+		if files[ankiCollectionLatest] != nil {
+			return nil, errors.New(`package uses the latest Anki format, export it with "Support older Anki versions"`)
+		}
+		return nil, errors.New("package has no collection")
+	}
+
+	dir, err := os.MkdirTemp("", "anki-import-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ankiCollection)
+	if err := extract(collection, path, ankiMaxCollectionSize); err != nil {
+		return nil, fmt.Errorf("could not extract collection: %w", err)
+	}
+
+	return readAnkiCollection(path, mapping)
+}
+
+// extract writes the file to path, failing if it unpacks to more than
+// maxSize bytes.
+func extract(file *zip.File, path string, maxSize int64) error {
+	if file.UncompressedSize64 > uint64(maxSize) {
+		return fmt.Errorf("%s is larger than %d MB", file.Name, maxSize>>20)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// The size in the header is not trusted, the copy stops past the cap.
+	n, err := io.Copy(dst, io.LimitReader(src, maxSize+1))
+	if err == nil && n > maxSize {
+		err = fmt.Errorf("%s is larger than %d MB", file.Name, maxSize>>20)
+	}
+	if err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func readAnkiCollection(path string, mapping *AnkiMapping) ([]Row, error) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var crtUnix int64
+	var models string
+	if err := db.QueryRowContext(ctx, `SELECT crt, models FROM col`).Scan(&crtUnix, &models); err != nil {
+		return nil, fmt.Errorf("could not read collection: %w", err)
+	}
+	crt := time.Unix(crtUnix, 0)
+
+	var noteTypes map[string]ankiNoteType
+	if err := json.Unmarshal([]byte(models), &noteTypes); err != nil {
+		return nil, fmt.Errorf("could not read note types: %w", err)
+	}
+	layouts := map[int64]*ankiLayout{}
+	for _, noteType := range noteTypes {
+		layouts[noteType.Id] = newAnkiLayout(noteType, mapping)
+	}
+
+	cards, err := readAnkiCards(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	noteRows, err := db.QueryContext(ctx, `SELECT id, guid, mid, flds FROM notes ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("could not read notes: %w", err)
+	}
+	defer noteRows.Close()
+
+	var rows []Row
+	for noteRows.Next() {
+		var noteId, noteTypeId int64
+		var guid, fields string
+		if err := noteRows.Scan(&noteId, &guid, &noteTypeId, &fields); err != nil {
+			return nil, fmt.Errorf("could not read notes: %w", err)
+		}
+
+		row := Row{Line: len(rows) + 1}
+		layout, ok := layouts[noteTypeId]
+		switch {
+		case !ok:
+			row.Err = fmt.Errorf("unknown note type %d", noteTypeId)
+		case layout.err != nil:
+			row.Err = layout.err
+		default:
+			row.Vocab = layout.vocab(strings.Split(fields, ankiFieldSeparator), cards[noteId], crt)
+			// Notes exported from here carry the vocab id, so importing
+			// them again merges into the same vocab.
+			if id, err := uuid.Parse(guid); err == nil {
+				row.Vocab.Id = id
+			}
+		}
+		rows = append(rows, row)
+	}
+	if err := noteRows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read notes: %w", err)
+	}
+
+	return rows, nil
+}
+
+func readAnkiCards(ctx context.Context, db *sql.DB) (map[int64][]ankiCard, error) {
+	rows, err := db.QueryContext(ctx, `SELECT nid, ord, type, due, ivl, reps FROM cards ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("could not read cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := map[int64][]ankiCard{}
+	for rows.Next() {
+		var noteId int64
+		var card ankiCard
+		if err := rows.Scan(&noteId, &card.ord, &card.kind, &card.due, &card.ivl, &card.reps); err != nil {
+			return nil, fmt.Errorf("could not read cards: %w", err)
+		}
+		cards[noteId] = append(cards[noteId], card)
+	}
+
+	return cards, rows.Err()
+}
+
+// ankiLayout holds the field indexes of a note type for a mapping.
+type ankiLayout struct {
+	definition   int
+	partOfSpeech int
+	defaultPOS   model.PartOfSpeech
+	forms        []ankiFormField
+	// err rejects every note of the type when the mapping does not fit it.
+	err error
+}
+
+type ankiFormField struct {
+	kind  string
+	field int
+	// ords are the cards whose answer shows the field.
+	ords []int
+}
+
+func newAnkiLayout(noteType ankiNoteType, mapping *AnkiMapping) *ankiLayout {
+	index := func(name string) int {
+		for _, field := range noteType.Flds {
+			if strings.EqualFold(field.Name, strings.TrimSpace(name)) && name != "" {
+				return field.Ord
+			}
+		}
+		return -1
+	}
+
+	if mapping == nil {
+		mapping = defaultAnkiMapping(noteType)
+	}
+
+	l := &ankiLayout{
+		definition:   index(mapping.Definition),
+		partOfSpeech: index(mapping.PartOfSpeech),
+		defaultPOS:   mapping.DefaultPartOfSpeech,
+	}
+	if l.definition < 0 {
+		l.err = fmt.Errorf("note type %q has no field %q", noteType.Name, mapping.Definition)
+		return l
+	}
+
+	kinds := make([]string, 0, len(mapping.Forms))
+	for kind := range mapping.Forms {
+		kinds = append(kinds, kind)
+	}
+	slices.SortFunc(kinds, func(a, b string) int {
+		return index(mapping.Forms[a]) - index(mapping.Forms[b])
+	})
+
+	for _, kind := range kinds {
+		field := index(mapping.Forms[kind])
+		if field < 0 {
+			l.err = fmt.Errorf("note type %q has no field %q", noteType.Name, mapping.Forms[kind])
+			return l
+		}
+
+		name := noteType.Flds[slices.IndexFunc(noteType.Flds, func(f ankiField) bool { return f.Ord == field })].Name
+		formField := ankiFormField{kind: kind, field: field}
+		for _, tmpl := range noteType.Tmpls {
+			if referencesField(tmpl.Afmt, name) && !referencesField(tmpl.Qfmt, name) {
+				formField.ords = append(formField.ords, tmpl.Ord)
+			}
+		}
+		l.forms = append(l.forms, formField)
+	}
+
+	return l
+}
+
+func defaultAnkiMapping(noteType ankiNoteType) *AnkiMapping {
+	mapping := &AnkiMapping{Forms: map[string]string{}}
+	for _, field := range noteType.Flds {
+		switch {
+		case strings.EqualFold(field.Name, ankiDefinitionField):
+			mapping.Definition = field.Name
+		case strings.EqualFold(field.Name, ankiPOSField), strings.EqualFold(field.Name, "part_of_speech"):
+			mapping.PartOfSpeech = field.Name
+		}
+	}
+	if mapping.Definition == "" && len(noteType.Flds) > 0 {
+		mapping.Definition = noteType.Flds[0].Name
+	}
+
+	for _, field := range noteType.Flds {
+		if field.Name == mapping.Definition || field.Name == mapping.PartOfSpeech {
+			continue
+		}
+		kind := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(field.Name)), " ", "_")
+		mapping.Forms[kind] = field.Name
+	}
+
+	return mapping
+}
+
+// fieldReference matches {{Field}} and filtered {{filter:Field}} references;
+// conditional sections like {{#Field}} only test the field.
+var fieldReference = regexp.MustCompile(`{{([#^/]?)(?:[^}:]*:)*([^}]+)}}`)
+
+// referencesField reports whether a card template shows the field.
+func referencesField(template, field string) bool {
+	for _, match := range fieldReference.FindAllStringSubmatch(template, -1) {
+		if match[1] == "" && strings.TrimSpace(match[2]) == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *ankiLayout) vocab(fields []string, cards []ankiCard, crt time.Time) model.Vocab {
+	field := func(i int) string {
+		if i < 0 || i >= len(fields) {
+			return ""
+		}
+		return plainText(fields[i])
+	}
+
+	vocab := model.Vocab{
+		Definition:   field(l.definition),
+		PartOfSpeech: model.PartOfSpeech(strings.ToLower(field(l.partOfSpeech))),
+		Forms:        []model.VocabForm{},
+	}
+	if vocab.PartOfSpeech == "" {
+		vocab.PartOfSpeech = l.defaultPOS
+	}
+
+	for _, formField := range l.forms {
+		value := field(formField.field)
+		if value == "" {
+			continue
+		}
+
+		card, reviewed := formCard(cards, formField.ords)
+		for _, value := range strings.Split(value, valueSeparator) {
+			form := model.VocabForm{Value: strings.TrimSpace(value), Form: formField.kind}
+			if reviewed {
+				interval := time.Duration(card.ivl) * ankiDay
+				form.Level = model.LevelForInterval(interval)
+				form.LastSuccess = crt.Add(time.Duration(card.due) * ankiDay).Add(-interval)
+			}
+			vocab.Forms = append(vocab.Forms, form)
+		}
+	}
+
+	return vocab
+}
+
+// formCard picks the card whose review interval a form takes: one that asks
+// for the form, or else the note's first card. It reports false when that
+// card has no review interval yet.
+func formCard(cards []ankiCard, ords []int) (ankiCard, bool) {
+	if len(cards) == 0 {
+		return ankiCard{}, false
+	}
+
+	card := cards[0]
+	for _, c := range cards {
+		if slices.Contains(ords, c.ord) {
+			card = c
+			break
+		}
+	}
+
+	reviewed := (card.kind == ankiCardReview || card.kind == ankiCardRelearn) && card.ivl > 0
+	return card, reviewed
+}
+
+var (
+	lineBreak = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>`)
+	htmlTag   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// plainText turns an HTML note field into text.
+func plainText(field string) string {
+	field = lineBreak.ReplaceAllString(field, " ")
+	field = htmlTag.ReplaceAllString(field, "")
+	field = html.UnescapeString(field)
+	return strings.Join(strings.Fields(field), " ")
+}
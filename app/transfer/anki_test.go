@@ -0,0 +1,238 @@
+package transfer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestAnkiRoundTrip(t *testing.T) {
+	lastSuccess := time.Now().Add(-48 * time.Hour).UTC()
+	vocabs := []model.Vocab{
+		{
+			Id:           uuid.New(),
+			Definition:   "house & home",
+			PartOfSpeech: model.PartOfSpeechNoun,
+			Forms: []model.VocabForm{
+				{Form: "singular", Value: "et hus", Level: 3, LastSuccess: lastSuccess},
+				{Form: "plural", Value: "huse"},
+				{Form: "plural", Value: "husene"},
+			},
+		},
+		{
+			Id:           uuid.New(),
+			Definition:   "to go",
+			PartOfSpeech: model.PartOfSpeechVerb,
+			Forms: []model.VocabForm{
+				{Form: "infinitive", Value: "at gå", Level: 1, LastSuccess: lastSuccess},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAnki(&buf, "Danish", vocabs))
+
+	rows, err := ReadAnki(bytes.NewReader(buf.Bytes()), int64(buf.Len()), nil)
+	require.NoError(t, err)
+	require.Len(t, rows, len(vocabs))
+
+	imported := make([]model.Vocab, len(rows))
+	for i, row := range rows {
+		require.NoError(t, row.Err)
+		requireSameVocab(t, vocabs[i], row.Vocab)
+		imported[i] = row.Vocab
+	}
+
+	// Exporting what was imported and importing it again changes nothing.
+	buf.Reset()
+	require.NoError(t, WriteAnki(&buf, "Danish", imported))
+
+	rows, err = ReadAnki(bytes.NewReader(buf.Bytes()), int64(buf.Len()), nil)
+	require.NoError(t, err)
+	require.Len(t, rows, len(imported))
+	for i, row := range rows {
+		require.NoError(t, row.Err)
+		require.Equal(t, imported[i], row.Vocab)
+	}
+}
+
+// requireSameVocab compares an exported vocab with its import. Anki counts
+// in days, so review times only survive to the day.
+func requireSameVocab(t *testing.T, want, got model.Vocab) {
+	t.Helper()
+
+	require.Equal(t, want.Id, got.Id)
+	require.Equal(t, want.Definition, got.Definition)
+	require.Equal(t, want.PartOfSpeech, got.PartOfSpeech)
+	require.Len(t, got.Forms, len(want.Forms))
+	for i, form := range want.Forms {
+		require.Equal(t, form.Form, got.Forms[i].Form)
+		require.Equal(t, form.Value, got.Forms[i].Value)
+		require.Equal(t, form.Level, got.Forms[i].Level)
+		require.WithinDuration(t, form.LastSuccess, got.Forms[i].LastSuccess, ankiDay)
+	}
+}
+
+func TestReadAnki_Deck(t *testing.T) {
+	crt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A deck as Anki writes it: a "Basic (and reversed card)" note type with
+	// HTML in the fields, one card reviewed with a 7 day interval due on day
+	// 10, and a note of a type the collection does not define.
+	noteType := ankiNoteType{
+		Id:   1700000000000,
+		Name: "Basic (and reversed card)",
+		Flds: []ankiField{{Name: "Front", Ord: 0}, {Name: "Back", Ord: 1}},
+		Tmpls: []ankiTemplate{
+			{Name: "Card 1", Ord: 0, Qfmt: "{{Front}}", Afmt: "{{FrontSide}}<hr id=answer>{{Back}}"},
+			{Name: "Card 2", Ord: 1, Qfmt: "{{Back}}", Afmt: "{{FrontSide}}<hr id=answer>{{Front}}"},
+		},
+	}
+	pkg := ankiPackage(t, "collection.anki21", func(db *sql.DB) {
+		models, err := json.Marshal(map[string]ankiNoteType{"1700000000000": noteType})
+		require.NoError(t, err)
+		exec(t, db, `INSERT INTO col VALUES (1, ?, 0, 0, 11, 0, 0, 0, '{}', ?, '{}', '{}', '{}')`,
+			crt.Unix(), string(models))
+		exec(t, db, `INSERT INTO notes VALUES (1, 'a1', ?, 0, 0, '', ?, '', 0, 0, '')`,
+			noteType.Id, "<b>house</b>\x1fet hus<br>huset")
+		exec(t, db, `INSERT INTO notes VALUES (2, 'b2', 42, 0, 0, '', 'x\x1fy', '', 0, 0, '')`)
+		exec(t, db, `INSERT INTO cards VALUES (10, 1, 1, 0, 0, 0, 2, 2, 10, 7, 2500, 3, 0, 0, 0, 0, 0, '')`)
+		exec(t, db, `INSERT INTO cards VALUES (11, 1, 1, 1, 0, 0, 0, 0, 1, 0, 2500, 0, 0, 0, 0, 0, 0, '')`)
+	})
+
+	mapping := &AnkiMapping{
+		Definition:          "front",
+		DefaultPartOfSpeech: model.PartOfSpeechNoun,
+		Forms:               map[string]string{"singular": "Back"},
+	}
+	rows, err := ReadAnki(bytes.NewReader(pkg), int64(len(pkg)), mapping)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.NoError(t, rows[0].Err)
+	require.Equal(t, model.Vocab{
+		Definition:   "house",
+		PartOfSpeech: model.PartOfSpeechNoun,
+		Forms: []model.VocabForm{{
+			Form:        "singular",
+			Value:       "et hus huset",
+			Level:       3,
+			LastSuccess: crt.Add(3 * ankiDay).Local(),
+		}},
+	}, rows[0].Vocab)
+
+	require.Equal(t, 2, rows[1].Line)
+	require.ErrorContains(t, rows[1].Err, "unknown note type 42")
+
+	// A mapping naming a field the note type lacks rejects its notes.
+	rows, err = ReadAnki(bytes.NewReader(pkg), int64(len(pkg)), &AnkiMapping{Definition: "English"})
+	require.NoError(t, err)
+	require.ErrorContains(t, rows[0].Err, `has no field "English"`)
+}
+
+func TestReadAnki_Malformed(t *testing.T) {
+	var valid bytes.Buffer
+	require.NoError(t, WriteAnki(&valid, "Danish", []model.Vocab{{Id: uuid.New(), Definition: "house"}}))
+
+	tests := []struct {
+		name    string
+		pkg     []byte
+		wantErr string
+	}{
+		{"not a zip", []byte("not a zip file"), "not an Anki package"},
+		{"truncated zip", valid.Bytes()[:valid.Len()/2], "not an Anki package"},
+		{"no collection", zipFile(t, "media", []byte("{}")), "package has no collection"},
+		{"latest format", zipFile(t, "collection.anki21b", []byte("zstd")), "Support older Anki versions"},
+		{"not a database", zipFile(t, "collection.anki2", []byte("garbage")), "could not read collection"},
+		{"database without tables", ankiPackage(t, "collection.anki2", nil), "could not read collection"},
+		{"oversized collection", oversizedZipFile(t, "collection.anki2"), "collection.anki2 is larger than 512 MB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadAnki(bytes.NewReader(tt.pkg), int64(len(tt.pkg)), nil)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// ankiPackage zips a collection created with the Anki schema, filled by
+// fill unless it is nil, in which case the database stays empty.
+func ankiPackage(t *testing.T, name string, fill func(db *sql.DB)) []byte {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "collection")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	if fill != nil {
+		exec(t, db, ankiSchema)
+		fill(db)
+	} else {
+		exec(t, db, `CREATE TABLE other (id integer)`)
+	}
+	require.NoError(t, db.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return zipFile(t, name, data)
+}
+
+func zipFile(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, err := zw.Create(name)
+	require.NoError(t, err)
+	_, err = f.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// oversizedZipFile zips a stored file whose header claims it unpacks past
+// the collection cap.
+func oversizedZipFile(t *testing.T, name string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               name,
+		Method:             zip.Store,
+		CompressedSize64:   4,
+		UncompressedSize64: ankiMaxCollectionSize + 1,
+	})
+	require.NoError(t, err)
+	_, err = f.Write([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestExtract_Limit(t *testing.T) {
+	pkg := zipFile(t, "collection.anki2", bytes.Repeat([]byte("a"), 2048))
+	zr, err := zip.NewReader(bytes.NewReader(pkg), int64(len(pkg)))
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	require.NoError(t, extract(zr.File[0], filepath.Join(dir, "fits"), 2048))
+	require.ErrorContains(t, extract(zr.File[0], filepath.Join(dir, "too large"), 2047), "is larger than")
+}
+
+func exec(t *testing.T, db *sql.DB, query string, args ...any) {
+	t.Helper()
+	_, err := db.ExecContext(context.Background(), query, args...)
+	require.NoError(t, err)
+}
@@ -0,0 +1,362 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+// Progress columns are named after the form column they belong to, e.g.
+// "indefinite_singular_level".
+const (
+	levelSuffix        = "_level"
+	lastSuccessSuffix  = "_last_success"
+	successInRowSuffix = "_success_in_row"
+)
+
+// valueSeparator joins several forms of the same kind in one cell.
+const valueSeparator = "|"
+
+// CSVMapping tells which header names hold which vocab fields. Form columns
+// may be followed by progress columns named <form>_level, <form>_last_success
+// and <form>_success_in_row.
+type CSVMapping struct {
+	Id                  string             `json:"id,omitempty"`
+	Definition          string             `json:"definition"`
+	PartOfSpeech        string             `json:"part_of_speech,omitempty"`
+	DefaultPartOfSpeech model.PartOfSpeech `json:"default_part_of_speech,omitempty"`
+	// Forms maps form kinds to the header of the column holding them.
+	Forms map[string]string `json:"forms"`
+}
+
+// Row is one parsed data row of an import.
+type Row struct {
+	// Line is the 1-based line number in the file.
+	Line  int
+	Vocab model.Vocab
+	Err   error
+}
+
+// ReadCSV parses a header row followed by vocab rows. Without a mapping,
+// the id, definition and part_of_speech columns are used and every other
+// column that is not a progress column is a form named after its header.
+func ReadCSV(r io.Reader, delimiter rune, mapping *CSVMapping) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	// Trimming leading white space would also swallow the empty cells of
+	// a tab separated file; cells are trimmed when they are read anyway.
+	reader.TrimLeadingSpace = !unicode.IsSpace(delimiter)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file is empty")
+		}
+		return nil, fmt.Errorf("could not read header: %w", err)
+	}
+
+	// Spreadsheet exports often start with a byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	columns := map[string]int{}
+	for i, name := range header {
+		name = columnName(name)
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+
+	if mapping == nil {
+		mapping = defaultMapping(columns)
+	}
+	layout, err := newLayout(columns, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, Row{Line: parseErr.StartLine, Err: err})
+				continue
+			}
+			return nil, fmt.Errorf("could not read line %d: %w", line, err)
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		vocab, err := layout.vocab(record)
+		rows = append(rows, Row{Line: line, Vocab: vocab, Err: err})
+	}
+
+	return rows, nil
+}
+
+// WriteCSV writes the vocab with one column per form kind followed by the
+// progress columns, in a format ReadCSV reads back without a mapping.
+func WriteCSV(w io.Writer, delimiter rune, vocabs []model.Vocab) error {
+	var kinds []string
+	for _, vocab := range vocabs {
+		for _, form := range vocab.Forms {
+			if !slices.Contains(kinds, form.Form) {
+				kinds = append(kinds, form.Form)
+			}
+		}
+	}
+
+	header := []string{"id", "definition", "part_of_speech"}
+	header = append(header, kinds...)
+	for _, kind := range kinds {
+		header = append(header, kind+levelSuffix, kind+lastSuccessSuffix, kind+successInRowSuffix)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, vocab := range vocabs {
+		values := make([][]string, len(kinds))
+		levels := make([][]string, len(kinds))
+		lastSuccesses := make([][]string, len(kinds))
+		successesInRow := make([][]string, len(kinds))
+		for _, form := range vocab.Forms {
+			i := slices.Index(kinds, form.Form)
+			values[i] = append(values[i], form.Value)
+			levels[i] = append(levels[i], strconv.Itoa(form.Level))
+			lastSuccess := ""
+			if !form.LastSuccess.IsZero() {
+				lastSuccess = form.LastSuccess.UTC().Format(time.RFC3339)
+			}
+			lastSuccesses[i] = append(lastSuccesses[i], lastSuccess)
+			successesInRow[i] = append(successesInRow[i], strconv.Itoa(form.SuccessInRow))
+		}
+
+		record := []string{vocab.Id.String(), vocab.Definition, string(vocab.PartOfSpeech)}
+		for _, cell := range values {
+			record = append(record, strings.Join(cell, valueSeparator))
+		}
+		for i := range kinds {
+			record = append(record,
+				strings.Join(levels[i], valueSeparator),
+				strings.Join(lastSuccesses[i], valueSeparator),
+				strings.Join(successesInRow[i], valueSeparator),
+			)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func defaultMapping(columns map[string]int) *CSVMapping {
+	mapping := &CSVMapping{Definition: "definition", Forms: map[string]string{}}
+	if _, ok := columns["id"]; ok {
+		mapping.Id = "id"
+	}
+	if _, ok := columns["part_of_speech"]; ok {
+		mapping.PartOfSpeech = "part_of_speech"
+	}
+
+	for name := range columns {
+		switch {
+		case name == "", name == "id", name == "definition", name == "part_of_speech":
+		case strings.HasSuffix(name, levelSuffix),
+			strings.HasSuffix(name, lastSuccessSuffix),
+			strings.HasSuffix(name, successInRowSuffix):
+		default:
+			mapping.Forms[name] = name
+		}
+	}
+
+	return mapping
+}
+
+// layout holds the resolved column indexes of a mapping, -1 when missing.
+type layout struct {
+	id           int
+	definition   int
+	partOfSpeech int
+	defaultPOS   model.PartOfSpeech
+	forms        []formColumns
+}
+
+type formColumns struct {
+	kind         string
+	value        int
+	level        int
+	lastSuccess  int
+	successInRow int
+}
+
+func newLayout(columns map[string]int, mapping *CSVMapping) (*layout, error) {
+	index := func(name string) int {
+		if i, ok := columns[columnName(name)]; ok && name != "" {
+			return i
+		}
+		return -1
+	}
+
+	l := &layout{
+		id:           index(mapping.Id),
+		definition:   index(mapping.Definition),
+		partOfSpeech: index(mapping.PartOfSpeech),
+		defaultPOS:   mapping.DefaultPartOfSpeech,
+	}
+	if l.definition < 0 {
+		return nil, fmt.Errorf("definition column %q not found", mapping.Definition)
+	}
+	if mapping.Id != "" && l.id < 0 {
+		return nil, fmt.Errorf("id column %q not found", mapping.Id)
+	}
+	if mapping.PartOfSpeech != "" && l.partOfSpeech < 0 {
+		return nil, fmt.Errorf("part of speech column %q not found", mapping.PartOfSpeech)
+	}
+
+	kinds := make([]string, 0, len(mapping.Forms))
+	for kind := range mapping.Forms {
+		kinds = append(kinds, kind)
+	}
+	slices.SortFunc(kinds, func(a, b string) int {
+		return index(mapping.Forms[a]) - index(mapping.Forms[b])
+	})
+
+	for _, kind := range kinds {
+		column := mapping.Forms[kind]
+		value := index(column)
+		if value < 0 {
+			return nil, fmt.Errorf("form column %q not found", column)
+		}
+		l.forms = append(l.forms, formColumns{
+			kind:         kind,
+			value:        value,
+			level:        index(column + levelSuffix),
+			lastSuccess:  index(column + lastSuccessSuffix),
+			successInRow: index(column + successInRowSuffix),
+		})
+	}
+
+	return l, nil
+}
+
+func (l *layout) vocab(record []string) (model.Vocab, error) {
+	cell := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	vocab := model.Vocab{
+		Definition:   cell(l.definition),
+		PartOfSpeech: model.PartOfSpeech(strings.ToLower(cell(l.partOfSpeech))),
+		Forms:        []model.VocabForm{},
+	}
+	if vocab.PartOfSpeech == "" {
+		vocab.PartOfSpeech = l.defaultPOS
+	}
+
+	if id := cell(l.id); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return vocab, fmt.Errorf("invalid id %q", id)
+		}
+		vocab.Id = parsed
+	}
+
+	for _, columns := range l.forms {
+		if cell(columns.value) == "" {
+			continue
+		}
+
+		values := strings.Split(cell(columns.value), valueSeparator)
+		levels := strings.Split(cell(columns.level), valueSeparator)
+		lastSuccesses := strings.Split(cell(columns.lastSuccess), valueSeparator)
+		successesInRow := strings.Split(cell(columns.successInRow), valueSeparator)
+
+		for i, value := range values {
+			form := model.VocabForm{Value: strings.TrimSpace(value), Form: columns.kind}
+
+			if s := part(levels, i); s != "" {
+				level, err := strconv.Atoi(s)
+				if err != nil {
+					return vocab, fmt.Errorf("invalid %s level %q", columns.kind, s)
+				}
+				form.Level = level
+			}
+			if s := part(lastSuccesses, i); s != "" {
+				lastSuccess, err := time.Parse(time.RFC3339, s)
+				if err != nil {
+					return vocab, fmt.Errorf("invalid %s last success %q", columns.kind, s)
+				}
+				form.LastSuccess = lastSuccess
+			}
+			if s := part(successesInRow, i); s != "" {
+				successInRow, err := strconv.Atoi(s)
+				if err != nil {
+					return vocab, fmt.Errorf("invalid %s success in row %q", columns.kind, s)
+				}
+				form.SuccessInRow = successInRow
+			}
+
+			vocab.Forms = append(vocab.Forms, form)
+		}
+	}
+
+	return vocab, nil
+}
+
+func columnName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func part(parts []string, i int) string {
+	if i >= len(parts) {
+		return ""
+	}
+	return strings.TrimSpace(parts[i])
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Delimiter returns the field delimiter of a "csv" or "tsv" format name.
+func Delimiter(format string) (rune, error) {
+	switch strings.ToLower(format) {
+	case "", "csv":
+		return ',', nil
+	case "tsv":
+		return '\t', nil
+	default:
+		return 0, fmt.Errorf("unsupported format %q", format)
+	}
+}
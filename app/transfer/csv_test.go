@@ -0,0 +1,167 @@
+package transfer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vladazn/danish/app/model"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	lastSuccess := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	vocabs := []model.Vocab{
+		{
+			Id:           uuid.New(),
+			Definition:   "house, home",
+			PartOfSpeech: model.PartOfSpeechNoun,
+			Forms: []model.VocabForm{
+				{Form: "singular", Value: "et hus", Level: 3, LastSuccess: lastSuccess, SuccessInRow: 2},
+				{Form: "plural", Value: "huse"},
+				{Form: "plural", Value: "husene", Level: 1},
+			},
+		},
+		{
+			Id:           uuid.New(),
+			Definition:   `to say "hello"`,
+			PartOfSpeech: model.PartOfSpeechVerb,
+			Forms: []model.VocabForm{
+				{Form: "infinitive", Value: "at sige\nhej"},
+			},
+		},
+		{
+			Id:         uuid.New(),
+			Definition: "no forms",
+			Forms:      []model.VocabForm{},
+		},
+	}
+
+	for _, format := range []string{"csv", "tsv"} {
+		t.Run(format, func(t *testing.T) {
+			delimiter, err := Delimiter(format)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, WriteCSV(&buf, delimiter, vocabs))
+
+			rows, err := ReadCSV(&buf, delimiter, nil)
+			require.NoError(t, err)
+			require.Len(t, rows, len(vocabs))
+			for i, row := range rows {
+				require.NoError(t, row.Err)
+				require.Equal(t, vocabs[i], row.Vocab)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		delimiter rune
+		mapping   *CSVMapping
+		want      []model.Vocab
+		wantErrs  []int
+	}{
+		{
+			name:      "quoted delimiters and quotes",
+			input:     "definition,indefinite\n\"bread, loaf\",\"et \"\"brød\"\"\"\n",
+			delimiter: ',',
+			want: []model.Vocab{{
+				Definition: "bread, loaf",
+				Forms:      []model.VocabForm{{Form: "indefinite", Value: `et "brød"`}},
+			}},
+		},
+		{
+			name:      "tabs keep commas",
+			input:     "definition\tindefinite\nbread, loaf\tet brød\n",
+			delimiter: '\t',
+			want: []model.Vocab{{
+				Definition: "bread, loaf",
+				Forms:      []model.VocabForm{{Form: "indefinite", Value: "et brød"}},
+			}},
+		},
+		{
+			name:      "byte order mark, header case and blank lines",
+			input:     "\ufeffDefinition , Part_Of_Speech\n\n bread ,NOUN\n,\n",
+			delimiter: ',',
+			want: []model.Vocab{{
+				Definition:   "bread",
+				PartOfSpeech: model.PartOfSpeechNoun,
+				Forms:        []model.VocabForm{},
+			}},
+		},
+		{
+			name:      "mapping",
+			input:     "English;Dansk;Flertal\nhouse;hus;huse\n",
+			delimiter: ';',
+			mapping: &CSVMapping{
+				Definition:          "english",
+				DefaultPartOfSpeech: model.PartOfSpeechNoun,
+				Forms:               map[string]string{"singular": "Dansk", "plural": "Flertal"},
+			},
+			want: []model.Vocab{{
+				Definition:   "house",
+				PartOfSpeech: model.PartOfSpeechNoun,
+				Forms: []model.VocabForm{
+					{Form: "singular", Value: "hus"},
+					{Form: "plural", Value: "huse"},
+				},
+			}},
+		},
+		{
+			name:      "bad rows are reported by line",
+			input:     "id,definition,form,form_level\nnot-an-id,house,hus,\n,tree,træ,x\n,bread,\"brød\n",
+			delimiter: ',',
+			wantErrs:  []int{2, 3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadCSV(strings.NewReader(tt.input), tt.delimiter, tt.mapping)
+			require.NoError(t, err)
+
+			if tt.wantErrs != nil {
+				var lines []int
+				for _, row := range rows {
+					require.Error(t, row.Err)
+					lines = append(lines, row.Line)
+				}
+				require.Equal(t, tt.wantErrs, lines)
+				return
+			}
+
+			var vocabs []model.Vocab
+			for _, row := range rows {
+				require.NoError(t, row.Err)
+				vocabs = append(vocabs, row.Vocab)
+			}
+			require.Equal(t, tt.want, vocabs)
+		})
+	}
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader(""), ',', nil)
+	require.ErrorContains(t, err, "empty")
+
+	_, err = ReadCSV(strings.NewReader("word,meaning\n"), ',', nil)
+	require.ErrorContains(t, err, `definition column "definition" not found`)
+
+	_, err = ReadCSV(strings.NewReader("definition\n"), ',', &CSVMapping{
+		Definition: "definition",
+		Forms:      map[string]string{"singular": "dansk"},
+	})
+	require.ErrorContains(t, err, `form column "dansk" not found`)
+}
+
+func TestDelimiter(t *testing.T) {
+	_, err := Delimiter("xlsx")
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package transfer
+
+import (
+	"github.com/vladazn/danish/app/model"
+)
+
+// ImportReport summarizes an import of a file.
+type ImportReport struct {
+	DryRun   bool                 `json:"dry_run"`
+	Created  int                  `json:"created"`
+	Merged   int                  `json:"merged"`
+	Rejected int                  `json:"rejected"`
+	Results  []model.ImportResult `json:"results"`
+}
+
+// Valid returns the vocab of the rows that parsed without errors.
+func Valid(rows []Row) []model.Vocab {
+	vocabs := make([]model.Vocab, 0, len(rows))
+	for _, row := range rows {
+		if row.Err == nil {
+			vocabs = append(vocabs, row.Vocab)
+		}
+	}
+	return vocabs
+}
+
+// NewImportReport combines the parsed rows with the results of importing
+// their valid vocab, which must be in the order returned by Valid. Rows that
+// did not parse are reported as rejected.
+func NewImportReport(rows []Row, results []model.ImportResult, dryRun bool) ImportReport {
+	report := ImportReport{DryRun: dryRun, Results: make([]model.ImportResult, 0, len(rows))}
+
+	next := 0
+	for i, row := range rows {
+		result := model.ImportResult{Status: model.ImportStatusRejected}
+		if row.Err != nil {
+			result.Reason = row.Err.Error()
+		} else if next < len(results) {
+			result = results[next]
+			next++
+		}
+		result.Index = i
+		result.Line = row.Line
+
+		switch result.Status {
+		case model.ImportStatusCreated:
+			report.Created++
+		case model.ImportStatusMerged:
+			report.Merged++
+		default:
+			report.Rejected++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+// FromCtx returns the logger of the context, or the global logger if the
+// context has none.
+func FromCtx(ctx context.Context) *zap.Logger {
+	if log, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return log
+	}
+	return zap.L()
+}
+
+// ToCtx adds the logger to the context. Code handling a request logs with
+// FromCtx, so every entry carries the request's fields.
+func ToCtx(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
@@ -0,0 +1,52 @@
+package mergepatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestApply runs the examples of RFC 7396 appendix A.
+func TestApply(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.doc+" "+tt.patch, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApply_KeepsNumbers(t *testing.T) {
+	got, err := Apply([]byte(`{"a":12345678901234567890}`), []byte(`{"b":1.50}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":12345678901234567890,"b":1.50}`, string(got))
+}
+
+func TestApply_InvalidJSON(t *testing.T) {
+	_, err := Apply([]byte(`{`), []byte(`{}`))
+	require.ErrorContains(t, err, "invalid document")
+
+	_, err = Apply([]byte(`{}`), []byte(`{"a":`))
+	require.ErrorContains(t, err, "invalid merge patch")
+}
@@ -0,0 +1,39 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+)
+
+// maxLength bounds ids taken from clients.
+const maxLength = 128
+
+type requestIdKey struct{}
+
+// FromCtx returns the request id of the context, or "" outside a request.
+func FromCtx(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
+
+// ToCtx adds the request id to the context.
+func ToCtx(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// Valid reports whether a request id from a client is safe to log and
+// echo: short and made of URL-safe characters.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("-_.:", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
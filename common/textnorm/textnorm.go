@@ -0,0 +1,59 @@
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Danish letters have two common ASCII spellings: the transliteration
+// (æ -> ae, ø -> oe, å -> aa) and the bare base letter (ø -> o, å -> a).
+var (
+	transliteration = strings.NewReplacer("æ", "ae", "ø", "oe", "å", "aa")
+	baseLetters     = strings.NewReplacer("æ", "ae", "ø", "o", "å", "a")
+)
+
+// Tokens splits s into lowercase words.
+func Tokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(norm.NFC.String(s)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	})
+}
+
+// Variants returns the distinct accent-free spellings of a lowercase token,
+// so "hús" becomes ["hus"] and "før" becomes ["foer", "for"].
+func Variants(token string) []string {
+	translit := stripMarks(transliteration.Replace(token))
+	base := stripMarks(baseLetters.Replace(token))
+	if translit == base {
+		return []string{translit}
+	}
+
+	return []string{translit, base}
+}
+
+// Normalize returns a canonical form of s for equality checks: lowercase,
+// accent-free, transliterated and with words separated by single spaces.
+func Normalize(s string) string {
+	tokens := Tokens(s)
+	for i, token := range tokens {
+		tokens[i] = stripMarks(transliteration.Replace(token))
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// stripMarks removes combining accents. Danish letters must be replaced
+// before, since å would otherwise decompose into a plain a.
+func stripMarks(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{" ,. ", []string{}},
+		{"Et Hus", []string{"et", "hus"}},
+		{"at gå, at løbe!", []string{"at", "gå", "at", "løbe"}},
+		{"hus-dør", []string{"hus", "dør"}},
+		{"1. maj", []string{"1", "maj"}},
+		{"ÆBLE", []string{"æble"}},
+		// A decomposed å is composed before splitting.
+		{"går", []string{"går"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, Tokens(tt.in))
+		})
+	}
+}
+
+func TestVariants(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"hus", []string{"hus"}},
+		{"hús", []string{"hus"}},
+		{"café", []string{"cafe"}},
+		{"æble", []string{"aeble"}},
+		{"før", []string{"foer", "for"}},
+		{"gå", []string{"gaa", "ga"}},
+		{"blåbær", []string{"blaabaer", "blabaer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, Variants(tt.in))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"  Et   Hus ", "et hus"},
+		{"Æble", "aeble"},
+		{"Før", "foer"},
+		{"Gå", "gaa"},
+		{"gå", "gaa"},
+		{"Café, crème", "cafe creme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, Normalize(tt.in))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Package validate collects the rules a value breaks, so a request can be
+// rejected with all of its problems at once.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Violation is a rule a field does not satisfy. Field is a path like
+// "forms[0].value"; Message completes a sentence starting with the field.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return v.Message
+	}
+	return v.Field + " " + v.Message
+}
+
+// Error lists every violation found in a value.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validator collects violations. The zero value is ready to use.
+type Validator struct {
+	violations []Violation
+}
+
+// Add records a violation of field.
+func (v *Validator) Add(field string, message string, args ...any) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	v.violations = append(v.violations, Violation{Field: field, Message: message})
+}
+
+// Check records a violation of field unless ok.
+func (v *Validator) Check(ok bool, field string, message string, args ...any) {
+	if !ok {
+		v.Add(field, message, args...)
+	}
+}
+
+// Required checks that value is not blank.
+func (v *Validator) Required(field string, value string) {
+	v.Check(strings.TrimSpace(value) != "", field, "is required")
+}
+
+// MaxLength checks that value has at most max characters.
+func (v *Validator) MaxLength(field string, value string, max int) {
+	v.Check(utf8.RuneCountInString(value) <= max, field, "must have at most %d characters", max)
+}
+
+// Range checks that value is between min and max, inclusive.
+func (v *Validator) Range(field string, value int, min int, max int) {
+	v.Check(value >= min && value <= max, field, "must be between %d and %d", min, max)
+}
+
+// Items checks that a list has between min and max items.
+func (v *Validator) Items(field string, n int, min int, max int) {
+	switch {
+	case n < min && min == 1:
+		v.Add(field, "is required")
+	case n < min:
+		v.Add(field, "must have at least %d items", min)
+	case n > max:
+		v.Add(field, "must have at most %d items", max)
+	}
+}
+
+// Nested records the violations of a nested value's validation under
+// field. Errors that are not an *Error are recorded as one violation.
+func (v *Validator) Nested(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	var validationErr *Error
+	if !errors.As(err, &validationErr) {
+		v.Add(field, err.Error())
+		return
+	}
+	for _, violation := range validationErr.Violations {
+		violation.Field = Join(field, violation.Field)
+		v.violations = append(v.violations, violation)
+	}
+}
+
+// Err returns an *Error with the violations found, or nil if there are
+// none.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &Error{Violations: v.violations}
+}
+
+// Index returns the path of item i of a list field.
+func Index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
+
+// Join returns the path of a field nested in parent.
+func Join(parent string, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "":
+		return parent
+	default:
+		return parent + "." + field
+	}
+}
@@ -0,0 +1,154 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(v *Validator)
+		want  []Violation
+	}{
+		{
+			name:  "nothing checked",
+			check: func(v *Validator) {},
+		},
+		{
+			name: "add formats the message",
+			check: func(v *Validator) {
+				v.Add("level", "must be %s", "A1")
+			},
+			want: []Violation{{Field: "level", Message: "must be A1"}},
+		},
+		{
+			name: "add keeps a message without arguments",
+			check: func(v *Validator) {
+				v.Add("name", "is 100% wrong")
+			},
+			want: []Violation{{Field: "name", Message: "is 100% wrong"}},
+		},
+		{
+			name: "check",
+			check: func(v *Validator) {
+				v.Check(true, "ok", "is wrong")
+				v.Check(false, "bad", "is wrong")
+			},
+			want: []Violation{{Field: "bad", Message: "is wrong"}},
+		},
+		{
+			name: "required",
+			check: func(v *Validator) {
+				v.Required("set", "x")
+				v.Required("empty", "")
+				v.Required("blank", " \t")
+			},
+			want: []Violation{
+				{Field: "empty", Message: "is required"},
+				{Field: "blank", Message: "is required"},
+			},
+		},
+		{
+			name: "max length counts characters",
+			check: func(v *Validator) {
+				v.MaxLength("short", "blåbær", 6)
+				v.MaxLength("long", "blåbærs", 6)
+			},
+			want: []Violation{{Field: "long", Message: "must have at most 6 characters"}},
+		},
+		{
+			name: "range",
+			check: func(v *Validator) {
+				v.Range("min", 1, 1, 5)
+				v.Range("max", 5, 1, 5)
+				v.Range("below", 0, 1, 5)
+				v.Range("above", 6, 1, 5)
+			},
+			want: []Violation{
+				{Field: "below", Message: "must be between 1 and 5"},
+				{Field: "above", Message: "must be between 1 and 5"},
+			},
+		},
+		{
+			name: "items",
+			check: func(v *Validator) {
+				v.Items("fits", 2, 1, 3)
+				v.Items("missing", 0, 1, 3)
+				v.Items("few", 1, 2, 3)
+				v.Items("many", 4, 1, 3)
+			},
+			want: []Violation{
+				{Field: "missing", Message: "is required"},
+				{Field: "few", Message: "must have at least 2 items"},
+				{Field: "many", Message: "must have at most 3 items"},
+			},
+		},
+		{
+			name: "nested violations",
+			check: func(v *Validator) {
+				var nested Validator
+				nested.Required("value", "")
+				nested.Add("", "is a duplicate")
+				v.Nested(Index("forms", 1), nested.Err())
+			},
+			want: []Violation{
+				{Field: "forms[1].value", Message: "is required"},
+				{Field: "forms[1]", Message: "is a duplicate"},
+			},
+		},
+		{
+			name: "nested plain error",
+			check: func(v *Validator) {
+				v.Nested("forms", errors.New("are not unique"))
+			},
+			want: []Violation{{Field: "forms", Message: "are not unique"}},
+		},
+		{
+			name: "nested nil",
+			check: func(v *Validator) {
+				v.Nested("forms", nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Validator
+			tt.check(&v)
+
+			err := v.Err()
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *Error
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.want, validationErr.Violations)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Violations: []Violation{
+		{Field: "name", Message: "is required"},
+		{Message: "the body is empty"},
+	}}
+	require.Equal(t, "name is required; the body is empty", err.Error())
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		parent, field, want string
+	}{
+		{"", "", ""},
+		{"", "name", "name"},
+		{"forms", "", "forms"},
+		{"forms[0]", "value", "forms[0].value"},
+		{Index("vocabs", 2), Index("forms", 0), "vocabs[2].forms[0]"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, Join(tt.parent, tt.field))
+	}
+}
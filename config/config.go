@@ -3,7 +3,7 @@ package config
 import (
 	"encoding/base64"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"go.uber.org/fx"
@@ -11,62 +11,185 @@ import (
 
 type Config struct {
 	fx.Out
-	FirebaseConfig   FirebaseConfig   `envPrefix:"FIREBASE_"`
-	LogConfig        LogConfig        `envPrefix:"LOG_"`
-	HttpServerConfig HttpServerConfig `envPrefix:"HTTP_SERVER_"`
+	FirebaseConfig      FirebaseConfig      `envPrefix:"FIREBASE_"`
+	LogConfig           LogConfig           `envPrefix:"LOG_"`
+	HttpServerConfig    HttpServerConfig    `envPrefix:"HTTP_SERVER_"`
+	StorageConfig       StorageConfig       `envPrefix:"STORAGE_"`
+	AuthConfig          AuthConfig          `envPrefix:"AUTH_"`
+	TracingConfig       TracingConfig       `envPrefix:"TRACING_"`
+	RateLimitConfig     RateLimitConfig     `envPrefix:"RATE_LIMIT_"`
+	HealthConfig        HealthConfig        `envPrefix:"HEALTH_"`
+	GRPCServerConfig    GRPCServerConfig    `envPrefix:"GRPC_SERVER_"`
+	MetricsServerConfig MetricsServerConfig `envPrefix:"METRICS_SERVER_"`
 }
 
 type Result struct {
 	fx.Out
-	FirebaseConfig   *FirebaseConfig
-	LogConfig        *LogConfig
-	HttpServerConfig *HttpServerConfig
+	FirebaseConfig      *FirebaseConfig
+	LogConfig           *LogConfig
+	HttpServerConfig    *HttpServerConfig
+	StorageConfig       *StorageConfig
+	AuthConfig          *AuthConfig
+	TracingConfig       *TracingConfig
+	RateLimitConfig     *RateLimitConfig
+	HealthConfig        *HealthConfig
+	GRPCServerConfig    *GRPCServerConfig
+	MetricsServerConfig *MetricsServerConfig
 }
 
 type HttpServerConfig struct {
 	Port int `env:"PORT" envDefault:"8080"`
 }
 
+// GRPCServerConfig configures the gRPC API, which is served next to HTTP
+// unless it is disabled.
+type GRPCServerConfig struct {
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+	Port    int  `env:"PORT" envDefault:"9090"`
+}
+
+// MetricsServerConfig configures the internal server Prometheus scrapes.
+// It listens on its own port so the metrics are not served to the public
+// next to the API.
+type MetricsServerConfig struct {
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+	Port    int  `env:"PORT" envDefault:"9100"`
+}
+
+// HealthConfig tunes the readiness probe. Every dependency check gets
+// CheckTimeout, and their results are reused for CacheTTL. On shutdown the
+// probe fails for DrainDelay before the server stops, so load balancers
+// stop sending traffic first.
+type HealthConfig struct {
+	CheckTimeout time.Duration `env:"CHECK_TIMEOUT" envDefault:"2s"`
+	CacheTTL     time.Duration `env:"CACHE_TTL" envDefault:"5s"`
+	DrainDelay   time.Duration `env:"DRAIN_DELAY" envDefault:"5s"`
+}
+
+const (
+	StorageBackendFirestore = "firestore"
+	StorageBackendLocal     = "local"
+)
+
+// StorageConfig selects where user data is kept. The local backend stores
+// JSON files under LocalPath. With MigrateOnStart the server brings data
+// written by older versions up to date in the background after starting.
+type StorageConfig struct {
+	Backend        string `env:"BACKEND" envDefault:"firestore"`
+	LocalPath      string `env:"LOCAL_PATH" envDefault:"data"`
+	MigrateOnStart bool   `env:"MIGRATE_ON_START" envDefault:"true"`
+}
+
+const (
+	AuthVerifierFirebase = "firebase"
+	AuthVerifierJWT      = "jwt"
+)
+
+// AuthConfig holds the authentication and authorization settings.
+// Verifier selects who checks bearer tokens: Firebase, or the local JWT
+// issuer meant for development and tests. AdminIds are always treated as
+// admins, so a fresh deployment has someone to hand out roles.
+type AuthConfig struct {
+	Verifier string    `env:"VERIFIER" envDefault:"firebase"`
+	JWT      JWTConfig `envPrefix:"JWT_"`
+	AdminIds []string  `env:"ADMIN_IDS" envSeparator:","`
+}
+
+// JWTConfig configures locally issued tokens. HS256 signs with Secret,
+// RS256 with the PEM key in PrivateKeyFile; verifying RS256 only needs
+// PublicKeyFile.
+type JWTConfig struct {
+	Algorithm      string        `env:"ALGORITHM" envDefault:"HS256"`
+	Secret         Secret        `env:"SECRET"`
+	PrivateKeyFile string        `env:"PRIVATE_KEY_FILE"`
+	PublicKeyFile  string        `env:"PUBLIC_KEY_FILE"`
+	Issuer         string        `env:"ISSUER" envDefault:"danish-dev"`
+	TTL            time.Duration `env:"TTL" envDefault:"24h"`
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig selects where OpenTelemetry spans go. The OTLP exporter
+// sends them over gRPC to Endpoint; SampleRatio is the share of new traces
+// that are recorded, traces started by a caller follow its decision.
+type TracingConfig struct {
+	Exporter     string  `env:"EXPORTER" envDefault:"none"`
+	OTLPEndpoint string  `env:"OTLP_ENDPOINT" envDefault:"localhost:4317"`
+	OTLPInsecure bool    `env:"OTLP_INSECURE"`
+	SampleRatio  float64 `env:"SAMPLE_RATIO" envDefault:"1"`
+	ServiceName  string  `env:"SERVICE_NAME" envDefault:"danish"`
+}
+
+const RateLimitBackendMemory = "memory"
+
+// RateLimitConfig sets the request budgets, written as <requests>/<period>
+// like 30/1m. IP applies to every client address before authentication,
+// User to every user afterwards. Routes replaces User on single routes,
+// keyed by method and route pattern as in "POST /vocab=30/1m". With
+// TrustForwarded the client address is taken from X-Forwarded-For, which
+// is only safe behind proxies that append to it; TrustedProxies is how
+// many of them are in front of the server.
+type RateLimitConfig struct {
+	Enabled        bool              `env:"ENABLED" envDefault:"true"`
+	Backend        string            `env:"BACKEND" envDefault:"memory"`
+	IP             string            `env:"IP" envDefault:"300/1m"`
+	User           string            `env:"USER" envDefault:"120/1m"`
+	Routes         map[string]string `env:"ROUTES" envKeyValSeparator:"=" envDefault:"POST /vocab=30/1m,GET /classroom/batch=30/1m,GET /classroom/sets/{setId}/batch=30/1m"`
+	TrustForwarded bool              `env:"TRUST_FORWARDED"`
+	TrustedProxies int               `env:"TRUSTED_PROXIES" envDefault:"1"`
+}
+
+// LogConfig sets the log level and the sampling of access logs: of the
+// requests in a second, the first AccessSampleFirst are logged and then
+// every AccessSampleThereafter-th. Failed requests are always logged.
 type LogConfig struct {
-	Level string `env:"LEVEL" envDefault:"info"`
+	Level                  string `env:"LEVEL" envDefault:"info"`
+	AccessSampleFirst      int    `env:"ACCESS_SAMPLE_FIRST" envDefault:"100"`
+	AccessSampleThereafter int    `env:"ACCESS_SAMPLE_THEREAFTER" envDefault:"10"`
 }
 
 type FirebaseConfig struct {
-	AuthJSONBase64 string `env:"AUTH_JSON_BASE64"`
-	AuthJSON       []byte
+	AuthJSONBase64 Secret `env:"AUTH_JSON_BASE64"`
+	AuthJSON       Secret
 	ProjectId      string `env:"PROJECT_ID"`
 }
 
 func NewConfig() (Result, error) {
 	cfg, err := env.ParseAs[Config]()
-	_ = cfg
 	if err != nil {
 		return Result{}, fmt.Errorf("could not parse config: %w", err)
 	}
 
-	fmt.Println(os.Getenv("FIREBASE_PROJECT_ID"))
-
-	fmt.Println(cfg)
-
 	err = parseAuthJson(&cfg)
 	if err != nil {
 		return Result{}, err
 	}
 
 	return Result{
-		FirebaseConfig:   &cfg.FirebaseConfig,
-		LogConfig:        &cfg.LogConfig,
-		HttpServerConfig: &cfg.HttpServerConfig,
+		FirebaseConfig:      &cfg.FirebaseConfig,
+		LogConfig:           &cfg.LogConfig,
+		HttpServerConfig:    &cfg.HttpServerConfig,
+		StorageConfig:       &cfg.StorageConfig,
+		AuthConfig:          &cfg.AuthConfig,
+		TracingConfig:       &cfg.TracingConfig,
+		RateLimitConfig:     &cfg.RateLimitConfig,
+		HealthConfig:        &cfg.HealthConfig,
+		GRPCServerConfig:    &cfg.GRPCServerConfig,
+		MetricsServerConfig: &cfg.MetricsServerConfig,
 	}, nil
 }
 
 func parseAuthJson(cfg *Config) error {
-	data, err := base64.StdEncoding.DecodeString(cfg.FirebaseConfig.AuthJSONBase64)
+	data, err := base64.StdEncoding.DecodeString(cfg.FirebaseConfig.AuthJSONBase64.Value())
 	if err != nil {
 		return fmt.Errorf("firebase auth json base64 decoding failed: %w", err)
 	}
 
-	cfg.FirebaseConfig.AuthJSON = data
+	cfg.FirebaseConfig.AuthJSON = Secret(data)
 
 	return nil
 }
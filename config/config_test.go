@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,3 +16,35 @@ func TestNewConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "123", cfg.FirebaseConfig.ProjectId)
 }
+
+func TestSecret(t *testing.T) {
+	t.Setenv("AUTH_JWT_SECRET", "hunter2")
+
+	cfg, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", cfg.AuthConfig.JWT.Secret.Value())
+
+	for _, printed := range []string{
+		fmt.Sprint(*cfg.AuthConfig),
+		fmt.Sprintf("%+v", *cfg.AuthConfig),
+		fmt.Sprintf("%#v", *cfg.AuthConfig),
+	} {
+		require.NotContains(t, printed, "hunter2")
+		require.Contains(t, printed, "[REDACTED]")
+	}
+
+	encoded, err := json.Marshal(cfg.AuthConfig)
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "hunter2")
+}
+
+func TestRateLimitRoutes(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ROUTES", "POST /vocab=2/1m,GET /classroom/sets/{setId}/batch=5/10s")
+
+	cfg, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"POST /vocab":                       "2/1m",
+		"GET /classroom/sets/{setId}/batch": "5/10s",
+	}, cfg.RateLimitConfig.Routes)
+}
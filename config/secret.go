@@ -0,0 +1,27 @@
+package config
+
+// redacted replaces secrets when config values are printed or logged.
+const redacted = "[REDACTED]"
+
+// Secret is a config value that must not end up in logs. It prints and
+// marshals as [REDACTED]; Value returns the real value.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return `"` + s.String() + `"`
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -2,8 +2,12 @@ package main
 
 import "github.com/vladazn/danish/app"
 
-//go:generate go run github.com/swaggo/swag/cmd/swag@latest init -o ./docs
+//go:generate go run github.com/swaggo/swag/cmd/swag@latest init -o ./docs/v1 --instanceName v1 --tags !health
 
+// @title Danish API
+// @version 1.0
+// @description Vocabulary, review and classroom API. Routes outside /v1 are deprecated aliases of the same API.
+// @BasePath /v1
 func main() {
 	app.Run()
 }